@@ -0,0 +1,20 @@
+package p2p
+
+// BroadcastResult defines the outcome of a non-blocking broadcast
+type BroadcastResult string
+
+const (
+	// BroadcastQueued - the message was queued on the outgoing channel and will be sent
+	BroadcastQueued BroadcastResult = "queued"
+	// BroadcastDroppedQueueFull - the message was dropped because the queue of the outgoing channel is full
+	BroadcastDroppedQueueFull BroadcastResult = "dropped: queue full"
+	// BroadcastDroppedUnknownTopic - the message was dropped because the topic was not created
+	BroadcastDroppedUnknownTopic BroadcastResult = "dropped: unknown topic"
+	// BroadcastDroppedInvalidData - the message was dropped because it is empty or too large
+	BroadcastDroppedInvalidData BroadcastResult = "dropped: invalid data"
+)
+
+// IsQueued returns true if the message was queued for sending
+func (result BroadcastResult) IsQueued() bool {
+	return result == BroadcastQueued
+}
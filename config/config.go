@@ -4,7 +4,14 @@ package config
 type P2PConfig struct {
 	Node                NodeConfig
 	KadDhtPeerDiscovery KadDhtPeerDiscoveryConfig
+	MdnsPeerDiscovery   MdnsPeerDiscoveryConfig
+	StaticPeerDiscovery StaticPeerDiscoveryConfig
 	Sharding            ShardingConfig
+	Antiflood           AntifloodConfig
+	ConnectionGater     ConnectionGaterConfig
+	AddressDenial       AddressDenialConfig
+	DirectSend          DirectSendConfig
+	TopicCompression    TopicCompressionConfig
 }
 
 // NodeConfig will hold basic p2p settings
@@ -39,6 +46,32 @@ type KadDhtPeerDiscoveryConfig struct {
 	InitialPeerList                  []string
 	BucketSize                       uint32
 	RoutingTableRefreshIntervalInSec uint32
+	RoutingTableSnapshot             RoutingTableSnapshotConfig
+}
+
+// RoutingTableSnapshotConfig will hold the settings of the kad-dht routing table snapshot, a local file storing the
+// routing table peers and their addresses, saved periodically and on close, and dialed on the next bootstrap
+type RoutingTableSnapshotConfig struct {
+	Enabled           bool
+	FilePath          string
+	SaveIntervalInSec uint32
+	EntryTTLInSec     uint32
+}
+
+// MdnsPeerDiscoveryConfig will hold the multicast DNS peer discovery config settings, meant for local networks
+type MdnsPeerDiscoveryConfig struct {
+	Enabled     bool
+	ServiceName string
+}
+
+// StaticPeerDiscoveryConfig will hold the settings of the discovery mechanism that keeps connected a fixed set of
+// preferred peers, read from PeerList and from the optional PeerListFile (one address per line, reloaded on change)
+type StaticPeerDiscoveryConfig struct {
+	Enabled               bool
+	PeerList              []string
+	PeerListFile          string
+	RefreshIntervalInSec  uint32
+	MaxRedialBackoffInSec uint32
 }
 
 // ShardingConfig will hold the network sharding config settings
@@ -51,6 +84,7 @@ type ShardingConfig struct {
 	MaxSeeders              uint32
 	Type                    string
 	AdditionalConnections   AdditionalConnectionsConfig
+	EvictionScoring         EvictionScoringConfig
 }
 
 // AdditionalConnectionsConfig will hold the additional connections that will be open when certain conditions are met
@@ -58,3 +92,94 @@ type ShardingConfig struct {
 type AdditionalConnectionsConfig struct {
 	MaxFullHistoryObservers uint32
 }
+
+// AntifloodConfig will hold the settings of the token-bucket limits applied on the received messages
+// A value of 0 for a limit disables that limit
+type AntifloodConfig struct {
+	Enabled                     bool
+	PeerMaxMessagesPerSec       uint32
+	PeerMaxBytesPerSec          uint64
+	OriginatorMaxMessagesPerSec uint32
+	OriginatorMaxBytesPerSec    uint64
+	TopicMaxMessagesPerSec      uint32
+	TopicMaxBytesPerSec         uint64
+	TopicTotalMaxMessagesPerSec uint32
+	TopicTotalMaxBytesPerSec    uint64
+	Topics                      []TopicAntifloodConfig
+	NumViolationsBeforeDenial   uint32
+	ViolationsWindowInSec       uint32
+	DenialDurationInSec         uint32
+}
+
+// TopicAntifloodConfig will hold the limits for a specific topic: the MaxMessagesPerSec and MaxBytesPerSec limits are
+// applied for each connected peer while the Total limits are applied on all the messages received on the topic
+// These values override the default topic limits
+type TopicAntifloodConfig struct {
+	Name                   string
+	MaxMessagesPerSec      uint32
+	MaxBytesPerSec         uint64
+	TotalMaxMessagesPerSec uint32
+	TotalMaxBytesPerSec    uint64
+}
+
+// RatingPolicyConfig will hold the settings used to compute the peers rating
+// A zero value config takes the default values: ratings in [-100, 100], top rated tier from 0, increase weights of 2
+// (1 for valid requests), decrease weights of 1 (10 for invalid data) and no decay. Otherwise, all the fields are used
+// as they are, a zero weight meaning that the reason does not change the rating
+type RatingPolicyConfig struct {
+	MinRating                 int32
+	MaxRating                 int32
+	TopRatedTierThreshold     int32
+	IncreaseWeight            uint32
+	DecreaseWeight            uint32
+	ValidGossipWeight         uint32
+	ValidDirectResponseWeight uint32
+	ValidRequestWeight        uint32
+	TimeoutWeight             uint32
+	InvalidDataWeight         uint32
+	FloodingWeight            uint32
+	DecayIntervalInSec        uint32
+	DecayStep                 uint32
+}
+
+// ConnectionGaterConfig will hold the addresses that are not allowed to connect to or from the host
+// DeniedIPs contains single IPv4 or IPv6 addresses, DeniedSubnets contains CIDR notations (e.g. 10.0.0.0/8)
+type ConnectionGaterConfig struct {
+	DeniedIPs     []string
+	DeniedSubnets []string
+}
+
+// AddressDenialConfig will hold the settings of the IP based peer denial
+// A value of 0 for a limit disables that limit. The subnets are computed using the IPv4SubnetMaskBits and
+// IPv6SubnetMaskBits values. The IPs of the denied peers are banned for DeniedPeerIPBanDurationInSec, if not 0
+type AddressDenialConfig struct {
+	MaxPeersPerIP                uint32
+	MaxPeersPerSubnet            uint32
+	IPv4SubnetMaskBits           uint32
+	IPv6SubnetMaskBits           uint32
+	DeniedPeerIPBanDurationInSec uint32
+}
+
+// DirectSendConfig will hold the settings of the direct send protocols
+// When CompressedProtocolEnabled is set, the node also supports the compressed direct send protocol, using the
+// provided Compression type. The compressed protocol is preferred while talking to the peers that support it
+type DirectSendConfig struct {
+	CompressedProtocolEnabled bool
+	Compression               string
+}
+
+// TopicCompressionConfig will hold the network-wide activation of the compressed topic messages
+// The topics created with a compression type send compressed messages only if Enabled is set, which should happen
+// only after all the nodes of the network are able to decompress them. Until then, the messages are sent uncompressed
+type TopicCompressionConfig struct {
+	Enabled bool
+}
+
+// EvictionScoringConfig will hold the settings used by the lists sharder to combine, inside each peers category,
+// the kad distance with the peer's rating and the connection age when choosing the peers to be evicted
+type EvictionScoringConfig struct {
+	Enabled             bool
+	DistanceWeight      uint32
+	RatingWeight        uint32
+	ConnectionAgeWeight uint32
+}
@@ -11,6 +11,24 @@ const NormalOperation NodeOperation = "normal operation"
 // FullArchiveMode defines the node operation as a full archive mode
 const FullArchiveMode NodeOperation = "full archive mode"
 
+// RatingReason defines the event that triggered a peer rating update
+type RatingReason string
+
+const (
+	// ValidGossipRatingReason - the peer relayed a valid message on a topic
+	ValidGossipRatingReason RatingReason = "valid gossip"
+	// ValidDirectResponseRatingReason - the peer sent a valid direct message or a valid response to a request
+	ValidDirectResponseRatingReason RatingReason = "valid direct response"
+	// ValidRequestRatingReason - the peer sent a valid request that was answered
+	ValidRequestRatingReason RatingReason = "valid request"
+	// TimeoutRatingReason - the peer did not respond in the allotted time
+	TimeoutRatingReason RatingReason = "timeout"
+	// InvalidDataRatingReason - the peer sent data that does not follow the protocol
+	InvalidDataRatingReason RatingReason = "invalid data"
+	// FloodingRatingReason - the peer exceeded the antiflood limits
+	FloodingRatingReason RatingReason = "flooding"
+)
+
 const (
 	// LocalHostListenAddrWithIp4AndTcp defines the local host listening ip v.4 address and TCP
 	LocalHostListenAddrWithIp4AndTcp = "/ip4/127.0.0.1/tcp/%d"
@@ -31,6 +49,18 @@ const (
 	// ConnectionWatcherTypeEmpty - not set, no connection watching should be made
 	ConnectionWatcherTypeEmpty = ""
 
+	// NoCompression - the topic payloads are sent as they are
+	NoCompression = "none"
+	// SnappyCompression - the topic payloads are compressed using snappy
+	SnappyCompression = "snappy"
+	// ZstdCompression - the topic payloads are compressed using zstd
+	ZstdCompression = "zstd"
+
+	// DropNewestPolicy - the message that does not fit in a full outgoing queue is dropped
+	DropNewestPolicy = "drop-newest"
+	// DropOldestPolicy - the oldest message of a full outgoing queue is dropped to make room for the new one
+	DropOldestPolicy = "drop-oldest"
+
 	// WrongP2PMessageBlacklistDuration represents the time to keep a peer id in the blacklist if it sends a message that
 	// do not follow this protocol
 	WrongP2PMessageBlacklistDuration = time.Second * 7200
@@ -0,0 +1,622 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: chunkMessage.proto
+
+package data
+
+import (
+	bytes "bytes"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ChunkMessage struct {
+	TransferID       []byte `protobuf:"bytes,1,opt,name=TransferID,proto3" json:"TransferID,omitempty"`
+	Index            uint32 `protobuf:"varint,2,opt,name=Index,proto3" json:"Index,omitempty"`
+	NumChunks        uint32 `protobuf:"varint,3,opt,name=NumChunks,proto3" json:"NumChunks,omitempty"`
+	TotalSize        uint64 `protobuf:"varint,4,opt,name=TotalSize,proto3" json:"TotalSize,omitempty"`
+	Payload          []byte `protobuf:"bytes,5,opt,name=Payload,proto3" json:"Payload,omitempty"`
+	PayloadSignature []byte `protobuf:"bytes,6,opt,name=PayloadSignature,proto3" json:"PayloadSignature,omitempty"`
+}
+
+func (m *ChunkMessage) Reset()      { *m = ChunkMessage{} }
+func (*ChunkMessage) ProtoMessage() {}
+func (*ChunkMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_96a456c557a8407a, []int{0}
+}
+func (m *ChunkMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChunkMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *ChunkMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChunkMessage.Merge(m, src)
+}
+func (m *ChunkMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChunkMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChunkMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChunkMessage proto.InternalMessageInfo
+
+func (m *ChunkMessage) GetTransferID() []byte {
+	if m != nil {
+		return m.TransferID
+	}
+	return nil
+}
+
+func (m *ChunkMessage) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ChunkMessage) GetNumChunks() uint32 {
+	if m != nil {
+		return m.NumChunks
+	}
+	return 0
+}
+
+func (m *ChunkMessage) GetTotalSize() uint64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+func (m *ChunkMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ChunkMessage) GetPayloadSignature() []byte {
+	if m != nil {
+		return m.PayloadSignature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChunkMessage)(nil), "proto.ChunkMessage")
+}
+
+func init() { proto.RegisterFile("chunkMessage.proto", fileDescriptor_96a456c557a8407a) }
+
+var fileDescriptor_96a456c557a8407a = []byte{
+	// 273 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4a, 0xce, 0x28, 0xcd,
+	0xcb, 0xf6, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
+	0x05, 0x53, 0x52, 0xba, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0xe9,
+	0xf9, 0xe9, 0xf9, 0xfa, 0x60, 0xe1, 0xa4, 0xd2, 0x34, 0x30, 0x0f, 0xcc, 0x01, 0xb3, 0x20, 0xba,
+	0x94, 0x8e, 0x31, 0x72, 0xf1, 0x38, 0x23, 0x19, 0x26, 0x24, 0xc7, 0xc5, 0x15, 0x52, 0x94, 0x98,
+	0x57, 0x9c, 0x96, 0x5a, 0xe4, 0xe9, 0x22, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x13, 0x84, 0x24, 0x22,
+	0x24, 0xc2, 0xc5, 0xea, 0x99, 0x97, 0x92, 0x5a, 0x21, 0xc1, 0xa4, 0xc0, 0xa8, 0xc1, 0x1b, 0x04,
+	0xe1, 0x08, 0xc9, 0x70, 0x71, 0xfa, 0x95, 0xe6, 0x82, 0x0d, 0x2a, 0x96, 0x60, 0x06, 0xcb, 0x20,
+	0x04, 0x40, 0xb2, 0x21, 0xf9, 0x25, 0x89, 0x39, 0xc1, 0x99, 0x55, 0xa9, 0x12, 0x2c, 0x0a, 0x8c,
+	0x1a, 0x2c, 0x41, 0x08, 0x01, 0x21, 0x09, 0x2e, 0xf6, 0x80, 0xc4, 0xca, 0x9c, 0xfc, 0xc4, 0x14,
+	0x09, 0x56, 0xb0, 0x75, 0x30, 0xae, 0x90, 0x16, 0x97, 0x00, 0x94, 0x19, 0x9c, 0x99, 0x9e, 0x97,
+	0x58, 0x52, 0x5a, 0x94, 0x2a, 0xc1, 0x06, 0x56, 0x82, 0x21, 0xee, 0x64, 0x77, 0xe1, 0xa1, 0x1c,
+	0xc3, 0x8d, 0x87, 0x72, 0x0c, 0x1f, 0x1e, 0xca, 0x31, 0x36, 0x3c, 0x92, 0x63, 0x5c, 0xf1, 0x48,
+	0x8e, 0xf1, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x6f, 0x3c, 0x92, 0x63, 0x7c, 0xf0,
+	0x48, 0x8e, 0xf1, 0xc5, 0x23, 0x39, 0x86, 0x0f, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8,
+	0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x28, 0x96, 0x94, 0xc4, 0x92, 0xc4, 0x24, 0x36,
+	0x70, 0x78, 0x18, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xd2, 0x76, 0xbc, 0xcd, 0x5b, 0x01, 0x00,
+	0x00,
+}
+
+func (this *ChunkMessage) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ChunkMessage)
+	if !ok {
+		that2, ok := that.(ChunkMessage)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !bytes.Equal(this.TransferID, that1.TransferID) {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if this.NumChunks != that1.NumChunks {
+		return false
+	}
+	if this.TotalSize != that1.TotalSize {
+		return false
+	}
+	if !bytes.Equal(this.Payload, that1.Payload) {
+		return false
+	}
+	if !bytes.Equal(this.PayloadSignature, that1.PayloadSignature) {
+		return false
+	}
+	return true
+}
+func (this *ChunkMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&data.ChunkMessage{")
+	s = append(s, "TransferID: "+fmt.Sprintf("%#v", this.TransferID)+",\n")
+	s = append(s, "Index: "+fmt.Sprintf("%#v", this.Index)+",\n")
+	s = append(s, "NumChunks: "+fmt.Sprintf("%#v", this.NumChunks)+",\n")
+	s = append(s, "TotalSize: "+fmt.Sprintf("%#v", this.TotalSize)+",\n")
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "PayloadSignature: "+fmt.Sprintf("%#v", this.PayloadSignature)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringChunkMessage(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ChunkMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChunkMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChunkMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PayloadSignature) > 0 {
+		i -= len(m.PayloadSignature)
+		copy(dAtA[i:], m.PayloadSignature)
+		i = encodeVarintChunkMessage(dAtA, i, uint64(len(m.PayloadSignature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Payload) > 0 {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintChunkMessage(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.TotalSize != 0 {
+		i = encodeVarintChunkMessage(dAtA, i, uint64(m.TotalSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.NumChunks != 0 {
+		i = encodeVarintChunkMessage(dAtA, i, uint64(m.NumChunks))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Index != 0 {
+		i = encodeVarintChunkMessage(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TransferID) > 0 {
+		i -= len(m.TransferID)
+		copy(dAtA[i:], m.TransferID)
+		i = encodeVarintChunkMessage(dAtA, i, uint64(len(m.TransferID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintChunkMessage(dAtA []byte, offset int, v uint64) int {
+	offset -= sovChunkMessage(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ChunkMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TransferID)
+	if l > 0 {
+		n += 1 + l + sovChunkMessage(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovChunkMessage(uint64(m.Index))
+	}
+	if m.NumChunks != 0 {
+		n += 1 + sovChunkMessage(uint64(m.NumChunks))
+	}
+	if m.TotalSize != 0 {
+		n += 1 + sovChunkMessage(uint64(m.TotalSize))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovChunkMessage(uint64(l))
+	}
+	l = len(m.PayloadSignature)
+	if l > 0 {
+		n += 1 + l + sovChunkMessage(uint64(l))
+	}
+	return n
+}
+
+func sovChunkMessage(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozChunkMessage(x uint64) (n int) {
+	return sovChunkMessage(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ChunkMessage) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ChunkMessage{`,
+		`TransferID:` + fmt.Sprintf("%v", this.TransferID) + `,`,
+		`Index:` + fmt.Sprintf("%v", this.Index) + `,`,
+		`NumChunks:` + fmt.Sprintf("%v", this.NumChunks) + `,`,
+		`TotalSize:` + fmt.Sprintf("%v", this.TotalSize) + `,`,
+		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`PayloadSignature:` + fmt.Sprintf("%v", this.PayloadSignature) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringChunkMessage(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ChunkMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowChunkMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChunkMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChunkMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TransferID = append(m.TransferID[:0], dAtA[iNdEx:postIndex]...)
+			if m.TransferID == nil {
+				m.TransferID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumChunks", wireType)
+			}
+			m.NumChunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumChunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalSize", wireType)
+			}
+			m.TotalSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PayloadSignature = append(m.PayloadSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.PayloadSignature == nil {
+				m.PayloadSignature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipChunkMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthChunkMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipChunkMessage(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowChunkMessage
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowChunkMessage
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthChunkMessage
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupChunkMessage
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthChunkMessage
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthChunkMessage        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowChunkMessage          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupChunkMessage = fmt.Errorf("proto: unexpected end of group")
+)
@@ -37,6 +37,19 @@ var ErrMessageTooOld = errors.New("message is too old")
 // ErrNilDirectSendMessageHandler signals that the message handler for new message has not been wired
 var ErrNilDirectSendMessageHandler = errors.New("nil direct sender message handler")
 
+// ErrNilDirectSendCodec signals that a nil direct send codec has been provided
+var ErrNilDirectSendCodec = errors.New("nil direct send codec")
+
+// ErrDirectSendProtocolAlreadyRegistered signals that the direct send protocol was already registered
+var ErrDirectSendProtocolAlreadyRegistered = errors.New("direct send protocol already registered")
+
+// ErrUnsupportedDirectSendProtocol signals that the protocol negotiated with the remote peer is not a known direct
+// send protocol
+var ErrUnsupportedDirectSendProtocol = errors.New("unsupported direct send protocol")
+
+// ErrNilCompressor signals that a nil compressor has been provided
+var ErrNilCompressor = errors.New("nil compressor")
+
 // ErrPeerNotDirectlyConnected signals that the peer is not directly connected to self
 var ErrPeerNotDirectlyConnected = errors.New("peer is not directly connected")
 
@@ -49,6 +62,18 @@ var ErrNilValidator = errors.New("no validator has been set for this topic")
 // ErrPeerDiscoveryProcessAlreadyStarted signals that a peer discovery is already turned on
 var ErrPeerDiscoveryProcessAlreadyStarted = errors.New("peer discovery is already turned on")
 
+// ErrPeerDiscoveryProcessNotStarted signals that a peer discovery was not yet turned on
+var ErrPeerDiscoveryProcessNotStarted = errors.New("peer discovery is not turned on")
+
+// ErrNilPeerDiscoverer signals that a nil peer discoverer has been provided
+var ErrNilPeerDiscoverer = errors.New("nil peer discoverer")
+
+// ErrEmptyPeerDiscoverers signals that no peer discoverer has been provided
+var ErrEmptyPeerDiscoverers = errors.New("empty peer discoverers")
+
+// ErrPeerDiscoveryBootstrapFailed signals that none of the peer discovery mechanisms could be bootstrapped
+var ErrPeerDiscoveryBootstrapFailed = errors.New("peer discovery bootstrap failed")
+
 // ErrMessageTooLarge signals that the message provided is too large
 var ErrMessageTooLarge = errors.New("buffer too large")
 
@@ -111,12 +136,20 @@ var ErrNilPeerDenialEvaluator = errors.New("nil peer denial evaluator")
 // with such a peer as it does not respect the protocol
 var ErrMessageUnmarshalError = errors.New("message unmarshal error")
 
+// ErrMessageDecompressionError signals that the payload of a received message could not be decompressed. The message
+// is dropped, but the sender is not penalized as it might use a codec this node is not aware of
+var ErrMessageDecompressionError = errors.New("message decompression error")
+
 // ErrUnsupportedFields signals that unsupported fields are provided
 var ErrUnsupportedFields = errors.New("unsupported fields")
 
 // ErrUnsupportedMessageVersion signals that an unsupported message version was detected
 var ErrUnsupportedMessageVersion = errors.New("unsupported message version")
 
+// ErrNewerMessageVersion signals that a received message uses a version newer than the ones known by this node. The
+// message is dropped, but the sender is not penalized as the network might be in the middle of an upgrade
+var ErrNewerMessageVersion = errors.New("newer message version")
+
 // ErrNilSyncTimer signals that a nil sync timer was provided
 var ErrNilSyncTimer = errors.New("nil sync timer")
 
@@ -173,3 +206,96 @@ var ErrInvalidWebTransportAddress = errors.New("invalid WebTransport address")
 
 // ErrNoTransportsDefined signals that no transports were defined
 var ErrNoTransportsDefined = errors.New("no transports defined")
+
+// ErrNilRequestHandler signals that a nil request handler has been provided
+var ErrNilRequestHandler = errors.New("nil request handler")
+
+// ErrNoRequestHandlerForTopic signals that no request handler was registered on the requested topic
+var ErrNoRequestHandlerForTopic = errors.New("no request handler for topic")
+
+// ErrRequestHandlerAlreadyDefined signals that a request handler was already defined on the provided topic
+var ErrRequestHandlerAlreadyDefined = errors.New("request handler already defined")
+
+// ErrNilRequestMessageHandler signals that the message handler for new requests has not been wired
+var ErrNilRequestMessageHandler = errors.New("nil request message handler")
+
+// ErrRequestTimeout signals that the response for a request was not received in the allotted time
+var ErrRequestTimeout = errors.New("request timeout")
+
+// ErrRequestRejected signals that the remote peer rejected the request
+var ErrRequestRejected = errors.New("request rejected by the remote peer")
+
+// ErrRequestIDMismatch signals that the response received does not correspond to the sent request
+var ErrRequestIDMismatch = errors.New("request ID mismatch")
+
+// ErrAcknowledgeTimeout signals that the delivery status of a direct message was not received in the allotted time
+var ErrAcknowledgeTimeout = errors.New("acknowledge timeout")
+
+// ErrDirectMessageRejected signals that the remote peer rejected the direct message
+var ErrDirectMessageRejected = errors.New("direct message rejected by the remote peer")
+
+// ErrAcknowledgeIDMismatch signals that the delivery status received does not correspond to the sent message
+var ErrAcknowledgeIDMismatch = errors.New("acknowledge ID mismatch")
+
+// ErrNilChunkedMessageHandler signals that the message handler for reassembled chunked messages has not been wired
+var ErrNilChunkedMessageHandler = errors.New("nil chunked message handler")
+
+// ErrInvalidChunk signals that an invalid chunk was received
+var ErrInvalidChunk = errors.New("invalid chunk")
+
+// ErrChunkedTransferBufferFull signals that the reassembly buffer can not hold a new chunked transfer
+var ErrChunkedTransferBufferFull = errors.New("chunked transfer reassembly buffer is full")
+
+// ErrFloodingDetected signals that the received message exceeded the antiflood limits
+var ErrFloodingDetected = errors.New("flooding detected")
+
+// ErrInvalidRatingsSnapshot signals that the persisted ratings snapshot could not be decoded
+var ErrInvalidRatingsSnapshot = errors.New("invalid ratings snapshot")
+
+// ErrRatingsSnapshotVersionMismatch signals that the persisted ratings snapshot was written with another format version
+var ErrRatingsSnapshotVersionMismatch = errors.New("ratings snapshot version mismatch")
+
+// ErrNilAddressDenialEvaluator signals that a nil address denial evaluator has been provided
+var ErrNilAddressDenialEvaluator = errors.New("nil address denial evaluator")
+
+// ErrDeniedAddress signals that all the addresses of a peer are denied
+var ErrDeniedAddress = errors.New("denied address")
+
+// ErrDeniedPeer signals that the peer is denied
+var ErrDeniedPeer = errors.New("denied peer")
+
+// ErrPeerAddressesNotFound signals that the addresses of a peer could not be found
+var ErrPeerAddressesNotFound = errors.New("peer addresses not found")
+
+// ErrNilConnectionAgeProvider signals that a nil connection age provider has been provided
+var ErrNilConnectionAgeProvider = errors.New("nil connection age provider")
+
+// ErrNilTopicsHandler signals that a nil topics handler has been provided
+var ErrNilTopicsHandler = errors.New("nil topics handler")
+
+// ErrInvalidShardID signals that an invalid shard ID has been provided
+var ErrInvalidShardID = errors.New("invalid shard ID")
+
+// ErrEmptyTopicTemplateName signals that a topic template with an empty name has been provided
+var ErrEmptyTopicTemplateName = errors.New("empty topic template name")
+
+// ErrInvalidTopicType signals that a topic template with an invalid topic type has been provided
+var ErrInvalidTopicType = errors.New("invalid topic type")
+
+// ErrDuplicatedTopicTemplate signals that the same topic template name has been provided more than once
+var ErrDuplicatedTopicTemplate = errors.New("duplicated topic template")
+
+// ErrUnknownTopicTemplate signals that the provided topic template name is not managed
+var ErrUnknownTopicTemplate = errors.New("unknown topic template")
+
+// ErrInvalidDropPolicy signals that an invalid outgoing queue drop policy has been provided
+var ErrInvalidDropPolicy = errors.New("invalid drop policy")
+
+// ErrOutgoingQueueFull signals that the message was dropped because the outgoing queue is full
+var ErrOutgoingQueueFull = errors.New("outgoing queue full")
+
+// ErrUnknownTopic signals that the provided topic was not created
+var ErrUnknownTopic = errors.New("unknown topic")
+
+// ErrLoadBalancerClosed signals that the outgoing load balancer was closed
+var ErrLoadBalancerClosed = errors.New("load balancer closed")
@@ -0,0 +1,47 @@
+package p2p
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// EvictionCategory defines the sharder's category a peer was placed in when computing an eviction list
+type EvictionCategory string
+
+const (
+	// IntraShardValidatorsCategory - validators from the same shard as the current node
+	IntraShardValidatorsCategory EvictionCategory = "intra shard validator"
+	// IntraShardObserversCategory - observers from the same shard as the current node
+	IntraShardObserversCategory EvictionCategory = "intra shard observer"
+	// CrossShardValidatorsCategory - validators from other shards
+	CrossShardValidatorsCategory EvictionCategory = "cross shard validator"
+	// CrossShardObserversCategory - observers from other shards
+	CrossShardObserversCategory EvictionCategory = "cross shard observer"
+	// SeedersCategory - the configured seeders
+	SeedersCategory EvictionCategory = "seeder"
+	// FullHistoryObserversCategory - full history observers from the same shard, used only in full archive mode
+	FullHistoryObserversCategory EvictionCategory = "full history observer"
+	// UnknownCategory - peers with no known shard and type
+	UnknownCategory EvictionCategory = "unknown"
+	// ShardAgnosticCategory - the only category used by the shard agnostic sharders
+	ShardAgnosticCategory EvictionCategory = "shard agnostic"
+)
+
+// PeerEvictionExplanation holds the details of the eviction decision taken for one peer
+type PeerEvictionExplanation struct {
+	Pid         core.PeerID
+	Category    EvictionCategory
+	Distance    *big.Int
+	IsPreferred bool
+	IsEvicted   bool
+	Reason      string
+}
+
+// EvictionDecision holds the explanations of all the peers provided in an eviction list computation
+type EvictionDecision struct {
+	Timestamp  time.Time
+	NumEvicted int
+	Peers      []PeerEvictionExplanation
+}
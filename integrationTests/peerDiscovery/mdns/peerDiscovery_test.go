@@ -0,0 +1,68 @@
+package mdns
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/integrationTests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerDiscoveryOnLocalNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	numOfPeers := 4
+	serviceName := fmt.Sprintf("_erd-test-%d._udp", time.Now().UnixNano()%100000)
+
+	//Step 1. Create numOfPeers instances of messenger type and call bootstrap
+	peers := make([]p2p.Messenger, numOfPeers)
+	for i := 0; i < numOfPeers; i++ {
+		peers[i] = integrationTests.CreateMessengerWithMdns(serviceName)
+		err := peers[i].Bootstrap()
+		if err != nil {
+			integrationTests.ClosePeers(peers[:i+1])
+			t.Skipf("mdns not available on this machine: %s", err.Error())
+		}
+	}
+	defer integrationTests.ClosePeers(peers)
+
+	//Step 2. wait until each peer found and connected to all the others
+	integrationTests.WaitForBootstrapAndShowConnected(peers, integrationTests.P2pBootstrapDelay)
+
+	for _, p := range peers {
+		assert.Equal(t, numOfPeers-1, len(p.ConnectedPeers()))
+	}
+}
+
+func TestPeerDiscoveryWithUnreachableSeederShouldFallBackOnMdns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	numOfPeers := 4
+	serviceName := fmt.Sprintf("_erd-test-%d._udp", time.Now().UnixNano()%100000)
+	unreachableSeeder := "/ip4/127.0.0.1/tcp/1/p2p/16Uiu2HAkw5SNNtSvH1zJiQ6Gc3WoGNSxiyNueRKe6fuAuh57G3Bk"
+
+	//Step 1. Create numOfPeers instances of messenger type running both kad-dht and mdns and call bootstrap
+	peers := make([]p2p.Messenger, numOfPeers)
+	for i := 0; i < numOfPeers; i++ {
+		peers[i] = integrationTests.CreateMessengerWithKadDhtAndMdns(unreachableSeeder, serviceName)
+		err := peers[i].Bootstrap()
+		if err != nil {
+			integrationTests.ClosePeers(peers[:i+1])
+			t.Skipf("mdns not available on this machine: %s", err.Error())
+		}
+	}
+	defer integrationTests.ClosePeers(peers)
+
+	//Step 2. the seeder is not reachable, the peers should have found each other through mdns
+	integrationTests.WaitForBootstrapAndShowConnected(peers, integrationTests.P2pBootstrapDelay)
+
+	for _, p := range peers {
+		assert.Equal(t, numOfPeers-1, len(p.ConnectedPeers()))
+	}
+}
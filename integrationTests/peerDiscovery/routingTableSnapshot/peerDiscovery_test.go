@@ -0,0 +1,73 @@
+package routingTableSnapshot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/integrationTests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func isConnected(mes p2p.Messenger, pid core.PeerID) bool {
+	for _, connectedPid := range mes.ConnectedPeers() {
+		if connectedPid == pid {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestRoutingTableSnapshotShouldReconnectAfterRestart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	t.Run("optimized kad dht", func(t *testing.T) {
+		testRoutingTableSnapshotShouldReconnectAfterRestart(t, "optimized")
+	})
+	t.Run("legacy kad dht", func(t *testing.T) {
+		testRoutingTableSnapshotShouldReconnectAfterRestart(t, "legacy")
+	})
+}
+
+func testRoutingTableSnapshotShouldReconnectAfterRestart(t *testing.T, kadDhtType string) {
+	//Step 1. Create a seeder and a peer that bootstraps from it
+	seeder := integrationTests.CreateMessengerWithKadDht("")
+	_ = seeder.Bootstrap()
+	seederAddress := integrationTests.GetConnectableAddress(seeder)
+	otherPeer := integrationTests.CreateMessengerWithKadDht(seederAddress)
+	_ = otherPeer.Bootstrap()
+	peers := []p2p.Messenger{seeder, otherPeer}
+	defer func() {
+		integrationTests.ClosePeers(peers)
+	}()
+
+	//Step 2. Create a node with the routing table snapshot enabled and wait until it discovers both peers
+	snapshotFile := filepath.Join(t.TempDir(), "routingTable.json")
+	node := integrationTests.CreateMessengerWithKadDhtAndSnapshot(seederAddress, kadDhtType, snapshotFile)
+	err := node.Bootstrap()
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		return isConnected(node, seeder.ID()) && isConnected(node, otherPeer.ID())
+	}, time.Second*10, time.Millisecond*100)
+
+	//Step 3. Close the node, the snapshot is saved on close
+	err = node.Close()
+	require.Nil(t, err)
+
+	//Step 4. Restart the node with no seeders, it should reconnect to the peers from the snapshot
+	restartedNode := integrationTests.CreateMessengerWithKadDhtAndSnapshot("", kadDhtType, snapshotFile)
+	peers = append(peers, restartedNode)
+	err = restartedNode.Bootstrap()
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		return isConnected(restartedNode, seeder.ID()) && isConnected(restartedNode, otherPeer.ID())
+	}, time.Second*10, time.Millisecond*100)
+}
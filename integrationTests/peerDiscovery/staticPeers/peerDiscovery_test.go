@@ -0,0 +1,68 @@
+package staticPeers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/integrationTests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func isConnected(mes p2p.Messenger, pid core.PeerID) bool {
+	for _, connectedPid := range mes.ConnectedPeers() {
+		if connectedPid == pid {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestStaticPeersShouldConnectAndReloadOnFileChange(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	//Step 1. Create 3 messengers without any peer discovery
+	peers := make([]p2p.Messenger, 0, 4)
+	for i := 0; i < 3; i++ {
+		peers = append(peers, integrationTests.CreateMessengerWithNoDiscovery())
+	}
+	defer func() {
+		integrationTests.ClosePeers(peers)
+	}()
+
+	//Step 2. Create the messenger with static peers: the first one in the config, the second one in the file
+	peersFile := filepath.Join(t.TempDir(), "peers.txt")
+	fileContent := "# static peers\n" + integrationTests.GetConnectableAddress(peers[1]) + "\n"
+	err := os.WriteFile(peersFile, []byte(fileContent), os.ModePerm)
+	require.Nil(t, err)
+
+	sentry := integrationTests.CreateMessengerWithStaticPeers(
+		[]string{integrationTests.GetConnectableAddress(peers[0])},
+		peersFile,
+	)
+	peers = append(peers, sentry)
+	err = sentry.Bootstrap()
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		return isConnected(sentry, peers[0].ID()) && isConnected(sentry, peers[1].ID())
+	}, time.Second*5, time.Millisecond*100)
+	assert.False(t, isConnected(sentry, peers[2].ID()))
+
+	//Step 3. Replace the second peer with the third one in the file
+	fileContent = "# static peers\n" + integrationTests.GetConnectableAddress(peers[2]) + "\n"
+	err = os.WriteFile(peersFile, []byte(fileContent), os.ModePerm)
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		return isConnected(sentry, peers[2].ID())
+	}, time.Second*5, time.Millisecond*100)
+	assert.True(t, isConnected(sentry, peers[0].ID()))
+}
@@ -0,0 +1,86 @@
+package peerDisconnecting
+
+import (
+	"testing"
+	"time"
+
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/integrationTests"
+	"github.com/multiversx/mx-chain-p2p-go/topicManager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type topicManagerHandler interface {
+	RegisterMessageProcessor(templateName string, identifier string, handler p2p.MessageProcessor) error
+	UpdateShardID(shardID uint32) error
+}
+
+func createTopicManager(t *testing.T, messenger p2p.Messenger, shardID uint32) topicManagerHandler {
+	tm, err := topicManager.NewTopicManager(topicManager.ArgsTopicManager{
+		TopicsHandler: messenger,
+		SelfShardID:   shardID,
+		NumShards:     2,
+		Templates: []topicManager.TopicTemplate{
+			{
+				Name: "blocks",
+				Type: topicManager.IntraShardTopic,
+			},
+			{
+				Name:                  "transactions",
+				Type:                  topicManager.CrossShardTopic,
+				CreateChannelForTopic: true,
+			},
+		},
+	})
+	require.Nil(t, err)
+
+	return tm
+}
+
+func TestTopicManager_ShardChangeShouldMigrateSubscriptions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this is not a short test")
+	}
+
+	messenger1 := integrationTests.CreateMessengerWithNoDiscovery()
+	messenger2 := integrationTests.CreateMessengerWithNoDiscovery()
+	defer integrationTests.ClosePeers([]p2p.Messenger{messenger1, messenger2})
+
+	err := messenger1.ConnectToPeer(integrationTests.GetConnectableAddress(messenger2))
+	require.Nil(t, err)
+
+	_ = createTopicManager(t, messenger1, 0)
+	topicManager2 := createTopicManager(t, messenger2, 1)
+
+	intraProcessor := newMessageProcessor()
+	crossProcessor := newMessageProcessor()
+	require.Nil(t, topicManager2.RegisterMessageProcessor("blocks", "test", intraProcessor))
+	require.Nil(t, topicManager2.RegisterMessageProcessor("transactions", "test", crossProcessor))
+
+	time.Sleep(durationBootstrapping)
+
+	messenger1.Broadcast("transactions_0_1", []byte("cross shard message"))
+	messenger1.Broadcast("blocks_0", []byte("intra shard message"))
+	time.Sleep(durationTraverseNetwork)
+
+	assert.Equal(t, 1, len(crossProcessor.AllMessages()))
+	assert.Equal(t, 0, len(intraProcessor.AllMessages()))
+	assert.False(t, messenger2.HasTopic("blocks_0"))
+
+	err = topicManager2.UpdateShardID(0)
+	require.Nil(t, err)
+	assert.True(t, messenger2.HasTopic("blocks_0"))
+	assert.False(t, messenger2.HasTopic("blocks_1"))
+	assert.True(t, messenger2.HasTopic("transactions_0_1"))
+	assert.True(t, messenger2.HasTopic("transactions_0_META"))
+	assert.False(t, messenger2.HasTopic("transactions_1_META"))
+
+	time.Sleep(durationBootstrapping)
+
+	messenger1.Broadcast("blocks_0", []byte("intra shard message"))
+	time.Sleep(durationTraverseNetwork)
+
+	assert.Equal(t, 1, len(crossProcessor.AllMessages()))
+	assert.Equal(t, 1, len(intraProcessor.AllMessages()))
+}
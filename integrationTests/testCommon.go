@@ -206,6 +206,63 @@ func CreateMessengerWithKadDhtAndProtocolID(initialAddr string, protocolID strin
 	return CreateMessengerFromConfig(p2pCfg)
 }
 
+// CreateMessengerWithKadDhtAndSnapshot creates a new libp2p messenger with the provided kad-dht peer discovery type,
+// saving and reusing the routing table snapshot from the provided file
+func CreateMessengerWithKadDhtAndSnapshot(initialAddr string, kadDhtType string, snapshotFile string) p2p.Messenger {
+	initialAddresses := make([]string, 0)
+	if len(initialAddr) > 0 {
+		initialAddresses = append(initialAddresses, initialAddr)
+	}
+	p2pCfg := createP2PConfig(initialAddresses)
+	p2pCfg.KadDhtPeerDiscovery.Type = kadDhtType
+	p2pCfg.KadDhtPeerDiscovery.RoutingTableSnapshot = config.RoutingTableSnapshotConfig{
+		Enabled:           true,
+		FilePath:          snapshotFile,
+		SaveIntervalInSec: 60,
+		EntryTTLInSec:     3600,
+	}
+
+	return CreateMessengerFromConfig(p2pCfg)
+}
+
+// CreateMessengerWithMdns creates a new libp2p messenger with mdns peer discovery, advertised under the provided
+// service name
+func CreateMessengerWithMdns(serviceName string) p2p.Messenger {
+	p2pCfg := createP2PConfigWithNoDiscovery()
+	p2pCfg.MdnsPeerDiscovery = config.MdnsPeerDiscoveryConfig{
+		Enabled:     true,
+		ServiceName: serviceName,
+	}
+
+	return CreateMessengerFromConfig(p2pCfg)
+}
+
+// CreateMessengerWithKadDhtAndMdns creates a new libp2p messenger running both the kad-dht and the mdns peer discovery
+func CreateMessengerWithKadDhtAndMdns(initialAddr string, serviceName string) p2p.Messenger {
+	p2pCfg := createP2PConfig([]string{initialAddr})
+	p2pCfg.MdnsPeerDiscovery = config.MdnsPeerDiscoveryConfig{
+		Enabled:     true,
+		ServiceName: serviceName,
+	}
+
+	return CreateMessengerFromConfig(p2pCfg)
+}
+
+// CreateMessengerWithStaticPeers creates a new libp2p messenger keeping connected the peers from the provided list
+// and from the provided peers file
+func CreateMessengerWithStaticPeers(peerList []string, peerListFile string) p2p.Messenger {
+	p2pCfg := createP2PConfigWithNoDiscovery()
+	p2pCfg.StaticPeerDiscovery = config.StaticPeerDiscoveryConfig{
+		Enabled:               true,
+		PeerList:              peerList,
+		PeerListFile:          peerListFile,
+		RefreshIntervalInSec:  1,
+		MaxRedialBackoffInSec: 2,
+	}
+
+	return CreateMessengerFromConfig(p2pCfg)
+}
+
 // ClosePeers calls Messenger.Close on the provided peers
 func ClosePeers(peers []p2p.Messenger) {
 	for _, p := range peers {
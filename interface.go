@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/hex"
 	"io"
+	"net/http"
 	"time"
 
+	"github.com/multiformats/go-multiaddr"
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-p2p-go/config"
 )
 
 // MessageProcessor is the interface used to describe what a receive message processor should do
@@ -79,8 +82,9 @@ type Messenger interface {
 
 	// CreateTopic defines a new topic for sending messages, and optionally
 	// creates a channel in the LoadBalancer for this topic (otherwise, the topic
-	// will use a default channel).
-	CreateTopic(name string, createChannelForTopic bool) error
+	// will use a default channel). The provided options customize how the
+	// messages are sent on the topic.
+	CreateTopic(name string, createChannelForTopic bool, options ...TopicOption) error
 
 	// HasTopic returns true if the Messenger has declared interest in a topic
 	// and it is listening to messages referencing it.
@@ -101,15 +105,22 @@ type Messenger interface {
 	// given topic.
 	UnregisterMessageProcessor(topic string, identifier string) error
 
-	// BroadcastOnChannelBlocking asynchronously waits until it can send a
-	// message on the channel, but once it is able to, it synchronously sends the
-	// message, blocking until sending is completed.
+	// BroadcastOnChannelBlocking queues a message to be sent on a given topic through a specified
+	// channel. If the channel's queue is full, it blocks until there is room or until the messenger is closed.
 	BroadcastOnChannelBlocking(channel string, topic string, buff []byte) error
 
-	// BroadcastOnChannel asynchronously sends a message on a given topic
-	// through a specified channel.
+	// BroadcastOnChannel queues, without blocking, a message to be sent on a given topic
+	// through a specified channel. The dropped messages are only logged.
 	BroadcastOnChannel(channel string, topic string, buff []byte)
 
+	// TryBroadcastOnChannel queues, without blocking, a message to be sent on a given topic
+	// through a specified channel and returns whether the message was queued or the reason it was dropped.
+	TryBroadcastOnChannel(channel string, topic string, buff []byte) BroadcastResult
+
+	// BroadcastOnChannelWithContext queues a message to be sent on a given topic through a specified
+	// channel. If the channel's queue is full, it blocks until there is room or until the context is done.
+	BroadcastOnChannelWithContext(ctx context.Context, channel string, topic string, buff []byte) error
+
 	// BroadcastUsingPrivateKey tries to send a byte buffer onto a topic using the topic name as channel
 	BroadcastUsingPrivateKey(topic string, buff []byte, pid core.PeerID, skBytes []byte)
 
@@ -122,13 +133,71 @@ type Messenger interface {
 	// peer, but reuses a connection and a stream if possible.
 	SendToConnectedPeer(topic string, buff []byte, peerID core.PeerID) error
 
+	// SendToConnectedPeerWithAck sends a message to a connected peer directly and waits for the peer to confirm
+	// that the message was accepted. The wait ends when the confirmation arrives, the remote peer rejects the
+	// message or the provided context is done. If the context has no deadline, a default timeout is applied.
+	SendToConnectedPeerWithAck(ctx context.Context, topic string, buff []byte, peerID core.PeerID) error
+
+	// SendToPeer sends a message to a peer directly, dialing the peer first if it is not connected. The peer
+	// addresses are searched in the peerstore and through the peer discovery mechanism and the dial respects the
+	// sharder limits and the denial evaluators. The new connection is temporary: once a short time to live expires,
+	// longer if keepConnection is set, it is closed unless the peer became a preferred one.
+	SendToPeer(ctx context.Context, topic string, buff []byte, peerID core.PeerID, keepConnection bool) error
+
+	// SendChunkedToConnectedPeer sends a large message to a connected peer by splitting it into signed chunks
+	// over a dedicated stream protocol. The receiver reassembles the chunks and delivers a single message to the
+	// registered message processors, just as it would do for a message received through SendToConnectedPeer.
+	SendChunkedToConnectedPeer(topic string, buff []byte, peerID core.PeerID) error
+
+	// Request sends a request to a connected peer on the dedicated request/response protocol and waits
+	// for its response. The wait ends when the response arrives, the remote peer rejects the request or
+	// the provided context is done. If the context has no deadline, a default request timeout is applied.
+	Request(ctx context.Context, topic string, buff []byte, peerID core.PeerID) ([]byte, error)
+
+	// RegisterRequestHandler sets the RequestHandler that will respond to the requests received on the
+	// specified topic. Only one handler can be registered on a topic.
+	RegisterRequestHandler(topic string, handler RequestHandler) error
+
+	// UnregisterRequestHandler removes the RequestHandler set on the given topic
+	UnregisterRequestHandler(topic string) error
+
+	// MetricsHandler returns the http.Handler that renders the messenger metrics (messages and bytes per topic,
+	// connections, connected peers per category, blacklist events, rating tiers sizes and outgoing queues depths)
+	// in the Prometheus text format. The metrics are collected regardless of the log level.
+	MetricsHandler() http.Handler
+
 	IsConnectedToTheNetwork() bool
 	ThresholdMinConnectedPeers() int
 	SetThresholdMinConnectedPeers(minConnectedPeers int) error
 	SetPeerShardResolver(peerShardResolver PeerShardResolver) error
 	SetPeerDenialEvaluator(handler PeerDenialEvaluator) error
+
+	// AddressDenialEvaluator returns the component used to ban IPs and subnets and to limit the number of peers
+	// accepted from the same IP or subnet
+	AddressDenialEvaluator() AddressDenialEvaluator
+
+	// ExplainEviction dry-runs the sharder on the currently connected peers, without closing any connection, and
+	// returns the category, the distance and the keep/evict reason for each peer
+	ExplainEviction() EvictionDecision
+
+	// LastEvictionDecision returns the last sharder decision that evicted at least one peer
+	LastEvictionDecision() EvictionDecision
+
+	// SeedersStatus returns the health state (last success, consecutive failures, latency and next dial attempt) of
+	// each configured seeder
+	SeedersStatus() []SeederStatus
+
+	// UpdateShardingConfig validates and applies the new connection quotas, then triggers an eviction pass on the
+	// connected peers. The sharder type can not be changed at runtime
+	UpdateShardingConfig(cfg config.ShardingConfig) error
+
 	GetConnectedPeersInfo() *ConnectedPeersInfo
 	UnjoinAllTopics() error
+
+	// LeaveTopic cancels the subscription and closes the provided topic, also removing its outgoing channel and all
+	// its message processors
+	LeaveTopic(name string) error
+
 	Port() int
 	WaitForConnections(maxWaitingTime time.Duration, minNumOfPeers uint32)
 	Sign(payload []byte) ([]byte, error)
@@ -158,6 +227,27 @@ type MessageP2P interface {
 type DirectSender interface {
 	NextSequenceNumber() []byte
 	Send(topic string, buff []byte, peer core.PeerID) error
+	SendWithAck(ctx context.Context, topic string, buff []byte, peer core.PeerID) error
+	IsInterfaceNil() bool
+}
+
+// ChunkedSender defines a component that can send large messages to connected peers by splitting them into chunks
+type ChunkedSender interface {
+	Send(topic string, buff []byte, peer core.PeerID) error
+	IsInterfaceNil() bool
+}
+
+// RequestSender defines a component that can send requests to connected peers and wait for their responses
+type RequestSender interface {
+	Request(ctx context.Context, topic string, buff []byte, peer core.PeerID) ([]byte, error)
+	IsInterfaceNil() bool
+}
+
+// RequestHandler is the interface used to describe what a request responder should do
+// If the function returns a non nil error, the request is considered rejected and the requester will receive
+// the error message instead of a response
+type RequestHandler interface {
+	ProcessRequest(request MessageP2P, fromConnectedPeer core.PeerID) ([]byte, error)
 	IsInterfaceNil() bool
 }
 
@@ -240,6 +330,9 @@ type Marshalizer interface {
 
 // PreferredPeersHolderHandler defines the behavior of a component able to handle preferred peers operations
 type PreferredPeersHolderHandler interface {
+	AddPreferredConnectionAddress(connectionAddress string) error
+	RemovePreferredConnectionAddress(connectionAddress string)
+	HasPreferredConnectionAddress(connectionAddress string) bool
 	PutConnectionAddress(peerID core.PeerID, address string)
 	PutShardID(peerID core.PeerID, shardID uint32)
 	Get() map[uint32][]core.PeerID
@@ -273,6 +366,18 @@ type PeerDenialEvaluator interface {
 	IsInterfaceNil() bool
 }
 
+// AddressDenialEvaluator defines the behavior of a component able to deny peers based on their IP addresses
+type AddressDenialEvaluator interface {
+	IsAddressDenied(address multiaddr.Multiaddr) bool
+	CanAcceptPeer(pid core.PeerID, address multiaddr.Multiaddr) bool
+	AddConnectedPeer(pid core.PeerID, address multiaddr.Multiaddr)
+	RemoveConnectedPeer(pid core.PeerID, address multiaddr.Multiaddr)
+	DenyPeerAddresses(pid core.PeerID)
+	UpsertIP(ip string, duration time.Duration) error
+	UpsertSubnet(subnet string, duration time.Duration) error
+	IsInterfaceNil() bool
+}
+
 // ConnectionMonitorWrapper uses a connection monitor but checks if the peer is blacklisted or not
 // TODO this should be removed after merging of the PeerShardResolver and BlacklistHandler
 type ConnectionMonitorWrapper interface {
@@ -308,12 +413,28 @@ type PeersRatingHandler interface {
 	AddPeer(pid core.PeerID)
 	IncreaseRating(pid core.PeerID)
 	DecreaseRating(pid core.PeerID)
+	IncreaseRatingWithReason(pid core.PeerID, reason RatingReason)
+	DecreaseRatingWithReason(pid core.PeerID, reason RatingReason)
 	GetTopRatedPeersFromList(peers []core.PeerID, minNumOfPeersExpected int) []core.PeerID
+	GetTierSizes() map[string]int
+	GetRatingsSnapshot() []PeerRating
+	GetRating(pid core.PeerID) (int32, bool)
 	IsInterfaceNil() bool
 }
 
 // PeerTopicNotifier represent an entity able to handle new notifications on a new peer on a topic
+// and on the topics left by the messenger
 type PeerTopicNotifier interface {
 	NewPeerFound(pid core.PeerID, topic string)
+	TopicLeft(topic string)
+	IsInterfaceNil() bool
+}
+
+// Compressor defines a component able to compress and decompress the payloads sent on topics
+type Compressor interface {
+	Compress(buff []byte) ([]byte, error)
+	Decompress(buff []byte, maxDecompressedSize int) ([]byte, error)
+	ID() uint32
+	Name() string
 	IsInterfaceNil() bool
 }
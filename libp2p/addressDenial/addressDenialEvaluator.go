@@ -0,0 +1,392 @@
+package addressDenial
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/multiversx/mx-chain-core-go/core"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+)
+
+const (
+	ipv4NumBits = 32
+	ipv6NumBits = 128
+)
+
+var log = logger.GetOrCreate("p2p/libp2p/addressdenial")
+
+// noExpiry marks the bans of the static deny-list, which are never lifted
+var noExpiry = time.Time{}
+
+type bannedSubnet struct {
+	subnet *net.IPNet
+	expiry time.Time
+}
+
+type addressDenialEvaluator struct {
+	mut                     sync.Mutex
+	bannedIPs               map[string]time.Time
+	bannedSubnets           map[string]*bannedSubnet
+	peersIPs                map[core.PeerID]map[string]int
+	peersPerIP              map[string]map[core.PeerID]struct{}
+	peersPerSubnet          map[string]map[core.PeerID]struct{}
+	maxPeersPerIP           int
+	maxPeersPerSubnet       int
+	ipv4SubnetMask          net.IPMask
+	ipv6SubnetMask          net.IPMask
+	deniedPeerIPBanDuration time.Duration
+	getTimeHandler          func() time.Time
+}
+
+// NewAddressDenialEvaluator creates a component able to deny peers by their IP, by the subnet their IP belongs to
+// and to limit the number of peers connected from the same IP or subnet. The static deny-list is banned without
+// an expiry
+func NewAddressDenialEvaluator(
+	cfg config.AddressDenialConfig,
+	staticDenials config.ConnectionGaterConfig,
+) (*addressDenialEvaluator, error) {
+	err := checkConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ade := &addressDenialEvaluator{
+		bannedIPs:               make(map[string]time.Time),
+		bannedSubnets:           make(map[string]*bannedSubnet),
+		peersIPs:                make(map[core.PeerID]map[string]int),
+		peersPerIP:              make(map[string]map[core.PeerID]struct{}),
+		peersPerSubnet:          make(map[string]map[core.PeerID]struct{}),
+		maxPeersPerIP:           int(cfg.MaxPeersPerIP),
+		maxPeersPerSubnet:       int(cfg.MaxPeersPerSubnet),
+		ipv4SubnetMask:          net.CIDRMask(int(cfg.IPv4SubnetMaskBits), ipv4NumBits),
+		ipv6SubnetMask:          net.CIDRMask(int(cfg.IPv6SubnetMaskBits), ipv6NumBits),
+		deniedPeerIPBanDuration: time.Duration(cfg.DeniedPeerIPBanDurationInSec) * time.Second,
+		getTimeHandler:          time.Now,
+	}
+
+	err = ade.addStaticDenials(staticDenials)
+	if err != nil {
+		return nil, err
+	}
+
+	return ade, nil
+}
+
+func checkConfig(cfg config.AddressDenialConfig) error {
+	if cfg.MaxPeersPerSubnet == 0 {
+		return nil
+	}
+
+	if cfg.IPv4SubnetMaskBits == 0 || cfg.IPv4SubnetMaskBits > ipv4NumBits {
+		return fmt.Errorf("%w for IPv4SubnetMaskBits, should be in the interval [1, %d]", p2p.ErrInvalidValue, ipv4NumBits)
+	}
+	if cfg.IPv6SubnetMaskBits == 0 || cfg.IPv6SubnetMaskBits > ipv6NumBits {
+		return fmt.Errorf("%w for IPv6SubnetMaskBits, should be in the interval [1, %d]", p2p.ErrInvalidValue, ipv6NumBits)
+	}
+
+	return nil
+}
+
+func (ade *addressDenialEvaluator) addStaticDenials(staticDenials config.ConnectionGaterConfig) error {
+	for _, ipString := range staticDenials.DeniedIPs {
+		ip := net.ParseIP(ipString)
+		if ip == nil {
+			return fmt.Errorf("%w for a denied IP: %s", p2p.ErrInvalidValue, ipString)
+		}
+
+		ade.bannedIPs[ip.String()] = noExpiry
+	}
+	for _, subnetString := range staticDenials.DeniedSubnets {
+		_, subnet, err := net.ParseCIDR(subnetString)
+		if err != nil {
+			return fmt.Errorf("%w for a denied subnet: %s", p2p.ErrInvalidValue, err.Error())
+		}
+
+		ade.bannedSubnets[subnet.String()] = &bannedSubnet{
+			subnet: subnet,
+			expiry: noExpiry,
+		}
+	}
+
+	return nil
+}
+
+// IsAddressDenied returns true if the IP of the provided address is banned or belongs to a banned subnet
+func (ade *addressDenialEvaluator) IsAddressDenied(address multiaddr.Multiaddr) bool {
+	ip, ok := extractIP(address)
+	if !ok {
+		return false
+	}
+
+	ade.mut.Lock()
+	defer ade.mut.Unlock()
+
+	return ade.isIPDenied(ip)
+}
+
+func (ade *addressDenialEvaluator) isIPDenied(ip net.IP) bool {
+	now := ade.getTimeHandler()
+
+	ipString := ip.String()
+	expiry, found := ade.bannedIPs[ipString]
+	if found {
+		if isBanActive(expiry, now) {
+			return true
+		}
+
+		delete(ade.bannedIPs, ipString)
+	}
+
+	for key, banned := range ade.bannedSubnets {
+		if !isBanActive(banned.expiry, now) {
+			delete(ade.bannedSubnets, key)
+			continue
+		}
+		if banned.subnet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isBanActive(expiry time.Time, now time.Time) bool {
+	return expiry.IsZero() || now.Before(expiry)
+}
+
+// isBanLonger returns true if the existing ban lasts longer than a ban ending at the provided expiry
+func isBanLonger(existingExpiry time.Time, expiry time.Time) bool {
+	return existingExpiry.IsZero() || existingExpiry.After(expiry)
+}
+
+// CanAcceptPeer returns false if the address is denied or if accepting the peer would exceed the maximum number
+// of peers connected from the same IP or subnet
+func (ade *addressDenialEvaluator) CanAcceptPeer(pid core.PeerID, address multiaddr.Multiaddr) bool {
+	ip, ok := extractIP(address)
+	if !ok {
+		return true
+	}
+
+	ade.mut.Lock()
+	defer ade.mut.Unlock()
+
+	if ade.isIPDenied(ip) {
+		return false
+	}
+	if isLimitReached(ade.peersPerIP[ip.String()], pid, ade.maxPeersPerIP) {
+		log.Trace("maximum number of peers per IP reached", "pid", pid.Pretty(), "ip", ip.String())
+		return false
+	}
+	if isLimitReached(ade.peersPerSubnet[ade.subnetKey(ip)], pid, ade.maxPeersPerSubnet) {
+		log.Trace("maximum number of peers per subnet reached", "pid", pid.Pretty(), "ip", ip.String())
+		return false
+	}
+
+	return true
+}
+
+func isLimitReached(peers map[core.PeerID]struct{}, pid core.PeerID, limit int) bool {
+	if limit == 0 {
+		return false
+	}
+
+	_, isKnownPeer := peers[pid]
+
+	return !isKnownPeer && len(peers) >= limit
+}
+
+// AddConnectedPeer records a new connection of the peer from the provided address
+func (ade *addressDenialEvaluator) AddConnectedPeer(pid core.PeerID, address multiaddr.Multiaddr) {
+	ip, ok := extractIP(address)
+	if !ok {
+		return
+	}
+
+	ade.mut.Lock()
+	defer ade.mut.Unlock()
+
+	ipString := ip.String()
+	peerIPs, found := ade.peersIPs[pid]
+	if !found {
+		peerIPs = make(map[string]int)
+		ade.peersIPs[pid] = peerIPs
+	}
+	peerIPs[ipString]++
+	if peerIPs[ipString] > 1 {
+		return
+	}
+
+	addPeerToSet(ade.peersPerIP, ipString, pid)
+	addPeerToSet(ade.peersPerSubnet, ade.subnetKey(ip), pid)
+}
+
+// RemoveConnectedPeer removes a connection of the peer from the provided address
+func (ade *addressDenialEvaluator) RemoveConnectedPeer(pid core.PeerID, address multiaddr.Multiaddr) {
+	ip, ok := extractIP(address)
+	if !ok {
+		return
+	}
+
+	ade.mut.Lock()
+	defer ade.mut.Unlock()
+
+	ipString := ip.String()
+	peerIPs := ade.peersIPs[pid]
+	if peerIPs[ipString] == 0 {
+		return
+	}
+
+	peerIPs[ipString]--
+	if peerIPs[ipString] > 0 {
+		return
+	}
+
+	delete(peerIPs, ipString)
+	if len(peerIPs) == 0 {
+		delete(ade.peersIPs, pid)
+	}
+	removePeerFromSet(ade.peersPerIP, ipString, pid)
+	removePeerFromSet(ade.peersPerSubnet, ade.subnetKey(ip), pid)
+}
+
+func addPeerToSet(sets map[string]map[core.PeerID]struct{}, key string, pid core.PeerID) {
+	peers, found := sets[key]
+	if !found {
+		peers = make(map[core.PeerID]struct{})
+		sets[key] = peers
+	}
+
+	peers[pid] = struct{}{}
+}
+
+func removePeerFromSet(sets map[string]map[core.PeerID]struct{}, key string, pid core.PeerID) {
+	peers := sets[key]
+	delete(peers, pid)
+	if len(peers) == 0 {
+		delete(sets, key)
+	}
+}
+
+// DenyPeerAddresses bans the IPs the provided peer is connected from, so the peer can not come back using a new
+// identity from the same IPs
+func (ade *addressDenialEvaluator) DenyPeerAddresses(pid core.PeerID) {
+	if ade.deniedPeerIPBanDuration == 0 {
+		return
+	}
+
+	ade.mut.Lock()
+	defer ade.mut.Unlock()
+
+	expiry := ade.getTimeHandler().Add(ade.deniedPeerIPBanDuration)
+	for ipString := range ade.peersIPs[pid] {
+		log.Debug("denied IP of a denied peer",
+			"pid", pid.Pretty(),
+			"ip", ipString,
+			"duration", ade.deniedPeerIPBanDuration,
+		)
+		ade.upsertIP(ipString, expiry)
+	}
+}
+
+// UpsertIP bans the provided IP for the provided duration, which should be positive. An existing ban is extended,
+// never shortened
+func (ade *addressDenialEvaluator) UpsertIP(ipString string, duration time.Duration) error {
+	err := checkBanDuration(duration)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(ipString)
+	if ip == nil {
+		return fmt.Errorf("%w for the IP: %s", p2p.ErrInvalidValue, ipString)
+	}
+
+	ade.mut.Lock()
+	defer ade.mut.Unlock()
+
+	ade.upsertIP(ip.String(), ade.getTimeHandler().Add(duration))
+
+	return nil
+}
+
+func (ade *addressDenialEvaluator) upsertIP(ipString string, expiry time.Time) {
+	existingExpiry, found := ade.bannedIPs[ipString]
+	if found && isBanLonger(existingExpiry, expiry) {
+		return
+	}
+
+	ade.bannedIPs[ipString] = expiry
+}
+
+// UpsertSubnet bans the provided subnet (CIDR notation) for the provided duration, which should be positive. An
+// existing ban is extended, never shortened
+func (ade *addressDenialEvaluator) UpsertSubnet(subnetString string, duration time.Duration) error {
+	err := checkBanDuration(duration)
+	if err != nil {
+		return err
+	}
+
+	_, subnet, err := net.ParseCIDR(subnetString)
+	if err != nil {
+		return fmt.Errorf("%w for the subnet: %s", p2p.ErrInvalidValue, err.Error())
+	}
+
+	ade.mut.Lock()
+	defer ade.mut.Unlock()
+
+	expiry := ade.getTimeHandler().Add(duration)
+	key := subnet.String()
+	existing, found := ade.bannedSubnets[key]
+	if found && isBanLonger(existing.expiry, expiry) {
+		return nil
+	}
+
+	ade.bannedSubnets[key] = &bannedSubnet{
+		subnet: subnet,
+		expiry: expiry,
+	}
+
+	return nil
+}
+
+func checkBanDuration(duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("%w for the ban duration: %v, should be greater than 0", p2p.ErrInvalidValue, duration)
+	}
+
+	return nil
+}
+
+func (ade *addressDenialEvaluator) subnetKey(ip net.IP) string {
+	ipv4 := ip.To4()
+	if ipv4 != nil {
+		return ipv4.Mask(ade.ipv4SubnetMask).String()
+	}
+
+	return ip.Mask(ade.ipv6SubnetMask).String()
+}
+
+func extractIP(address multiaddr.Multiaddr) (net.IP, bool) {
+	if address == nil {
+		return nil, false
+	}
+
+	ip, err := manet.ToIP(address)
+	if err != nil {
+		// addresses without an IP component (e.g. DNS, circuit) can not be evaluated
+		return nil, false
+	}
+
+	return ip, true
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ade *addressDenialEvaluator) IsInterfaceNil() bool {
+	return ade == nil
+}
@@ -0,0 +1,349 @@
+package addressDenial_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/addressDenial"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockAddressDenialConfig() config.AddressDenialConfig {
+	return config.AddressDenialConfig{
+		MaxPeersPerIP:                2,
+		MaxPeersPerSubnet:            3,
+		IPv4SubnetMaskBits:           24,
+		IPv6SubnetMaskBits:           64,
+		DeniedPeerIPBanDurationInSec: 60,
+	}
+}
+
+func TestNewAddressDenialEvaluator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid IPv4 subnet mask bits should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockAddressDenialConfig()
+		cfg.IPv4SubnetMaskBits = 0
+		ade, err := addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+		assert.True(t, check.IfNil(ade))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+		cfg.IPv4SubnetMaskBits = 33
+		ade, err = addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+		assert.True(t, check.IfNil(ade))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid IPv6 subnet mask bits should error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockAddressDenialConfig()
+		cfg.IPv6SubnetMaskBits = 0
+		ade, err := addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+		assert.True(t, check.IfNil(ade))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+		cfg.IPv6SubnetMaskBits = 129
+		ade, err = addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+		assert.True(t, check.IfNil(ade))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid static denied IP should error", func(t *testing.T) {
+		t.Parallel()
+
+		ade, err := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{
+			DeniedIPs: []string{"not an IP"},
+		})
+		assert.True(t, check.IfNil(ade))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid static denied subnet should error", func(t *testing.T) {
+		t.Parallel()
+
+		ade, err := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{
+			DeniedSubnets: []string{"10.0.0.0/33"},
+		})
+		assert.True(t, check.IfNil(ade))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("empty config should work", func(t *testing.T) {
+		t.Parallel()
+
+		ade, err := addressDenial.NewAddressDenialEvaluator(config.AddressDenialConfig{}, config.ConnectionGaterConfig{})
+		assert.False(t, check.IfNil(ade))
+		assert.Nil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		ade, err := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		assert.False(t, check.IfNil(ade))
+		assert.Nil(t, err)
+	})
+}
+
+func TestAddressDenialEvaluator_UpsertIP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid IP should error", func(t *testing.T) {
+		t.Parallel()
+
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		err := ade.UpsertIP("not an IP", time.Minute)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid duration should error", func(t *testing.T) {
+		t.Parallel()
+
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		err := ade.UpsertIP("1.2.3.4", 0)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		err = ade.UpsertIP("1.2.3.4", -time.Minute)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")))
+	})
+	t.Run("should deny until expiry", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		ade.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		err := ade.UpsertIP("1.2.3.4", time.Minute)
+		require.Nil(t, err)
+		err = ade.UpsertIP("2001:db8::1", time.Minute)
+		require.Nil(t, err)
+
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")))
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.4/udp/10000/quic-v1")))
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip6/2001:db8::1/tcp/10000")))
+		assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.5/tcp/10000")))
+		assert.False(t, ade.CanAcceptPeer("pid", multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")))
+
+		currentTime = currentTime.Add(time.Minute)
+		assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")))
+		assert.True(t, ade.CanAcceptPeer("pid", multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")))
+	})
+	t.Run("should not shorten an existing ban", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		ade.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		_ = ade.UpsertIP("1.2.3.4", time.Hour)
+		_ = ade.UpsertIP("1.2.3.4", time.Minute)
+
+		currentTime = currentTime.Add(time.Minute * 2)
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")))
+	})
+}
+
+func TestAddressDenialEvaluator_UpsertSubnet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid subnet should error", func(t *testing.T) {
+		t.Parallel()
+
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		err := ade.UpsertSubnet("10.0.0.0/33", time.Minute)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid duration should error", func(t *testing.T) {
+		t.Parallel()
+
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		err := ade.UpsertSubnet("10.0.0.0/8", 0)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		err = ade.UpsertSubnet("10.0.0.0/8", -time.Minute)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/10.1.2.3/tcp/10000")))
+	})
+	t.Run("should deny until expiry", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		ade.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		err := ade.UpsertSubnet("10.0.0.0/8", time.Minute)
+		require.Nil(t, err)
+		err = ade.UpsertSubnet("2001:db8::/32", time.Hour)
+		require.Nil(t, err)
+
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/10.20.30.40/tcp/10000")))
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip6/2001:db8::abcd/tcp/10000")))
+		assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/11.0.0.1/tcp/10000")))
+
+		currentTime = currentTime.Add(time.Minute)
+		assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/10.20.30.40/tcp/10000")))
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip6/2001:db8::abcd/tcp/10000")))
+	})
+}
+
+func TestAddressDenialEvaluator_StaticDenialsShouldNotExpire(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Now()
+	ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{
+		DeniedIPs:     []string{"1.2.3.4", "2001:db8::1"},
+		DeniedSubnets: []string{"10.0.0.0/8"},
+	})
+	ade.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+
+	deniedAddresses := []string{
+		"/ip4/1.2.3.4/tcp/10000",
+		"/ip6/2001:db8::1/tcp/10000",
+		"/ip4/10.20.30.40/tcp/10000",
+	}
+	_ = ade.UpsertIP("1.2.3.4", time.Minute)
+	_ = ade.UpsertSubnet("10.0.0.0/8", time.Minute)
+
+	currentTime = currentTime.Add(time.Hour * 24 * 365)
+	for _, address := range deniedAddresses {
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast(address)), address)
+		assert.False(t, ade.CanAcceptPeer("pid", multiaddr.StringCast(address)), address)
+	}
+	assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.5/tcp/10000")))
+}
+
+func TestAddressDenialEvaluator_NonIPAddressesShouldNotBeEvaluated(t *testing.T) {
+	t.Parallel()
+
+	ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+	address := multiaddr.StringCast("/dns4/example.com/tcp/10000")
+
+	assert.False(t, ade.IsAddressDenied(address))
+	assert.False(t, ade.IsAddressDenied(nil))
+	for i := 0; i < 10; i++ {
+		pid := core.PeerID(string(rune('a' + i)))
+		assert.True(t, ade.CanAcceptPeer(pid, address))
+		ade.AddConnectedPeer(pid, address)
+	}
+}
+
+func TestAddressDenialEvaluator_MaxPeersPerIP(t *testing.T) {
+	t.Parallel()
+
+	cfg := createMockAddressDenialConfig()
+	cfg.MaxPeersPerSubnet = 0
+	ade, _ := addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+	address := multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")
+	otherPortAddress := multiaddr.StringCast("/ip4/1.2.3.4/tcp/10001")
+
+	assert.True(t, ade.CanAcceptPeer("pid1", address))
+	ade.AddConnectedPeer("pid1", address)
+	assert.True(t, ade.CanAcceptPeer("pid2", otherPortAddress))
+	ade.AddConnectedPeer("pid2", otherPortAddress)
+
+	assert.False(t, ade.CanAcceptPeer("pid3", address))
+	// already known peers are still accepted
+	assert.True(t, ade.CanAcceptPeer("pid1", otherPortAddress))
+	// other IPs are not affected
+	assert.True(t, ade.CanAcceptPeer("pid3", multiaddr.StringCast("/ip4/1.2.3.5/tcp/10000")))
+
+	ade.RemoveConnectedPeer("pid2", otherPortAddress)
+	assert.True(t, ade.CanAcceptPeer("pid3", address))
+}
+
+func TestAddressDenialEvaluator_MaxPeersPerSubnet(t *testing.T) {
+	t.Parallel()
+
+	cfg := createMockAddressDenialConfig()
+	cfg.MaxPeersPerIP = 0
+	ade, _ := addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+
+	ade.AddConnectedPeer("pid1", multiaddr.StringCast("/ip4/192.168.1.1/tcp/10000"))
+	ade.AddConnectedPeer("pid2", multiaddr.StringCast("/ip4/192.168.1.2/tcp/10000"))
+	ade.AddConnectedPeer("pid3", multiaddr.StringCast("/ip4/192.168.1.3/tcp/10000"))
+
+	assert.False(t, ade.CanAcceptPeer("pid4", multiaddr.StringCast("/ip4/192.168.1.4/tcp/10000")))
+	assert.True(t, ade.CanAcceptPeer("pid4", multiaddr.StringCast("/ip4/192.168.2.4/tcp/10000")))
+
+	ade.AddConnectedPeer("pid5", multiaddr.StringCast("/ip6/2001:db8::1/tcp/10000"))
+	ade.AddConnectedPeer("pid6", multiaddr.StringCast("/ip6/2001:db8::2/tcp/10000"))
+	ade.AddConnectedPeer("pid7", multiaddr.StringCast("/ip6/2001:db8::3/tcp/10000"))
+	assert.False(t, ade.CanAcceptPeer("pid8", multiaddr.StringCast("/ip6/2001:db8::4/tcp/10000")))
+	assert.True(t, ade.CanAcceptPeer("pid8", multiaddr.StringCast("/ip6/2001:db8:0:1::4/tcp/10000")))
+}
+
+func TestAddressDenialEvaluator_RemoveConnectedPeerShouldCountConnections(t *testing.T) {
+	t.Parallel()
+
+	cfg := createMockAddressDenialConfig()
+	cfg.MaxPeersPerIP = 1
+	ade, _ := addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+	address := multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")
+
+	ade.AddConnectedPeer("pid1", address)
+	ade.AddConnectedPeer("pid1", address)
+	assert.False(t, ade.CanAcceptPeer("pid2", address))
+
+	ade.RemoveConnectedPeer("pid1", address)
+	assert.False(t, ade.CanAcceptPeer("pid2", address))
+
+	ade.RemoveConnectedPeer("pid1", address)
+	assert.True(t, ade.CanAcceptPeer("pid2", address))
+
+	// removing an unknown connection should not panic
+	ade.RemoveConnectedPeer("pid1", address)
+	ade.RemoveConnectedPeer("unknown pid", address)
+}
+
+func TestAddressDenialEvaluator_DenyPeerAddresses(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should ban the recorded IPs of the peer", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		ade, _ := addressDenial.NewAddressDenialEvaluator(createMockAddressDenialConfig(), config.ConnectionGaterConfig{})
+		ade.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		ade.AddConnectedPeer("denied pid", multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000"))
+		ade.AddConnectedPeer("denied pid", multiaddr.StringCast("/ip6/2001:db8::1/tcp/10000"))
+		ade.AddConnectedPeer("other pid", multiaddr.StringCast("/ip4/1.2.3.5/tcp/10000"))
+		ade.DenyPeerAddresses("denied pid")
+		ade.DenyPeerAddresses("unknown pid")
+
+		newIdentityAddress := multiaddr.StringCast("/ip4/1.2.3.4/tcp/20000")
+		assert.True(t, ade.IsAddressDenied(newIdentityAddress))
+		assert.False(t, ade.CanAcceptPeer("new identity", newIdentityAddress))
+		assert.True(t, ade.IsAddressDenied(multiaddr.StringCast("/ip6/2001:db8::1/tcp/20000")))
+		assert.False(t, ade.IsAddressDenied(multiaddr.StringCast("/ip4/1.2.3.5/tcp/10000")))
+
+		currentTime = currentTime.Add(time.Minute)
+		assert.False(t, ade.IsAddressDenied(newIdentityAddress))
+	})
+	t.Run("zero ban duration should not ban", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockAddressDenialConfig()
+		cfg.DeniedPeerIPBanDurationInSec = 0
+		ade, _ := addressDenial.NewAddressDenialEvaluator(cfg, config.ConnectionGaterConfig{})
+		address := multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")
+
+		ade.AddConnectedPeer("denied pid", address)
+		ade.DenyPeerAddresses("denied pid")
+
+		assert.False(t, ade.IsAddressDenied(address))
+	})
+}
@@ -0,0 +1,10 @@
+package addressDenial
+
+import "time"
+
+// SetTimeHandler -
+func (ade *addressDenialEvaluator) SetTimeHandler(handler func() time.Time) {
+	ade.mut.Lock()
+	ade.getTimeHandler = handler
+	ade.mut.Unlock()
+}
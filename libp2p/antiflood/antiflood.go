@@ -0,0 +1,342 @@
+package antiflood
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+)
+
+const (
+	sweepInterval     = time.Second * 30
+	topicKeySeparator = "_"
+
+	peerLimitReason       = "connected peer"
+	originatorLimitReason = "originator"
+	topicLimitReason      = "topic"
+	topicTotalLimitReason = "topic total"
+)
+
+var log = logger.GetOrCreate("p2p/libp2p/antiflood")
+
+// ArgsAntiflood is the DTO used to create a new antiflood instance
+type ArgsAntiflood struct {
+	Config                    config.AntifloodConfig
+	PeersRatingHandler        p2p.PeersRatingHandler
+	PeerDenialEvaluatorHolder PeerDenialEvaluatorHolder
+}
+
+type violationsCounter struct {
+	numViolations uint32
+	windowStart   time.Time
+}
+
+type antiflood struct {
+	mut                       sync.Mutex
+	peerLimiter               *rateLimiter
+	originatorLimiter         *rateLimiter
+	defaultTopicLimiter       *rateLimiter
+	topicLimiters             map[string]*rateLimiter
+	defaultTopicTotalLimiter  *rateLimiter
+	topicTotalLimiters        map[string]*rateLimiter
+	topicShares               map[string]*topicShares
+	violations                map[core.PeerID]*violationsCounter
+	numViolationsBeforeDenial uint32
+	violationsWindow          time.Duration
+	denialDuration            time.Duration
+	peersRatingHandler        p2p.PeersRatingHandler
+	peerDenialEvaluatorHolder PeerDenialEvaluatorHolder
+	getTimeHandler            func() time.Time
+	cancelFunc                context.CancelFunc
+}
+
+// NewAntiflood creates a component that applies token-bucket limits on the received messages for each connected
+// peer, for each originator, for each connected peer on each topic and for each topic
+func NewAntiflood(args ArgsAntiflood) (*antiflood, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := args.Config
+	af := &antiflood{
+		peerLimiter:               newRateLimiter(cfg.PeerMaxMessagesPerSec, cfg.PeerMaxBytesPerSec),
+		originatorLimiter:         newRateLimiter(cfg.OriginatorMaxMessagesPerSec, cfg.OriginatorMaxBytesPerSec),
+		defaultTopicLimiter:       newRateLimiter(cfg.TopicMaxMessagesPerSec, cfg.TopicMaxBytesPerSec),
+		topicLimiters:             make(map[string]*rateLimiter),
+		defaultTopicTotalLimiter:  newRateLimiter(cfg.TopicTotalMaxMessagesPerSec, cfg.TopicTotalMaxBytesPerSec),
+		topicTotalLimiters:        make(map[string]*rateLimiter),
+		topicShares:               make(map[string]*topicShares),
+		violations:                make(map[core.PeerID]*violationsCounter),
+		numViolationsBeforeDenial: cfg.NumViolationsBeforeDenial,
+		violationsWindow:          time.Duration(cfg.ViolationsWindowInSec) * time.Second,
+		denialDuration:            time.Duration(cfg.DenialDurationInSec) * time.Second,
+		peersRatingHandler:        args.PeersRatingHandler,
+		peerDenialEvaluatorHolder: args.PeerDenialEvaluatorHolder,
+		getTimeHandler:            time.Now,
+	}
+	for _, topicConfig := range cfg.Topics {
+		af.topicLimiters[topicConfig.Name] = newRateLimiter(topicConfig.MaxMessagesPerSec, topicConfig.MaxBytesPerSec)
+		af.topicTotalLimiters[topicConfig.Name] = newRateLimiter(topicConfig.TotalMaxMessagesPerSec, topicConfig.TotalMaxBytesPerSec)
+	}
+
+	var ctx context.Context
+	ctx, af.cancelFunc = context.WithCancel(context.Background())
+	go af.processSweepLoop(ctx)
+
+	return af, nil
+}
+
+func checkArgs(args ArgsAntiflood) error {
+	if check.IfNil(args.PeersRatingHandler) {
+		return p2p.ErrNilPeersRatingHandler
+	}
+	if check.IfNil(args.PeerDenialEvaluatorHolder) {
+		return p2p.ErrNilPeerDenialEvaluator
+	}
+
+	cfg := args.Config
+	if cfg.NumViolationsBeforeDenial > 0 {
+		if cfg.ViolationsWindowInSec == 0 {
+			return fmt.Errorf("%w for ViolationsWindowInSec, should be greater than 0", p2p.ErrInvalidValue)
+		}
+		if cfg.DenialDurationInSec == 0 {
+			return fmt.Errorf("%w for DenialDurationInSec, should be greater than 0", p2p.ErrInvalidValue)
+		}
+	}
+
+	topics := make(map[string]struct{})
+	for _, topicConfig := range cfg.Topics {
+		if len(topicConfig.Name) == 0 {
+			return fmt.Errorf("%w for the name of a topic antiflood config, empty name", p2p.ErrInvalidValue)
+		}
+		_, found := topics[topicConfig.Name]
+		if found {
+			return fmt.Errorf("%w for the name of a topic antiflood config, duplicated name %s",
+				p2p.ErrInvalidValue, topicConfig.Name)
+		}
+		topics[topicConfig.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// CanProcessMessage returns nil if the message is within all the limits, consuming from the buckets of the
+// connected peer, of the originator and of the topic. Otherwise, the offending peer has its rating decreased and
+// is denied if it exceeded the limits too many times within the violations window. For a message exceeding the total
+// limit of the topic, the offender is the connected peer or the originator with the largest share of the topic
+func (af *antiflood) CanProcessMessage(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+	if check.IfNil(message) {
+		return p2p.ErrNilMessage
+	}
+
+	size := uint64(len(message.Data()))
+	topic := message.Topic()
+	originator := message.Peer()
+	now := af.getTimeHandler()
+
+	af.mut.Lock()
+	offender, reason := af.checkLimits(fromConnectedPeer, originator, topic, size, now)
+	if len(reason) == 0 {
+		af.consume(fromConnectedPeer, originator, topic, size, now)
+		af.mut.Unlock()
+
+		return nil
+	}
+	if len(offender) == 0 {
+		af.mut.Unlock()
+
+		return fmt.Errorf("%w, %s limit exceeded on topic %s", p2p.ErrFloodingDetected, reason, topic)
+	}
+	shouldDeny := af.addViolation(offender, now)
+	af.mut.Unlock()
+
+	af.peersRatingHandler.DecreaseRatingWithReason(offender, p2p.FloodingRatingReason)
+	if shouldDeny {
+		af.denyPeer(offender)
+	}
+
+	return fmt.Errorf("%w, %s limit exceeded by %s on topic %s",
+		p2p.ErrFloodingDetected, reason, offender.Pretty(), topic)
+}
+
+func (af *antiflood) checkLimits(
+	fromConnectedPeer core.PeerID,
+	originator core.PeerID,
+	topic string,
+	size uint64,
+	now time.Time,
+) (core.PeerID, string) {
+	if !af.peerLimiter.canProcess(string(fromConnectedPeer), size, now) {
+		return fromConnectedPeer, peerLimitReason
+	}
+	if !af.getTopicLimiter(topic).canProcess(topicKey(fromConnectedPeer, topic), size, now) {
+		return fromConnectedPeer, topicLimitReason
+	}
+	if len(originator) > 0 && !af.originatorLimiter.canProcess(string(originator), size, now) {
+		return originator, originatorLimitReason
+	}
+	topicTotalLimiter := getLimiter(af.topicTotalLimiters, af.defaultTopicTotalLimiter, topic)
+	if !topicTotalLimiter.canProcess(topic, size, now) {
+		byMessages := topicTotalLimiter.isMessagesLimitExceeded(topic)
+		shares := af.getTopicShares(topic, now)
+
+		return shares.largestShareOwner(fromConnectedPeer, originator, size, byMessages), topicTotalLimitReason
+	}
+
+	return "", ""
+}
+
+func (af *antiflood) consume(
+	fromConnectedPeer core.PeerID,
+	originator core.PeerID,
+	topic string,
+	size uint64,
+	now time.Time,
+) {
+	af.peerLimiter.consume(string(fromConnectedPeer), size)
+	af.getTopicLimiter(topic).consume(topicKey(fromConnectedPeer, topic), size)
+	if len(originator) > 0 {
+		af.originatorLimiter.consume(string(originator), size)
+	}
+	getLimiter(af.topicTotalLimiters, af.defaultTopicTotalLimiter, topic).consume(topic, size)
+	af.getTopicShares(topic, now).add(fromConnectedPeer, originator, size)
+}
+
+// getTopicShares returns the shares of the topic in the current window, starting a new window if the previous one
+// expired
+func (af *antiflood) getTopicShares(topic string, now time.Time) *topicShares {
+	shares, found := af.topicShares[topic]
+	if !found || shares.isExpired(now) {
+		shares = newTopicShares(now)
+		af.topicShares[topic] = shares
+	}
+
+	return shares
+}
+
+func (af *antiflood) getTopicLimiter(topic string) *rateLimiter {
+	return getLimiter(af.topicLimiters, af.defaultTopicLimiter, topic)
+}
+
+func getLimiter(limiters map[string]*rateLimiter, defaultLimiter *rateLimiter, topic string) *rateLimiter {
+	limiter, found := limiters[topic]
+	if found {
+		return limiter
+	}
+
+	return defaultLimiter
+}
+
+func topicKey(pid core.PeerID, topic string) string {
+	return string(pid) + topicKeySeparator + topic
+}
+
+// addViolation returns true if the peer should be denied
+func (af *antiflood) addViolation(pid core.PeerID, now time.Time) bool {
+	if af.numViolationsBeforeDenial == 0 {
+		return false
+	}
+
+	counter, found := af.violations[pid]
+	if !found || now.Sub(counter.windowStart) > af.violationsWindow {
+		counter = &violationsCounter{
+			windowStart: now,
+		}
+		af.violations[pid] = counter
+	}
+
+	counter.numViolations++
+	if counter.numViolations < af.numViolationsBeforeDenial {
+		return false
+	}
+
+	delete(af.violations, pid)
+
+	return true
+}
+
+func (af *antiflood) denyPeer(pid core.PeerID) {
+	peerDenialEvaluator := af.peerDenialEvaluatorHolder.PeerDenialEvaluator()
+	if check.IfNil(peerDenialEvaluator) {
+		return
+	}
+
+	log.Debug("denied peer due to flooding",
+		"pid", pid.Pretty(),
+		"duration", af.denialDuration,
+	)
+
+	err := peerDenialEvaluator.UpsertPeerID(pid, af.denialDuration)
+	if err != nil {
+		log.Warn("error denying peer ID in antiflood",
+			"pid", pid.Pretty(),
+			"error", err.Error(),
+		)
+	}
+}
+
+func (af *antiflood) processSweepLoop(ctx context.Context) {
+	timer := time.NewTimer(sweepInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("closing antiflood's sweep go routine")
+			return
+		case <-timer.C:
+		}
+
+		af.sweep()
+		timer.Reset(sweepInterval)
+	}
+}
+
+func (af *antiflood) sweep() {
+	now := af.getTimeHandler()
+
+	af.mut.Lock()
+	defer af.mut.Unlock()
+
+	af.peerLimiter.sweep(now)
+	af.originatorLimiter.sweep(now)
+	af.defaultTopicLimiter.sweep(now)
+	for _, limiter := range af.topicLimiters {
+		limiter.sweep(now)
+	}
+	af.defaultTopicTotalLimiter.sweep(now)
+	for _, limiter := range af.topicTotalLimiters {
+		limiter.sweep(now)
+	}
+
+	for topic, shares := range af.topicShares {
+		if shares.isExpired(now) {
+			delete(af.topicShares, topic)
+		}
+	}
+
+	for pid, counter := range af.violations {
+		if now.Sub(counter.windowStart) > af.violationsWindow {
+			delete(af.violations, pid)
+		}
+	}
+}
+
+// Close stops the sweep go routine
+func (af *antiflood) Close() error {
+	af.cancelFunc()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (af *antiflood) IsInterfaceNil() bool {
+	return af == nil
+}
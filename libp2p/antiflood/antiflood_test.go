@@ -0,0 +1,513 @@
+package antiflood_test
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/antiflood"
+	"github.com/multiversx/mx-chain-p2p-go/message"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	connectedPeer = core.PeerID("connected peer")
+	originator    = core.PeerID("originator")
+	testTopic     = "topic"
+)
+
+func createMockArgsAntiflood() antiflood.ArgsAntiflood {
+	return antiflood.ArgsAntiflood{
+		Config: config.AntifloodConfig{
+			Enabled: true,
+		},
+		PeersRatingHandler:        &mock.PeersRatingHandlerStub{},
+		PeerDenialEvaluatorHolder: &mock.PeerDenialEvaluatorHolderStub{},
+	}
+}
+
+func createMessage(from core.PeerID, topic string, size int) p2p.MessageP2P {
+	return &message.Message{
+		DataField:  []byte(strings.Repeat("a", size)),
+		TopicField: topic,
+		PeerField:  from,
+	}
+}
+
+func TestNewAntiflood(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil peers rating handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.PeersRatingHandler = nil
+		af, err := antiflood.NewAntiflood(args)
+
+		assert.True(t, check.IfNil(af))
+		assert.Equal(t, p2p.ErrNilPeersRatingHandler, err)
+	})
+	t.Run("nil peer denial evaluator holder should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.PeerDenialEvaluatorHolder = nil
+		af, err := antiflood.NewAntiflood(args)
+
+		assert.True(t, check.IfNil(af))
+		assert.Equal(t, p2p.ErrNilPeerDenialEvaluator, err)
+	})
+	t.Run("denial enabled without violations window should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.NumViolationsBeforeDenial = 3
+		args.Config.DenialDurationInSec = 10
+		af, err := antiflood.NewAntiflood(args)
+
+		assert.True(t, check.IfNil(af))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "ViolationsWindowInSec")
+	})
+	t.Run("denial enabled without denial duration should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.NumViolationsBeforeDenial = 3
+		args.Config.ViolationsWindowInSec = 10
+		af, err := antiflood.NewAntiflood(args)
+
+		assert.True(t, check.IfNil(af))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "DenialDurationInSec")
+	})
+	t.Run("empty topic name should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.Topics = []config.TopicAntifloodConfig{{Name: ""}}
+		af, err := antiflood.NewAntiflood(args)
+
+		assert.True(t, check.IfNil(af))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "empty name")
+	})
+	t.Run("duplicated topic name should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.Topics = []config.TopicAntifloodConfig{{Name: testTopic}, {Name: testTopic}}
+		af, err := antiflood.NewAntiflood(args)
+
+		assert.True(t, check.IfNil(af))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "duplicated name")
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		af, err := antiflood.NewAntiflood(createMockArgsAntiflood())
+
+		assert.False(t, check.IfNil(af))
+		assert.Nil(t, err)
+		assert.Nil(t, af.Close())
+	})
+}
+
+func TestAntiflood_CanProcessMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil message should error", func(t *testing.T) {
+		t.Parallel()
+
+		af, _ := antiflood.NewAntiflood(createMockArgsAntiflood())
+		defer func() {
+			_ = af.Close()
+		}()
+
+		err := af.CanProcessMessage(nil, connectedPeer)
+		assert.Equal(t, p2p.ErrNilMessage, err)
+	})
+	t.Run("no limits should accept all messages", func(t *testing.T) {
+		t.Parallel()
+
+		af, _ := antiflood.NewAntiflood(createMockArgsAntiflood())
+		defer func() {
+			_ = af.Close()
+		}()
+
+		for i := 0; i < 1000; i++ {
+			assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1000), connectedPeer))
+		}
+		assert.Equal(t, 0, af.NumTrackedBuckets())
+	})
+	t.Run("connected peer messages limit should reject and decrease the rating", func(t *testing.T) {
+		t.Parallel()
+
+		decreasedPeers := make([]core.PeerID, 0)
+		args := createMockArgsAntiflood()
+		args.Config.PeerMaxMessagesPerSec = 2
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				assert.Equal(t, p2p.FloodingRatingReason, reason)
+				decreasedPeers = append(decreasedPeers, pid)
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		err := af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Equal(t, []core.PeerID{connectedPeer}, decreasedPeers)
+
+		// another connected peer has its own bucket
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), "another peer"))
+
+		// the bucket refills in time
+		now = now.Add(time.Millisecond * 500)
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		err = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+	})
+	t.Run("connected peer bytes limit should reject", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.PeerMaxBytesPerSec = 100
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 60), connectedPeer))
+		err := af.CanProcessMessage(createMessage(originator, testTopic, 60), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 40), connectedPeer))
+	})
+	t.Run("originator limit should reject and decrease the originator rating", func(t *testing.T) {
+		t.Parallel()
+
+		decreasedPeers := make([]core.PeerID, 0)
+		args := createMockArgsAntiflood()
+		args.Config.OriginatorMaxMessagesPerSec = 1
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				assert.Equal(t, p2p.FloodingRatingReason, reason)
+				decreasedPeers = append(decreasedPeers, pid)
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		err := af.CanProcessMessage(createMessage(originator, testTopic, 1), "another connected peer")
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Contains(t, err.Error(), "originator")
+		assert.Equal(t, []core.PeerID{originator}, decreasedPeers)
+	})
+	t.Run("topic limits should apply for each connected peer and topic", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.TopicMaxMessagesPerSec = 1
+		args.Config.Topics = []config.TopicAntifloodConfig{
+			{
+				Name:              "special topic",
+				MaxMessagesPerSec: 3,
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		err := af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Contains(t, err.Error(), "topic")
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, "another topic", 1), connectedPeer))
+
+		for i := 0; i < 3; i++ {
+			assert.Nil(t, af.CanProcessMessage(createMessage(originator, "special topic", 1), connectedPeer))
+		}
+		err = af.CanProcessMessage(createMessage(originator, "special topic", 1), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+	})
+	t.Run("topic total limits should apply for all the connected peers", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.TopicTotalMaxMessagesPerSec = 2
+		args.Config.Topics = []config.TopicAntifloodConfig{
+			{
+				Name:                   "special topic",
+				TotalMaxMessagesPerSec: 3,
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		assert.Nil(t, af.CanProcessMessage(createMessage("originator 2", testTopic, 1), "connected peer 2"))
+		err := af.CanProcessMessage(createMessage("originator 3", testTopic, 1), "connected peer 3")
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Contains(t, err.Error(), "topic total")
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, "another topic", 1), connectedPeer))
+
+		for i := 0; i < 3; i++ {
+			assert.Nil(t, af.CanProcessMessage(createMessage(originator, "special topic", 1), core.PeerID(rune('a'+i))))
+		}
+		err = af.CanProcessMessage(createMessage(originator, "special topic", 1), "another peer")
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+	})
+	t.Run("topic total limit on equal shares should charge the peer of the rejected message", func(t *testing.T) {
+		t.Parallel()
+
+		decreasedPeers := make([]core.PeerID, 0)
+		args := createMockArgsAntiflood()
+		args.Config.TopicTotalMaxMessagesPerSec = 2
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				assert.Equal(t, p2p.FloodingRatingReason, reason)
+				decreasedPeers = append(decreasedPeers, pid)
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage("originator 1", testTopic, 1), "connected peer 1"))
+		assert.Nil(t, af.CanProcessMessage(createMessage("originator 2", testTopic, 1), "connected peer 2"))
+		err := af.CanProcessMessage(createMessage("originator 3", testTopic, 1), "connected peer 3")
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Equal(t, []core.PeerID{"connected peer 3"}, decreasedPeers)
+	})
+	t.Run("topic total limit should charge the connected peer with the largest share", func(t *testing.T) {
+		t.Parallel()
+
+		decreasedPeers := make([]core.PeerID, 0)
+		args := createMockArgsAntiflood()
+		args.Config.TopicTotalMaxBytesPerSec = 100
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				decreasedPeers = append(decreasedPeers, pid)
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage("originator 1", testTopic, 60), "flooding peer"))
+		assert.Nil(t, af.CanProcessMessage(createMessage("originator 2", testTopic, 30), "honest peer"))
+		err := af.CanProcessMessage(createMessage("originator 2", testTopic, 20), "honest peer")
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Contains(t, err.Error(), core.PeerID("flooding peer").Pretty())
+		assert.Equal(t, []core.PeerID{"flooding peer"}, decreasedPeers)
+
+		// a new window starts after one second
+		now = now.Add(time.Second)
+		assert.Nil(t, af.CanProcessMessage(createMessage("originator 2", testTopic, 20), "honest peer"))
+	})
+	t.Run("topic total limit should charge the originator with the largest share", func(t *testing.T) {
+		t.Parallel()
+
+		decreasedPeers := make([]core.PeerID, 0)
+		args := createMockArgsAntiflood()
+		args.Config.TopicTotalMaxMessagesPerSec = 3
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				decreasedPeers = append(decreasedPeers, pid)
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		// the flooding originator spreads its messages through several connected peers
+		for i := 0; i < 3; i++ {
+			pid := core.PeerID(rune('a' + i))
+			assert.Nil(t, af.CanProcessMessage(createMessage("flooding originator", testTopic, 1), pid))
+		}
+		err := af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+		assert.Equal(t, []core.PeerID{"flooding originator"}, decreasedPeers)
+	})
+	t.Run("message larger than the bytes limit should pass only on a full bucket", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.PeerMaxBytesPerSec = 100
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 250), connectedPeer))
+		// the bucket is in debt, so the next messages wait until it is refilled
+		err := af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+
+		now = now.Add(time.Second)
+		err = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+
+		now = now.Add(time.Second)
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		err = af.CanProcessMessage(createMessage(originator, testTopic, 250), connectedPeer)
+		assert.True(t, errors.Is(err, p2p.ErrFloodingDetected))
+
+		now = now.Add(time.Second)
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 250), connectedPeer))
+	})
+	t.Run("rejected message should not consume from the other buckets", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsAntiflood()
+		args.Config.PeerMaxMessagesPerSec = 10
+		args.Config.TopicMaxMessagesPerSec = 1
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		for i := 0; i < 20; i++ {
+			_ = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		}
+		for i := 0; i < 9; i++ {
+			topic := string(rune('a' + i))
+			assert.Nil(t, af.CanProcessMessage(createMessage(originator, topic, 1), connectedPeer))
+		}
+	})
+	t.Run("repeat offender should be denied", func(t *testing.T) {
+		t.Parallel()
+
+		numDenials := uint32(0)
+		args := createMockArgsAntiflood()
+		args.Config.PeerMaxMessagesPerSec = 1
+		args.Config.NumViolationsBeforeDenial = 3
+		args.Config.ViolationsWindowInSec = 10
+		args.Config.DenialDurationInSec = 60
+		args.PeerDenialEvaluatorHolder = &mock.PeerDenialEvaluatorHolderStub{
+			PeerDenialEvaluatorCalled: func() p2p.PeerDenialEvaluator {
+				return &mock.PeerDenialEvaluatorStub{
+					UpsertPeerIDCalled: func(pid core.PeerID, duration time.Duration) error {
+						assert.Equal(t, connectedPeer, pid)
+						assert.Equal(t, time.Minute, duration)
+						atomic.AddUint32(&numDenials, 1)
+
+						return nil
+					},
+				}
+			},
+		}
+		af, _ := antiflood.NewAntiflood(args)
+		defer func() {
+			_ = af.Close()
+		}()
+		now := time.Now()
+		af.SetTimeHandler(func() time.Time {
+			return now
+		})
+
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		_ = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		_ = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.Equal(t, uint32(0), atomic.LoadUint32(&numDenials))
+
+		// violations outside the window are not accumulated
+		now = now.Add(time.Second * 11)
+		assert.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer))
+		_ = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		_ = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.Equal(t, uint32(0), atomic.LoadUint32(&numDenials))
+
+		_ = af.CanProcessMessage(createMessage(originator, testTopic, 1), connectedPeer)
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numDenials))
+	})
+}
+
+func TestAntiflood_SweepShouldRemoveRefilledBuckets(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsAntiflood()
+	args.Config.PeerMaxMessagesPerSec = 10
+	args.Config.OriginatorMaxBytesPerSec = 1000
+	af, _ := antiflood.NewAntiflood(args)
+	defer func() {
+		_ = af.Close()
+	}()
+	now := time.Now()
+	af.SetTimeHandler(func() time.Time {
+		return now
+	})
+
+	require.Nil(t, af.CanProcessMessage(createMessage(originator, testTopic, 10), connectedPeer))
+	assert.Equal(t, 2, af.NumTrackedBuckets())
+
+	af.Sweep()
+	assert.Equal(t, 2, af.NumTrackedBuckets())
+
+	now = now.Add(time.Second)
+	af.Sweep()
+	assert.Equal(t, 0, af.NumTrackedBuckets())
+}
@@ -0,0 +1,34 @@
+package antiflood
+
+import "time"
+
+// SetTimeHandler -
+func (af *antiflood) SetTimeHandler(handler func() time.Time) {
+	af.mut.Lock()
+	af.getTimeHandler = handler
+	af.mut.Unlock()
+}
+
+// Sweep -
+func (af *antiflood) Sweep() {
+	af.sweep()
+}
+
+// NumTrackedBuckets -
+func (af *antiflood) NumTrackedBuckets() int {
+	af.mut.Lock()
+	defer af.mut.Unlock()
+
+	num := len(af.peerLimiter.messagesBuckets) + len(af.peerLimiter.bytesBuckets)
+	num += len(af.originatorLimiter.messagesBuckets) + len(af.originatorLimiter.bytesBuckets)
+	num += len(af.defaultTopicLimiter.messagesBuckets) + len(af.defaultTopicLimiter.bytesBuckets)
+	for _, limiter := range af.topicLimiters {
+		num += len(limiter.messagesBuckets) + len(limiter.bytesBuckets)
+	}
+	num += len(af.defaultTopicTotalLimiter.messagesBuckets) + len(af.defaultTopicTotalLimiter.bytesBuckets)
+	for _, limiter := range af.topicTotalLimiters {
+		num += len(limiter.messagesBuckets) + len(limiter.bytesBuckets)
+	}
+
+	return num
+}
@@ -0,0 +1,9 @@
+package antiflood
+
+import p2p "github.com/multiversx/mx-chain-p2p-go"
+
+// PeerDenialEvaluatorHolder defines a component that holds the current peer denial evaluator
+type PeerDenialEvaluatorHolder interface {
+	PeerDenialEvaluator() p2p.PeerDenialEvaluator
+	IsInterfaceNil() bool
+}
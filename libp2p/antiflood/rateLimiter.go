@@ -0,0 +1,89 @@
+package antiflood
+
+import "time"
+
+// rateLimiter holds a messages token bucket and a bytes token bucket for each key. A zero limit disables the
+// corresponding bucket
+type rateLimiter struct {
+	maxMessagesPerSec float64
+	maxBytesPerSec    float64
+	messagesBuckets   map[string]*tokenBucket
+	bytesBuckets      map[string]*tokenBucket
+}
+
+func newRateLimiter(maxMessagesPerSec uint32, maxBytesPerSec uint64) *rateLimiter {
+	return &rateLimiter{
+		maxMessagesPerSec: float64(maxMessagesPerSec),
+		maxBytesPerSec:    float64(maxBytesPerSec),
+		messagesBuckets:   make(map[string]*tokenBucket),
+		bytesBuckets:      make(map[string]*tokenBucket),
+	}
+}
+
+func (rl *rateLimiter) canProcess(key string, size uint64, now time.Time) bool {
+	messagesBucket := getOrCreateBucket(rl.messagesBuckets, key, rl.maxMessagesPerSec, now)
+	if messagesBucket != nil && !messagesBucket.canConsume(1) {
+		return false
+	}
+
+	bytesBucket := getOrCreateBucket(rl.bytesBuckets, key, rl.maxBytesPerSec, now)
+	if bytesBucket != nil && !bytesBucket.canConsume(float64(size)) {
+		return false
+	}
+
+	return true
+}
+
+// isMessagesLimitExceeded tells if the messages bucket is the one that rejected the message, being called only after
+// canProcess returned false for the same key
+func (rl *rateLimiter) isMessagesLimitExceeded(key string) bool {
+	messagesBucket, found := rl.messagesBuckets[key]
+
+	return found && !messagesBucket.canConsume(1)
+}
+
+// consume should be called only after canProcess returned true for the same key
+func (rl *rateLimiter) consume(key string, size uint64) {
+	messagesBucket, found := rl.messagesBuckets[key]
+	if found {
+		messagesBucket.consume(1)
+	}
+
+	bytesBucket, found := rl.bytesBuckets[key]
+	if found {
+		bytesBucket.consume(float64(size))
+	}
+}
+
+// sweep removes the buckets that were fully refilled as they are equivalent to newly created ones
+func (rl *rateLimiter) sweep(now time.Time) {
+	sweepBuckets(rl.messagesBuckets, now)
+	sweepBuckets(rl.bytesBuckets, now)
+}
+
+func getOrCreateBucket(buckets map[string]*tokenBucket, key string, rate float64, now time.Time) *tokenBucket {
+	if rate == 0 {
+		return nil
+	}
+
+	bucket, found := buckets[key]
+	if !found {
+		bucket = newTokenBucket(rate, now)
+		buckets[key] = bucket
+
+		return bucket
+	}
+
+	bucket.refill(now)
+
+	return bucket
+}
+
+func sweepBuckets(buckets map[string]*tokenBucket, now time.Time) {
+	for key, bucket := range buckets {
+		bucket.refill(now)
+		if bucket.isFull() {
+			delete(buckets, key)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package antiflood
+
+import "time"
+
+// tokenBucket allows an average rate of tokens per second with bursts of at most one second worth of tokens. A single
+// consume larger than one second worth of tokens is allowed only on a full bucket, leaving the bucket in debt until
+// it is refilled
+type tokenBucket struct {
+	rate       float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:       rate,
+		tokens:     rate,
+		lastRefill: now,
+	}
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(tb.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	tb.tokens += elapsed * tb.rate
+	if tb.tokens > tb.rate {
+		tb.tokens = tb.rate
+	}
+	tb.lastRefill = now
+}
+
+func (tb *tokenBucket) canConsume(amount float64) bool {
+	if amount > tb.rate {
+		return tb.isFull()
+	}
+
+	return tb.tokens >= amount
+}
+
+func (tb *tokenBucket) consume(amount float64) {
+	tb.tokens -= amount
+}
+
+func (tb *tokenBucket) isFull() bool {
+	return tb.tokens >= tb.rate
+}
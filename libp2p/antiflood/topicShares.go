@@ -0,0 +1,115 @@
+package antiflood
+
+import (
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// sharesWindow matches the burst of the token buckets, one second worth of tokens
+const sharesWindow = time.Second
+
+type share struct {
+	numMessages uint64
+	numBytes    uint64
+}
+
+func (s *share) value(byMessages bool) uint64 {
+	if s == nil {
+		return 0
+	}
+	if byMessages {
+		return s.numMessages
+	}
+
+	return s.numBytes
+}
+
+// topicShares holds the messages and the bytes accepted on a topic in the current window for each connected peer and
+// for each originator, so the peer that pushed the topic over its total limit can be found
+type topicShares struct {
+	windowStart time.Time
+	peers       map[core.PeerID]*share
+	originators map[core.PeerID]*share
+}
+
+func newTopicShares(now time.Time) *topicShares {
+	return &topicShares{
+		windowStart: now,
+		peers:       make(map[core.PeerID]*share),
+		originators: make(map[core.PeerID]*share),
+	}
+}
+
+func (ts *topicShares) isExpired(now time.Time) bool {
+	return now.Sub(ts.windowStart) >= sharesWindow
+}
+
+func (ts *topicShares) add(fromConnectedPeer core.PeerID, originator core.PeerID, size uint64) {
+	addShare(ts.peers, fromConnectedPeer, size)
+	if len(originator) > 0 {
+		addShare(ts.originators, originator, size)
+	}
+}
+
+func addShare(shares map[core.PeerID]*share, pid core.PeerID, size uint64) {
+	s, found := shares[pid]
+	if !found {
+		s = &share{}
+		shares[pid] = s
+	}
+
+	s.numMessages++
+	s.numBytes += size
+}
+
+// largestShareOwner returns the connected peer or the originator with the largest share of the topic, counting also
+// the rejected message. On equal shares, the connected peer and the originator of the rejected message are preferred,
+// as their message is the one that did not fit, followed by the connected peers
+func (ts *topicShares) largestShareOwner(
+	fromConnectedPeer core.PeerID,
+	originator core.PeerID,
+	size uint64,
+	byMessages bool,
+) core.PeerID {
+	current := (&share{numMessages: 1, numBytes: size}).value(byMessages)
+
+	owner := fromConnectedPeer
+	largest := ts.peers[fromConnectedPeer].value(byMessages) + current
+	owner, largest = findLargerShare(ts.peers, owner, largest, byMessages)
+
+	if len(originator) > 0 {
+		originatorShare := ts.originators[originator].value(byMessages) + current
+		if originatorShare > largest {
+			owner, largest = originator, originatorShare
+		}
+	}
+	owner, _ = findLargerShare(ts.originators, owner, largest, byMessages)
+
+	return owner
+}
+
+// findLargerShare returns the owner of a share strictly larger than the provided one, if any. Equal shares are
+// decided by the peer ID so the result does not depend on the map iteration order
+func findLargerShare(
+	shares map[core.PeerID]*share,
+	owner core.PeerID,
+	largest uint64,
+	byMessages bool,
+) (core.PeerID, uint64) {
+	candidate := core.PeerID("")
+	candidateShare := uint64(0)
+	for pid, s := range shares {
+		value := s.value(byMessages)
+		isLarger := value > candidateShare || (value == candidateShare && pid < candidate)
+		if isLarger {
+			candidate, candidateShare = pid, value
+		}
+	}
+
+	if candidateShare > largest {
+		return candidate, candidateShare
+	}
+
+	return owner, largest
+}
@@ -0,0 +1,474 @@
+package libp2p
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubPb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/throttler"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/data"
+	"github.com/whyrusleeping/timecache"
+)
+
+var _ p2p.ChunkedSender = (*chunkedSender)(nil)
+
+// chunkWriteTimeout is the maximum time a sender waits for a chunk to be written on the stream
+const chunkWriteTimeout = time.Second * 10
+
+// ArgsChunkedSender is the DTO used to create a new chunked sender
+type ArgsChunkedSender struct {
+	Ctx                    context.Context
+	Host                   host.Host
+	MessageHandler         func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error
+	Signer                 p2p.SignerVerifier
+	ChunkSize              int
+	MaxPayloadSize         int
+	MaxBufferedSize        int
+	MaxBufferedSizePerPeer int
+	ReassemblyTimeout      time.Duration
+}
+
+type chunkedSender struct {
+	counter                uint64
+	ctx                    context.Context
+	hostP2P                host.Host
+	messageHandler         func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error
+	mutSeenMessages        sync.Mutex
+	seenMessages           *timecache.TimeCache
+	signer                 p2p.SignerVerifier
+	chunkSize              int
+	maxPayloadSize         int
+	maxBufferedSize        int
+	maxBufferedSizePerPeer int
+	reassemblyTimeout      time.Duration
+	mutBufferedSize        sync.Mutex
+	bufferedSize           int
+	bufferedSizePerPeer    map[peer.ID]int
+	transfersThrottler     *throttler.NumGoRoutinesThrottler
+}
+
+// NewChunkedSender returns a new instance of chunked sender object
+func NewChunkedSender(args ArgsChunkedSender) (*chunkedSender, error) {
+	err := checkArgsChunkedSender(args)
+	if err != nil {
+		return nil, err
+	}
+
+	transfersThrottler, err := throttler.NewNumGoRoutinesThrottler(streamHandlerGoRoutines)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &chunkedSender{
+		counter:                uint64(time.Now().UnixNano()),
+		ctx:                    args.Ctx,
+		hostP2P:                args.Host,
+		messageHandler:         args.MessageHandler,
+		seenMessages:           timecache.NewTimeCache(timeSeenMessages),
+		signer:                 args.Signer,
+		chunkSize:              args.ChunkSize,
+		maxPayloadSize:         args.MaxPayloadSize,
+		maxBufferedSize:        args.MaxBufferedSize,
+		maxBufferedSizePerPeer: args.MaxBufferedSizePerPeer,
+		reassemblyTimeout:      args.ReassemblyTimeout,
+		bufferedSizePerPeer:    make(map[peer.ID]int),
+		transfersThrottler:     transfersThrottler,
+	}
+
+	// wire-up a handler for chunked messages
+	args.Host.SetStreamHandler(ChunkedSendID, cs.chunkedStreamHandler)
+
+	return cs, nil
+}
+
+func checkArgsChunkedSender(args ArgsChunkedSender) error {
+	if args.Host == nil {
+		return p2p.ErrNilHost
+	}
+	if args.Ctx == nil {
+		return p2p.ErrNilContext
+	}
+	if args.MessageHandler == nil {
+		return p2p.ErrNilChunkedMessageHandler
+	}
+	if check.IfNil(args.Signer) {
+		return p2p.ErrNilP2PSigner
+	}
+	if args.ChunkSize <= 0 {
+		return fmt.Errorf("%w for ChunkSize: %d", p2p.ErrInvalidValue, args.ChunkSize)
+	}
+	if args.MaxPayloadSize < args.ChunkSize {
+		return fmt.Errorf("%w for MaxPayloadSize: %d, it should be at least the ChunkSize: %d",
+			p2p.ErrInvalidValue, args.MaxPayloadSize, args.ChunkSize)
+	}
+	if args.MaxBufferedSizePerPeer < args.MaxPayloadSize {
+		return fmt.Errorf("%w for MaxBufferedSizePerPeer: %d, it should be at least the MaxPayloadSize: %d",
+			p2p.ErrInvalidValue, args.MaxBufferedSizePerPeer, args.MaxPayloadSize)
+	}
+	if args.MaxBufferedSize < args.MaxBufferedSizePerPeer {
+		return fmt.Errorf("%w for MaxBufferedSize: %d, it should be at least the MaxBufferedSizePerPeer: %d",
+			p2p.ErrInvalidValue, args.MaxBufferedSize, args.MaxBufferedSizePerPeer)
+	}
+	if args.ReassemblyTimeout <= 0 {
+		return fmt.Errorf("%w for ReassemblyTimeout: %v", p2p.ErrInvalidValue, args.ReassemblyTimeout)
+	}
+
+	return nil
+}
+
+// chunkedStreamHandler handles exactly one chunked transfer on each stream: reads and reassembles all the chunks
+// and calls the message handler with the reassembled message. The streams received while too many other transfers
+// are being handled are reset
+func (cs *chunkedSender) chunkedStreamHandler(s network.Stream) {
+	if !cs.transfersThrottler.CanProcess() {
+		_ = s.Reset()
+		log.Trace("too many chunked transfers in progress", "from", s.Conn().RemotePeer())
+		return
+	}
+
+	cs.transfersThrottler.StartProcessing()
+	go func() {
+		defer cs.transfersThrottler.EndProcessing()
+
+		err := cs.receiveChunkedTransfer(s)
+		if err != nil {
+			_ = s.Reset()
+			log.Trace("p2p receiveChunkedTransfer",
+				"from", s.Conn().RemotePeer(),
+				"error", err.Error(),
+			)
+			return
+		}
+
+		_ = s.Close()
+	}()
+}
+
+func (cs *chunkedSender) receiveChunkedTransfer(s network.Stream) error {
+	// the whole transfer should be completed before the reassembly timeout
+	_ = s.SetReadDeadline(time.Now().Add(cs.reassemblyTimeout))
+
+	fromConnectedPeer := s.Conn().RemotePeer()
+	reader := ggio.NewDelimitedReader(s, cs.chunkSize+messageHeader)
+
+	var transfer *chunkedTransfer
+	defer func() {
+		if transfer != nil {
+			cs.releaseBufferedSize(fromConnectedPeer, int(transfer.totalSize))
+		}
+	}()
+
+	for {
+		msg := &pubsubPb.Message{}
+		err := reader.ReadMsg(msg)
+		if err != nil {
+			return err
+		}
+
+		chunk, err := cs.processReceivedChunk(msg, fromConnectedPeer)
+		if err != nil {
+			return err
+		}
+
+		if transfer == nil {
+			transfer, err = cs.newChunkedTransferWithReservedBuffer(msg, chunk, fromConnectedPeer)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = transfer.addChunk(msg, chunk)
+		if err != nil {
+			return err
+		}
+
+		if transfer.isComplete() {
+			reassembled := transfer.reassembledMessage(chunk.PayloadSignature)
+			err = checkReassembledMessageSignature(cs.signer, reassembled)
+			if err != nil {
+				return err
+			}
+
+			pbMessage := &pubsub.Message{
+				Message: reassembled,
+			}
+
+			return cs.messageHandler(pbMessage, core.PeerID(fromConnectedPeer))
+		}
+	}
+}
+
+func (cs *chunkedSender) processReceivedChunk(message *pubsubPb.Message, fromConnectedPeer peer.ID) (*data.ChunkMessage, error) {
+	err := checkReceivedDirectMessage(message, fromConnectedPeer)
+	if err != nil {
+		return nil, err
+	}
+	if cs.checkAndSetSeenMessage(message) {
+		return nil, p2p.ErrAlreadySeenMessage
+	}
+	err = checkMessageSignature(cs.signer, message)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := &data.ChunkMessage{}
+	err = chunk.Unmarshal(message.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w, error: %s", p2p.ErrInvalidChunk, err.Error())
+	}
+
+	return chunk, nil
+}
+
+// newChunkedTransferWithReservedBuffer reserves the whole announced size on the buffered size budgets before
+// allocating the reassembly buffer, so the announced size is bounded both by the maximum payload size and by the
+// budget of the sending peer. The reserved size is released when the transfer ends
+func (cs *chunkedSender) newChunkedTransferWithReservedBuffer(
+	message *pubsubPb.Message,
+	firstChunk *data.ChunkMessage,
+	fromConnectedPeer peer.ID,
+) (*chunkedTransfer, error) {
+	transfer, err := cs.newChunkedTransfer(message, firstChunk)
+	if err != nil {
+		return nil, err
+	}
+	if !cs.reserveBufferedSize(fromConnectedPeer, int(transfer.totalSize)) {
+		return nil, p2p.ErrChunkedTransferBufferFull
+	}
+
+	transfer.buff = make([]byte, 0, transfer.totalSize)
+
+	return transfer, nil
+}
+
+func (cs *chunkedSender) newChunkedTransfer(message *pubsubPb.Message, firstChunk *data.ChunkMessage) (*chunkedTransfer, error) {
+	if firstChunk.NumChunks == 0 || firstChunk.TotalSize == 0 {
+		return nil, fmt.Errorf("%w, empty transfer", p2p.ErrInvalidChunk)
+	}
+	if uint64(firstChunk.NumChunks) > firstChunk.TotalSize {
+		return nil, fmt.Errorf("%w, number of chunks %d is larger than the total size %d",
+			p2p.ErrInvalidChunk, firstChunk.NumChunks, firstChunk.TotalSize)
+	}
+	if firstChunk.TotalSize > uint64(cs.maxPayloadSize) {
+		return nil, fmt.Errorf("%w, to be received: %d, maximum: %d",
+			p2p.ErrMessageTooLarge, firstChunk.TotalSize, cs.maxPayloadSize)
+	}
+
+	return &chunkedTransfer{
+		transferID: firstChunk.TransferID,
+		topic:      message.GetTopic(),
+		from:       message.GetFrom(),
+		numChunks:  firstChunk.NumChunks,
+		totalSize:  firstChunk.TotalSize,
+	}, nil
+}
+
+// reserveBufferedSize accounts the size both on the global budget and on the budget of the peer, so that one peer
+// can not keep the reassembly buffer occupied for all the others
+func (cs *chunkedSender) reserveBufferedSize(pid peer.ID, size int) bool {
+	cs.mutBufferedSize.Lock()
+	defer cs.mutBufferedSize.Unlock()
+
+	if cs.bufferedSize+size > cs.maxBufferedSize {
+		return false
+	}
+	if cs.bufferedSizePerPeer[pid]+size > cs.maxBufferedSizePerPeer {
+		return false
+	}
+
+	cs.bufferedSize += size
+	cs.bufferedSizePerPeer[pid] += size
+	return true
+}
+
+func (cs *chunkedSender) releaseBufferedSize(pid peer.ID, size int) {
+	cs.mutBufferedSize.Lock()
+	defer cs.mutBufferedSize.Unlock()
+
+	cs.bufferedSize -= size
+	cs.bufferedSizePerPeer[pid] -= size
+	if cs.bufferedSizePerPeer[pid] <= 0 {
+		delete(cs.bufferedSizePerPeer, pid)
+	}
+}
+
+func (cs *chunkedSender) checkAndSetSeenMessage(msg *pubsubPb.Message) bool {
+	cs.mutSeenMessages.Lock()
+	defer cs.mutSeenMessages.Unlock()
+
+	return checkAndSetSeenMessage(cs.seenMessages, msg)
+}
+
+// NextSequenceNumber returns the next uint64 found in *counter as byte slice
+func (cs *chunkedSender) NextSequenceNumber() []byte {
+	return nextSequenceNumber(&cs.counter)
+}
+
+// Send will split the buffer in signed chunks and will send them to the connected peer on a new stream
+func (cs *chunkedSender) Send(topic string, buff []byte, peer core.PeerID) error {
+	if len(buff) == 0 {
+		return p2p.ErrEmptyBufferToSend
+	}
+	if len(buff) > cs.maxPayloadSize {
+		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), cs.maxPayloadSize)
+	}
+
+	conn, err := getConnectionToPeer(cs.hostP2P, peer)
+	if err != nil {
+		return err
+	}
+
+	stream, err := cs.hostP2P.NewStream(cs.ctx, conn.RemotePeer(), ChunkedSendID)
+	if err != nil {
+		return err
+	}
+
+	err = cs.writeChunks(stream, topic, buff, conn)
+	if err != nil {
+		_ = stream.Reset()
+		return err
+	}
+
+	return stream.Close()
+}
+
+func (cs *chunkedSender) writeChunks(stream network.Stream, topic string, buff []byte, conn network.Conn) error {
+	transferID := cs.NextSequenceNumber()
+	numChunks := (len(buff) + cs.chunkSize - 1) / cs.chunkSize
+
+	// the receiver delivers the reassembled message with the transfer ID as sequence number, so the signature carried
+	// in the last chunk is computed over that message
+	reassembled, err := createSignedMessage(cs.signer, transferID, topic, buff, conn)
+	if err != nil {
+		return err
+	}
+
+	bufw := bufio.NewWriter(stream)
+	w := ggio.NewDelimitedWriter(bufw)
+	for i := 0; i < numChunks; i++ {
+		start := i * cs.chunkSize
+		end := start + cs.chunkSize
+		if end > len(buff) {
+			end = len(buff)
+		}
+
+		chunk := &data.ChunkMessage{
+			TransferID: transferID,
+			Index:      uint32(i),
+			NumChunks:  uint32(numChunks),
+			TotalSize:  uint64(len(buff)),
+			Payload:    buff[start:end],
+		}
+		if i == numChunks-1 {
+			chunk.PayloadSignature = reassembled.Signature
+		}
+		chunkBuff, err := chunk.Marshal()
+		if err != nil {
+			return err
+		}
+
+		msg, err := createSignedMessage(cs.signer, cs.NextSequenceNumber(), topic, chunkBuff, conn)
+		if err != nil {
+			return err
+		}
+
+		_ = stream.SetWriteDeadline(time.Now().Add(chunkWriteTimeout))
+		err = w.WriteMsg(msg)
+		if err != nil {
+			return err
+		}
+
+		err = bufw.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cs *chunkedSender) IsInterfaceNil() bool {
+	return cs == nil
+}
+
+type chunkedTransfer struct {
+	transferID []byte
+	topic      string
+	from       []byte
+	numChunks  uint32
+	totalSize  uint64
+	nextIndex  uint32
+	buff       []byte
+}
+
+func (ct *chunkedTransfer) addChunk(message *pubsubPb.Message, chunk *data.ChunkMessage) error {
+	isSameTransfer := bytes.Equal(ct.transferID, chunk.TransferID) &&
+		ct.topic == message.GetTopic() &&
+		ct.numChunks == chunk.NumChunks &&
+		ct.totalSize == chunk.TotalSize
+	if !isSameTransfer {
+		return fmt.Errorf("%w, chunk does not belong to the current transfer", p2p.ErrInvalidChunk)
+	}
+	if chunk.Index != ct.nextIndex {
+		return fmt.Errorf("%w, expected chunk index %d, received %d", p2p.ErrInvalidChunk, ct.nextIndex, chunk.Index)
+	}
+	if len(chunk.Payload) == 0 {
+		return fmt.Errorf("%w, empty chunk payload", p2p.ErrInvalidChunk)
+	}
+	if uint64(len(ct.buff)+len(chunk.Payload)) > ct.totalSize {
+		return fmt.Errorf("%w, reassembled size exceeds the total size %d", p2p.ErrInvalidChunk, ct.totalSize)
+	}
+
+	ct.buff = append(ct.buff, chunk.Payload...)
+	ct.nextIndex++
+
+	if ct.isComplete() && uint64(len(ct.buff)) != ct.totalSize {
+		return fmt.Errorf("%w, reassembled size %d, expected %d", p2p.ErrInvalidChunk, len(ct.buff), ct.totalSize)
+	}
+
+	return nil
+}
+
+func (ct *chunkedTransfer) isComplete() bool {
+	return ct.nextIndex == ct.numChunks
+}
+
+// reassembledMessage returns the message as it was signed by the sender: the whole payload with the transfer ID as
+// sequence number
+func (ct *chunkedTransfer) reassembledMessage(signature []byte) *pubsubPb.Message {
+	topic := ct.topic
+
+	return &pubsubPb.Message{
+		From:      ct.from,
+		Data:      ct.buff,
+		Seqno:     ct.transferID,
+		Topic:     &topic,
+		Signature: signature,
+	}
+}
+
+func checkReassembledMessageSignature(signer p2p.SignerVerifier, message *pubsubPb.Message) error {
+	if len(message.Signature) == 0 {
+		return fmt.Errorf("%w, missing the signature of the reassembled payload", p2p.ErrInvalidChunk)
+	}
+
+	err := checkMessageSignature(signer, message)
+	if err != nil {
+		return fmt.Errorf("%w, invalid signature of the reassembled payload: %s", p2p.ErrInvalidChunk, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,463 @@
+package libp2p_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var blankChunkedMessageHandler = func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+	return nil
+}
+
+func createMockArgsChunkedSender() libp2p.ArgsChunkedSender {
+	return libp2p.ArgsChunkedSender{
+		Ctx:                    context.Background(),
+		Host:                   generateHostStub(),
+		MessageHandler:         blankChunkedMessageHandler,
+		Signer:                 createHashingSigner(),
+		ChunkSize:              10,
+		MaxPayloadSize:         100,
+		MaxBufferedSize:        1000,
+		MaxBufferedSizePerPeer: 200,
+		ReassemblyTimeout:      time.Second,
+	}
+}
+
+// createHashingSigner returns a signer whose signatures are the hashes of the signed payloads
+func createHashingSigner() *mock.P2PSignerStub {
+	return &mock.P2PSignerStub{
+		SignCalled: func(payload []byte) ([]byte, error) {
+			hash := sha256.Sum256(payload)
+			return hash[:], nil
+		},
+		VerifyCalled: func(payload []byte, pid core.PeerID, signature []byte) error {
+			hash := sha256.Sum256(payload)
+			if !bytes.Equal(hash[:], signature) {
+				return errors.New("invalid signature")
+			}
+
+			return nil
+		},
+	}
+}
+
+func createChunkedSenderOnHost(
+	h host.Host,
+	handler func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error,
+	signer p2p.SignerVerifier,
+) p2p.ChunkedSender {
+	args := createMockArgsChunkedSender()
+	args.Host = h
+	args.MessageHandler = handler
+	args.Signer = signer
+	cs, _ := libp2p.NewChunkedSender(args)
+
+	return cs
+}
+
+func TestNewChunkedSender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.Ctx = nil
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("nil host should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.Host = nil
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.Equal(t, p2p.ErrNilHost, err)
+	})
+	t.Run("nil message handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.MessageHandler = nil
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.Equal(t, p2p.ErrNilChunkedMessageHandler, err)
+	})
+	t.Run("nil signer should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.Signer = nil
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.Equal(t, p2p.ErrNilP2PSigner, err)
+	})
+	t.Run("invalid chunk size should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.ChunkSize = 0
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "ChunkSize")
+	})
+	t.Run("max payload size lower than the chunk size should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.MaxPayloadSize = args.ChunkSize - 1
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "MaxPayloadSize")
+	})
+	t.Run("max buffered size per peer lower than the max payload size should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.MaxBufferedSizePerPeer = args.MaxPayloadSize - 1
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "MaxBufferedSizePerPeer")
+	})
+	t.Run("max buffered size lower than the max buffered size per peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.MaxBufferedSize = args.MaxBufferedSizePerPeer - 1
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "MaxBufferedSize")
+	})
+	t.Run("invalid reassembly timeout should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		args.ReassemblyTimeout = 0
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.True(t, check.IfNil(cs))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Contains(t, err.Error(), "ReassemblyTimeout")
+	})
+	t.Run("should work and set the stream handler", func(t *testing.T) {
+		t.Parallel()
+
+		var pidCalled protocol.ID
+		var handlerCalled network.StreamHandler
+		args := createMockArgsChunkedSender()
+		args.Host = &mock.ConnectableHostStub{
+			SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {
+				pidCalled = pid
+				handlerCalled = handler
+			},
+		}
+		cs, err := libp2p.NewChunkedSender(args)
+
+		assert.False(t, check.IfNil(cs))
+		assert.Nil(t, err)
+		assert.NotNil(t, handlerCalled)
+		assert.Equal(t, libp2p.ChunkedSendID, pidCalled)
+	})
+}
+
+func TestChunkedSender_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty buffer should error", func(t *testing.T) {
+		t.Parallel()
+
+		cs, _ := libp2p.NewChunkedSender(createMockArgsChunkedSender())
+
+		err := cs.Send("topic", nil, "pid")
+		assert.Equal(t, p2p.ErrEmptyBufferToSend, err)
+	})
+	t.Run("buffer too large should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsChunkedSender()
+		cs, _ := libp2p.NewChunkedSender(args)
+
+		err := cs.Send("topic", make([]byte, args.MaxPayloadSize+1), "pid")
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+	})
+	t.Run("not connected peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		netw := &mock.NetworkStub{
+			ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+				return make([]network.Conn, 0)
+			},
+		}
+		args := createMockArgsChunkedSender()
+		args.Host = &mock.ConnectableHostStub{
+			SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {},
+			NetworkCalled: func() network.Network {
+				return netw
+			},
+		}
+		cs, _ := libp2p.NewChunkedSender(args)
+
+		err := cs.Send("topic", []byte("data"), "not connected peer")
+		assert.Equal(t, p2p.ErrPeerNotDirectlyConnected, err)
+	})
+	t.Run("should reassemble the chunks and call the message handler once", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		topic := "topic"
+		payload := bytes.Repeat([]byte("0123456789abcdef"), 5) // 80 bytes, 8 chunks
+		numCalls := uint32(0)
+		chanDone := make(chan struct{})
+		cs1 := createChunkedSenderOnHost(h1, blankChunkedMessageHandler, createHashingSigner())
+		_ = createChunkedSenderOnHost(
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				assert.Equal(t, payload, msg.Data)
+				assert.Equal(t, topic, *msg.Topic)
+				assert.Equal(t, []byte(h1.ID()), msg.From)
+				assert.Equal(t, core.PeerID(h1.ID()), fromConnectedPeer)
+				assert.Nil(t, libp2p.CheckMessageSignature(createHashingSigner(), msg.Message))
+				atomic.AddUint32(&numCalls, 1)
+				close(chanDone)
+
+				return nil
+			},
+			createHashingSigner(),
+		)
+
+		err := cs1.Send(topic, payload, core.PeerID(h2.ID()))
+		require.Nil(t, err)
+
+		select {
+		case <-chanDone:
+		case <-time.After(timeoutWaitResponses):
+			assert.Fail(t, "timeout waiting for the reassembled message")
+		}
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+	})
+	t.Run("payload larger than the receiver maximum should not call the message handler", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		args := createMockArgsChunkedSender()
+		args.Host = h1
+		args.MaxPayloadSize = args.MaxPayloadSize * 2
+		cs1, _ := libp2p.NewChunkedSender(args)
+		_ = createChunkedSenderOnHost(
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				assert.Fail(t, "should have not called the message handler")
+				return nil
+			},
+			createHashingSigner(),
+		)
+
+		_ = cs1.Send("topic", make([]byte, args.MaxPayloadSize), core.PeerID(h2.ID()))
+		time.Sleep(time.Millisecond * 500)
+	})
+	t.Run("signature verification fails should not call the message handler", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		cs1 := createChunkedSenderOnHost(
+			h1,
+			blankChunkedMessageHandler,
+			&mock.P2PSignerStub{
+				SignCalled: func(payload []byte) ([]byte, error) {
+					return []byte("signature"), nil
+				},
+			},
+		)
+		_ = createChunkedSenderOnHost(
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				assert.Fail(t, "should have not called the message handler")
+				return nil
+			},
+			&mock.P2PSignerStub{
+				VerifyCalled: func(payload []byte, pid core.PeerID, signature []byte) error {
+					return errors.New("invalid signature")
+				},
+			},
+		)
+
+		_ = cs1.Send("topic", []byte("data to be sent in chunks"), core.PeerID(h2.ID()))
+		time.Sleep(time.Millisecond * 500)
+	})
+	t.Run("missing signature of the reassembled payload should not call the message handler", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		// the empty signatures of the chunks are still tolerated, but not the empty signature of the whole payload
+		cs1 := createChunkedSenderOnHost(h1, blankChunkedMessageHandler, &mock.P2PSignerStub{})
+		_ = createChunkedSenderOnHost(
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				assert.Fail(t, "should have not called the message handler")
+				return nil
+			},
+			createHashingSigner(),
+		)
+
+		_ = cs1.Send("topic", []byte("data to be sent in chunks"), core.PeerID(h2.ID()))
+		time.Sleep(time.Millisecond * 500)
+	})
+	t.Run("exhausted buffer of the sending peer should not call the message handler", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		args := createMockArgsChunkedSender()
+		cs1 := createChunkedSenderOnHost(h1, blankChunkedMessageHandler, createHashingSigner())
+		args.Host = h2
+		args.MessageHandler = func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+			assert.Fail(t, "should have not called the message handler")
+			return nil
+		}
+		receiver, _ := libp2p.NewChunkedSender(args)
+		require.True(t, receiver.ReserveBufferedSize(h1.ID(), args.MaxBufferedSizePerPeer-5))
+
+		_ = cs1.Send("topic", []byte("data to be sent in chunks"), core.PeerID(h2.ID()))
+		time.Sleep(time.Millisecond * 500)
+		assert.Equal(t, args.MaxBufferedSizePerPeer-5, receiver.BufferedSize())
+	})
+	t.Run("whole transfer size should be reserved until the message is handled", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		payload := []byte("data to be sent in chunks")
+		chHandlerStarted := make(chan struct{})
+		chReleaseHandler := make(chan struct{})
+		args := createMockArgsChunkedSender()
+		cs1 := createChunkedSenderOnHost(h1, blankChunkedMessageHandler, createHashingSigner())
+		args.Host = h2
+		args.MessageHandler = func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+			close(chHandlerStarted)
+			<-chReleaseHandler
+			return nil
+		}
+		receiver, _ := libp2p.NewChunkedSender(args)
+
+		err := cs1.Send("topic", payload, core.PeerID(h2.ID()))
+		require.Nil(t, err)
+
+		select {
+		case <-chHandlerStarted:
+		case <-time.After(timeoutWaitResponses):
+			assert.Fail(t, "timeout waiting for the reassembled message")
+		}
+		assert.Equal(t, len(payload), receiver.BufferedSize())
+
+		close(chReleaseHandler)
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, 0, receiver.BufferedSize())
+	})
+	t.Run("too many transfers in progress should reset the stream", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		chHandlerStarted := make(chan struct{}, 1)
+		chReleaseHandler := make(chan struct{})
+		numCalls := uint32(0)
+		args := createMockArgsChunkedSender()
+		cs1 := createChunkedSenderOnHost(h1, blankChunkedMessageHandler, createHashingSigner())
+		args.Host = h2
+		args.MessageHandler = func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+			atomic.AddUint32(&numCalls, 1)
+			chHandlerStarted <- struct{}{}
+			<-chReleaseHandler
+			return nil
+		}
+		receiver, _ := libp2p.NewChunkedSender(args)
+		receiver.SetTransfersThrottler(1)
+
+		err := cs1.Send("topic", []byte("first data to be sent in chunks"), core.PeerID(h2.ID()))
+		require.Nil(t, err)
+		<-chHandlerStarted
+
+		_ = cs1.Send("topic", []byte("second data to be sent in chunks"), core.PeerID(h2.ID()))
+		time.Sleep(time.Millisecond * 500)
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numCalls))
+
+		close(chReleaseHandler)
+	})
+}
+
+func TestChunkedSender_ReserveBufferedSize(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsChunkedSender()
+	cs, _ := libp2p.NewChunkedSender(args)
+
+	assert.True(t, cs.ReserveBufferedSize("pid1", args.MaxBufferedSizePerPeer))
+	assert.False(t, cs.ReserveBufferedSize("pid1", 1))
+	assert.True(t, cs.ReserveBufferedSize("pid2", args.MaxBufferedSizePerPeer))
+	assert.Equal(t, 2*args.MaxBufferedSizePerPeer, cs.BufferedSize())
+}
@@ -0,0 +1,50 @@
+package compression
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-p2p-go"
+)
+
+// NoCompressionID is the codec ID written in the topic messages that are not compressed
+const NoCompressionID = uint32(0)
+
+type disabledCompressor struct {
+}
+
+// NewDisabledCompressor returns a compressor that leaves the payloads unchanged
+func NewDisabledCompressor() *disabledCompressor {
+	return &disabledCompressor{}
+}
+
+// Compress returns the provided buffer
+func (dc *disabledCompressor) Compress(buff []byte) ([]byte, error) {
+	return buff, nil
+}
+
+// Decompress returns the provided buffer if its size does not exceed the maximum allowed size
+func (dc *disabledCompressor) Decompress(buff []byte, maxDecompressedSize int) ([]byte, error) {
+	if maxDecompressedSize <= 0 {
+		return nil, ErrInvalidMaxDecompressedSize
+	}
+	if len(buff) > maxDecompressedSize {
+		return nil, fmt.Errorf("%w, size %d, maximum %d", ErrDecompressedSizeTooLarge, len(buff), maxDecompressedSize)
+	}
+
+	return buff, nil
+}
+
+// ID returns the codec ID
+func (dc *disabledCompressor) ID() uint32 {
+	return NoCompressionID
+}
+
+// Name returns the codec name
+func (dc *disabledCompressor) Name() string {
+	return p2p.NoCompression
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (dc *disabledCompressor) IsInterfaceNil() bool {
+	return dc == nil
+}
@@ -0,0 +1,38 @@
+package compression_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabledCompressor(t *testing.T) {
+	t.Parallel()
+
+	dc := compression.NewDisabledCompressor()
+	assert.False(t, check.IfNil(dc))
+	assert.Equal(t, compression.NoCompressionID, dc.ID())
+	assert.Equal(t, p2p.NoCompression, dc.Name())
+
+	buff := []byte("payload")
+	compressed, err := dc.Compress(buff)
+	assert.Nil(t, err)
+	assert.Equal(t, buff, compressed)
+
+	decompressed, err := dc.Decompress(compressed, len(buff))
+	assert.Nil(t, err)
+	assert.Equal(t, buff, decompressed)
+
+	decompressed, err = dc.Decompress(compressed, 0)
+	assert.Nil(t, decompressed)
+	assert.Equal(t, compression.ErrInvalidMaxDecompressedSize, err)
+
+	decompressed, err = dc.Decompress(bytes.Repeat([]byte("a"), 11), 10)
+	assert.Nil(t, decompressed)
+	assert.True(t, errors.Is(err, compression.ErrDecompressedSizeTooLarge))
+}
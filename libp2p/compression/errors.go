@@ -0,0 +1,9 @@
+package compression
+
+import "errors"
+
+// ErrDecompressedSizeTooLarge signals that the decompressed payload would exceed the maximum allowed size
+var ErrDecompressedSizeTooLarge = errors.New("decompressed size too large")
+
+// ErrInvalidMaxDecompressedSize signals that an invalid maximum decompressed size was provided
+var ErrInvalidMaxDecompressedSize = errors.New("invalid maximum decompressed size")
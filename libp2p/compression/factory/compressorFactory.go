@@ -0,0 +1,36 @@
+package factory
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+)
+
+// NewCompressor creates a new Compressor instance based on the provided compression type
+func NewCompressor(compressionType string) (p2p.Compressor, error) {
+	switch compressionType {
+	case p2p.NoCompression, "":
+		return compression.NewDisabledCompressor(), nil
+	case p2p.SnappyCompression:
+		return compression.NewSnappyCompressor(), nil
+	case p2p.ZstdCompression:
+		return compression.NewZstdCompressor()
+	default:
+		return nil, fmt.Errorf("%w %s", ErrUnknownCompressionType, compressionType)
+	}
+}
+
+// NewCompressorByID creates a new Compressor instance based on the codec ID found in a received message
+func NewCompressorByID(id uint32) (p2p.Compressor, error) {
+	switch id {
+	case compression.NoCompressionID:
+		return compression.NewDisabledCompressor(), nil
+	case compression.SnappyCompressionID:
+		return compression.NewSnappyCompressor(), nil
+	case compression.ZstdCompressionID:
+		return compression.NewZstdCompressor()
+	default:
+		return nil, fmt.Errorf("%w %d", ErrUnknownCompressionID, id)
+	}
+}
@@ -0,0 +1,74 @@
+package factory_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression/factory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompressor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no compression", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := factory.NewCompressor(p2p.NoCompression)
+		assert.Nil(t, err)
+		assert.Equal(t, "*compression.disabledCompressor", fmt.Sprintf("%T", c))
+	})
+	t.Run("empty compression", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := factory.NewCompressor("")
+		assert.Nil(t, err)
+		assert.Equal(t, "*compression.disabledCompressor", fmt.Sprintf("%T", c))
+	})
+	t.Run("snappy compression", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := factory.NewCompressor(p2p.SnappyCompression)
+		assert.Nil(t, err)
+		assert.Equal(t, "*compression.snappyCompressor", fmt.Sprintf("%T", c))
+	})
+	t.Run("zstd compression", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := factory.NewCompressor(p2p.ZstdCompression)
+		assert.Nil(t, err)
+		assert.Equal(t, "*compression.zstdCompressor", fmt.Sprintf("%T", c))
+	})
+	t.Run("unknown type", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := factory.NewCompressor("unknown")
+		assert.True(t, errors.Is(err, factory.ErrUnknownCompressionType))
+		assert.True(t, check.IfNil(c))
+	})
+}
+
+func TestNewCompressorByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known IDs", func(t *testing.T) {
+		t.Parallel()
+
+		for _, id := range []uint32{compression.NoCompressionID, compression.SnappyCompressionID, compression.ZstdCompressionID} {
+			c, err := factory.NewCompressorByID(id)
+			assert.Nil(t, err)
+			assert.Equal(t, id, c.ID())
+		}
+	})
+	t.Run("unknown ID", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := factory.NewCompressorByID(100)
+		assert.True(t, errors.Is(err, factory.ErrUnknownCompressionID))
+		assert.True(t, check.IfNil(c))
+	})
+}
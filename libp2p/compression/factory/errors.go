@@ -0,0 +1,9 @@
+package factory
+
+import "errors"
+
+// ErrUnknownCompressionType signals that an unknown compression type was provided
+var ErrUnknownCompressionType = errors.New("unknown compression type")
+
+// ErrUnknownCompressionID signals that an unknown compression codec ID was provided
+var ErrUnknownCompressionID = errors.New("unknown compression ID")
@@ -0,0 +1,57 @@
+package compression
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/multiversx/mx-chain-p2p-go"
+)
+
+// SnappyCompressionID is the codec ID written in the topic messages compressed with snappy
+const SnappyCompressionID = uint32(1)
+
+type snappyCompressor struct {
+}
+
+// NewSnappyCompressor returns a compressor that uses the snappy block format
+func NewSnappyCompressor() *snappyCompressor {
+	return &snappyCompressor{}
+}
+
+// Compress compresses the provided buffer
+func (sc *snappyCompressor) Compress(buff []byte) ([]byte, error) {
+	return snappy.Encode(nil, buff), nil
+}
+
+// Decompress decompresses the provided buffer. The decoded length is read from the block header and checked
+// against the maximum allowed size before any allocation is made
+func (sc *snappyCompressor) Decompress(buff []byte, maxDecompressedSize int) ([]byte, error) {
+	if maxDecompressedSize <= 0 {
+		return nil, ErrInvalidMaxDecompressedSize
+	}
+
+	decodedLen, err := snappy.DecodedLen(buff)
+	if err != nil {
+		return nil, err
+	}
+	if decodedLen > maxDecompressedSize {
+		return nil, fmt.Errorf("%w, size %d, maximum %d", ErrDecompressedSizeTooLarge, decodedLen, maxDecompressedSize)
+	}
+
+	return snappy.Decode(nil, buff)
+}
+
+// ID returns the codec ID
+func (sc *snappyCompressor) ID() uint32 {
+	return SnappyCompressionID
+}
+
+// Name returns the codec name
+func (sc *snappyCompressor) Name() string {
+	return p2p.SnappyCompression
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (sc *snappyCompressor) IsInterfaceNil() bool {
+	return sc == nil
+}
@@ -0,0 +1,58 @@
+package compression_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnappyCompressor(t *testing.T) {
+	t.Parallel()
+
+	sc := compression.NewSnappyCompressor()
+	assert.False(t, check.IfNil(sc))
+	assert.Equal(t, compression.SnappyCompressionID, sc.ID())
+	assert.Equal(t, p2p.SnappyCompression, sc.Name())
+
+	buff := bytes.Repeat([]byte("payload"), 1000)
+
+	t.Run("compress and decompress should work", func(t *testing.T) {
+		t.Parallel()
+
+		compressed, err := sc.Compress(buff)
+		assert.Nil(t, err)
+		assert.True(t, len(compressed) < len(buff))
+
+		decompressed, err := sc.Decompress(compressed, len(buff))
+		assert.Nil(t, err)
+		assert.Equal(t, buff, decompressed)
+	})
+	t.Run("invalid maximum size should error", func(t *testing.T) {
+		t.Parallel()
+
+		compressed, _ := sc.Compress(buff)
+		decompressed, err := sc.Decompress(compressed, 0)
+		assert.Nil(t, decompressed)
+		assert.Equal(t, compression.ErrInvalidMaxDecompressedSize, err)
+	})
+	t.Run("decompressed size exceeds the maximum should error", func(t *testing.T) {
+		t.Parallel()
+
+		compressed, _ := sc.Compress(buff)
+		decompressed, err := sc.Decompress(compressed, len(buff)-1)
+		assert.Nil(t, decompressed)
+		assert.True(t, errors.Is(err, compression.ErrDecompressedSizeTooLarge))
+	})
+	t.Run("corrupted data should error", func(t *testing.T) {
+		t.Parallel()
+
+		decompressed, err := sc.Decompress([]byte("not a snappy block"), len(buff))
+		assert.Nil(t, decompressed)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,98 @@
+package compression
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/multiversx/mx-chain-p2p-go"
+)
+
+// ZstdCompressionID is the codec ID written in the topic messages compressed with zstd
+const ZstdCompressionID = uint32(2)
+
+// MaxDecompressedPayloadSize is the maximum size a received payload can have after decompression. It is also the hard
+// limit of memory the zstd decoder is allowed to allocate for one payload, so that the frames without the content
+// size in their header are not decoded past this size
+const MaxDecompressedPayloadSize = 1 << 24 // 16 MB
+
+// the encoder and the decoder are safe for concurrent use when calling EncodeAll and DecodeAll so they are shared
+// between all the zstd compressor instances
+var (
+	onceZstd       sync.Once
+	zstdEncoder    *zstd.Encoder
+	zstdDecoder    *zstd.Decoder
+	errZstdCreator error
+)
+
+type zstdCompressor struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// NewZstdCompressor returns a compressor that uses the zstd format
+func NewZstdCompressor() (*zstdCompressor, error) {
+	onceZstd.Do(func() {
+		zstdEncoder, errZstdCreator = zstd.NewWriter(nil)
+		if errZstdCreator != nil {
+			return
+		}
+
+		zstdDecoder, errZstdCreator = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxDecompressedPayloadSize))
+	})
+	if errZstdCreator != nil {
+		return nil, errZstdCreator
+	}
+
+	return &zstdCompressor{
+		encoder: zstdEncoder,
+		decoder: zstdDecoder,
+	}, nil
+}
+
+// Compress compresses the provided buffer
+func (zc *zstdCompressor) Compress(buff []byte) ([]byte, error) {
+	return zc.encoder.EncodeAll(buff, nil), nil
+}
+
+// Decompress decompresses the provided buffer. If the frame header contains the content size, it is checked against
+// the maximum allowed size before decoding, otherwise the check is done on the decoded result
+func (zc *zstdCompressor) Decompress(buff []byte, maxDecompressedSize int) ([]byte, error) {
+	if maxDecompressedSize <= 0 {
+		return nil, ErrInvalidMaxDecompressedSize
+	}
+
+	header := zstd.Header{}
+	err := header.Decode(buff)
+	if err != nil {
+		return nil, err
+	}
+	if header.HasFCS && header.FrameContentSize > uint64(maxDecompressedSize) {
+		return nil, fmt.Errorf("%w, size %d, maximum %d", ErrDecompressedSizeTooLarge, header.FrameContentSize, maxDecompressedSize)
+	}
+
+	decompressed, err := zc.decoder.DecodeAll(buff, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) > maxDecompressedSize {
+		return nil, fmt.Errorf("%w, size %d, maximum %d", ErrDecompressedSizeTooLarge, len(decompressed), maxDecompressedSize)
+	}
+
+	return decompressed, nil
+}
+
+// ID returns the codec ID
+func (zc *zstdCompressor) ID() uint32 {
+	return ZstdCompressionID
+}
+
+// Name returns the codec name
+func (zc *zstdCompressor) Name() string {
+	return p2p.ZstdCompression
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (zc *zstdCompressor) IsInterfaceNil() bool {
+	return zc == nil
+}
@@ -0,0 +1,78 @@
+package compression_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZstdCompressor(t *testing.T) {
+	t.Parallel()
+
+	zc, err := compression.NewZstdCompressor()
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(zc))
+	assert.Equal(t, compression.ZstdCompressionID, zc.ID())
+	assert.Equal(t, p2p.ZstdCompression, zc.Name())
+
+	buff := bytes.Repeat([]byte("payload"), 1000)
+
+	t.Run("compress and decompress should work", func(t *testing.T) {
+		t.Parallel()
+
+		compressed, errCompress := zc.Compress(buff)
+		assert.Nil(t, errCompress)
+		assert.True(t, len(compressed) < len(buff))
+
+		decompressed, errDecompress := zc.Decompress(compressed, len(buff))
+		assert.Nil(t, errDecompress)
+		assert.Equal(t, buff, decompressed)
+	})
+	t.Run("invalid maximum size should error", func(t *testing.T) {
+		t.Parallel()
+
+		compressed, _ := zc.Compress(buff)
+		decompressed, errDecompress := zc.Decompress(compressed, 0)
+		assert.Nil(t, decompressed)
+		assert.Equal(t, compression.ErrInvalidMaxDecompressedSize, errDecompress)
+	})
+	t.Run("decompressed size exceeds the maximum should error", func(t *testing.T) {
+		t.Parallel()
+
+		bomb := make([]byte, 1<<20)
+		compressed, _ := zc.Compress(bomb)
+		decompressed, errDecompress := zc.Decompress(compressed, len(buff))
+		assert.Nil(t, decompressed)
+		assert.True(t, errors.Is(errDecompress, compression.ErrDecompressedSizeTooLarge))
+	})
+	t.Run("frame without content size is not decoded past the maximum payload size", func(t *testing.T) {
+		t.Parallel()
+
+		compressedBuff := &bytes.Buffer{}
+		encoder, _ := zstd.NewWriter(compressedBuff)
+		_, _ = encoder.Write(make([]byte, compression.MaxDecompressedPayloadSize+1))
+		_ = encoder.Close()
+
+		header := zstd.Header{}
+		_ = header.Decode(compressedBuff.Bytes())
+		require.False(t, header.HasFCS)
+
+		decompressed, errDecompress := zc.Decompress(compressedBuff.Bytes(), compression.MaxDecompressedPayloadSize*2)
+		assert.Nil(t, decompressed)
+		assert.NotNil(t, errDecompress)
+	})
+	t.Run("corrupted data should error", func(t *testing.T) {
+		t.Parallel()
+
+		decompressed, errDecompress := zc.Decompress([]byte("not a zstd frame"), len(buff))
+		assert.Nil(t, decompressed)
+		assert.NotNil(t, errDecompress)
+	})
+}
@@ -0,0 +1,45 @@
+package libp2p
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// connectionAgeProvider computes the connection age of a peer from its oldest opened connection
+type connectionAgeProvider struct {
+	network network.Network
+}
+
+func newConnectionAgeProvider(network network.Network) *connectionAgeProvider {
+	return &connectionAgeProvider{
+		network: network,
+	}
+}
+
+// ConnectionAge returns for how long the host has been connected to the provided peer. Returns 0 if the peer
+// is not connected
+func (provider *connectionAgeProvider) ConnectionAge(pid peer.ID) time.Duration {
+	var oldest time.Time
+	for _, conn := range provider.network.ConnsToPeer(pid) {
+		opened := conn.Stat().Opened
+		if opened.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || opened.Before(oldest) {
+			oldest = opened
+		}
+	}
+
+	if oldest.IsZero() {
+		return 0
+	}
+
+	return time.Since(oldest)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (provider *connectionAgeProvider) IsInterfaceNil() bool {
+	return provider == nil
+}
@@ -0,0 +1,54 @@
+package libp2p_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createConnStubOpenedAt(opened time.Time) network.Conn {
+	return &mock.ConnStub{
+		StatCalled: func() network.ConnStats {
+			return network.ConnStats{
+				Stats: network.Stats{
+					Opened: opened,
+				},
+			}
+		},
+	}
+}
+
+func TestConnectionAgeProvider_ConnectionAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	provider := libp2p.NewConnectionAgeProvider(&mock.NetworkStub{
+		ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+			switch p {
+			case "connected":
+				return []network.Conn{
+					createConnStubOpenedAt(now.Add(-time.Minute)),
+					createConnStubOpenedAt(now.Add(-time.Hour)),
+					createConnStubOpenedAt(time.Time{}),
+				}
+			case "unknown opened time":
+				return []network.Conn{createConnStubOpenedAt(time.Time{})}
+			default:
+				return nil
+			}
+		},
+	})
+	assert.False(t, check.IfNil(provider))
+
+	age := provider.ConnectionAge("connected")
+	assert.True(t, age >= time.Hour)
+	assert.True(t, age < time.Hour+time.Minute)
+	assert.Equal(t, time.Duration(0), provider.ConnectionAge("unknown opened time"))
+	assert.Equal(t, time.Duration(0), provider.ConnectionAge("not connected"))
+}
@@ -0,0 +1,111 @@
+package libp2p
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/disabled"
+)
+
+var _ connmgr.ConnectionGater = (*connectionGater)(nil)
+
+// connectionGater is consulted by the host before dialing and while accepting connections, so denied peers and
+// addresses never complete the handshake
+type connectionGater struct {
+	mutPeerDenialEvaluator sync.RWMutex
+	peerDenialEvaluator    p2p.PeerDenialEvaluator
+	addressDenialEvaluator p2p.AddressDenialEvaluator
+}
+
+func newConnectionGater(addressDenialEvaluator p2p.AddressDenialEvaluator) (*connectionGater, error) {
+	if check.IfNil(addressDenialEvaluator) {
+		return nil, p2p.ErrNilAddressDenialEvaluator
+	}
+
+	return &connectionGater{
+		peerDenialEvaluator:    &disabled.PeerDenialEvaluator{},
+		addressDenialEvaluator: addressDenialEvaluator,
+	}, nil
+}
+
+// InterceptPeerDial tests whether we're permitted to dial the specified peer
+func (gater *connectionGater) InterceptPeerDial(p peer.ID) bool {
+	return !gater.isPeerDenied(p)
+}
+
+// InterceptAddrDial tests whether we're permitted to dial the specified multiaddr for the given peer
+func (gater *connectionGater) InterceptAddrDial(p peer.ID, address multiaddr.Multiaddr) bool {
+	return !gater.isPeerDenied(p) && !gater.isAddressDenied(address)
+}
+
+// InterceptAccept tests whether an incipient inbound connection is allowed
+func (gater *connectionGater) InterceptAccept(addresses network.ConnMultiaddrs) bool {
+	return !gater.isAddressDenied(addresses.RemoteMultiaddr())
+}
+
+// InterceptSecured tests whether a given connection, now authenticated, is allowed
+func (gater *connectionGater) InterceptSecured(_ network.Direction, p peer.ID, addresses network.ConnMultiaddrs) bool {
+	remoteAddress := addresses.RemoteMultiaddr()
+	if gater.isPeerDenied(p) || gater.isAddressDenied(remoteAddress) {
+		return false
+	}
+
+	canAccept := gater.addressDenialEvaluator.CanAcceptPeer(core.PeerID(p), remoteAddress)
+	if !canAccept {
+		log.Trace("connection gater: peer not accepted", "pid", p.String(), "address", remoteAddress)
+	}
+
+	return canAccept
+}
+
+// InterceptUpgraded allows all the fully capable connections
+func (gater *connectionGater) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+func (gater *connectionGater) isPeerDenied(p peer.ID) bool {
+	gater.mutPeerDenialEvaluator.RLock()
+	peerDenialEvaluator := gater.peerDenialEvaluator
+	gater.mutPeerDenialEvaluator.RUnlock()
+
+	isDenied := peerDenialEvaluator.IsDenied(core.PeerID(p))
+	if isDenied {
+		log.Trace("connection gater: denied peer", "pid", p.String())
+	}
+
+	return isDenied
+}
+
+func (gater *connectionGater) isAddressDenied(address multiaddr.Multiaddr) bool {
+	isDenied := gater.addressDenialEvaluator.IsAddressDenied(address)
+	if isDenied {
+		log.Trace("connection gater: denied address", "address", address.String())
+	}
+
+	return isDenied
+}
+
+// SetPeerDenialEvaluator sets the peer denial evaluator consulted on each dialed or accepted connection
+func (gater *connectionGater) SetPeerDenialEvaluator(handler p2p.PeerDenialEvaluator) error {
+	if check.IfNil(handler) {
+		return p2p.ErrNilPeerDenialEvaluator
+	}
+
+	gater.mutPeerDenialEvaluator.Lock()
+	gater.peerDenialEvaluator = handler
+	gater.mutPeerDenialEvaluator.Unlock()
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gater *connectionGater) IsInterfaceNil() bool {
+	return gater == nil
+}
@@ -0,0 +1,157 @@
+package libp2p_test
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/addressDenial"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type connMultiaddrs struct {
+	local  multiaddr.Multiaddr
+	remote multiaddr.Multiaddr
+}
+
+func (cm *connMultiaddrs) LocalMultiaddr() multiaddr.Multiaddr {
+	return cm.local
+}
+
+func (cm *connMultiaddrs) RemoteMultiaddr() multiaddr.Multiaddr {
+	return cm.remote
+}
+
+func createConnMultiaddrs(remote string) network.ConnMultiaddrs {
+	return &connMultiaddrs{
+		local:  multiaddr.StringCast("/ip4/127.0.0.1/tcp/10000"),
+		remote: multiaddr.StringCast(remote),
+	}
+}
+
+func createDenyingPeerDenialEvaluator(deniedPid core.PeerID) p2p.PeerDenialEvaluator {
+	return &mock.PeerDenialEvaluatorStub{
+		IsDeniedCalled: func(pid core.PeerID) bool {
+			return pid == deniedPid
+		},
+	}
+}
+
+func TestNewConnectionGater(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil address denial evaluator should error", func(t *testing.T) {
+		t.Parallel()
+
+		gater, err := libp2p.NewConnectionGater(nil)
+		assert.True(t, check.IfNil(gater))
+		assert.Equal(t, p2p.ErrNilAddressDenialEvaluator, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		gater, err := libp2p.NewConnectionGater(&mock.AddressDenialEvaluatorStub{})
+		assert.False(t, check.IfNil(gater))
+		assert.Nil(t, err)
+	})
+}
+
+func TestConnectionGater_PeerDenial(t *testing.T) {
+	t.Parallel()
+
+	deniedPid := core.PeerID("denied pid")
+	allowedPid := core.PeerID("allowed pid")
+	addresses := createConnMultiaddrs("/ip4/192.168.0.1/tcp/10000")
+
+	gater, _ := libp2p.NewConnectionGater(&mock.AddressDenialEvaluatorStub{})
+	assert.True(t, gater.InterceptPeerDial(peer.ID(deniedPid)))
+
+	err := gater.SetPeerDenialEvaluator(nil)
+	assert.Equal(t, p2p.ErrNilPeerDenialEvaluator, err)
+
+	err = gater.SetPeerDenialEvaluator(createDenyingPeerDenialEvaluator(deniedPid))
+	require.Nil(t, err)
+
+	assert.False(t, gater.InterceptPeerDial(peer.ID(deniedPid)))
+	assert.False(t, gater.InterceptAddrDial(peer.ID(deniedPid), addresses.RemoteMultiaddr()))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, peer.ID(deniedPid), addresses))
+	assert.True(t, gater.InterceptPeerDial(peer.ID(allowedPid)))
+	assert.True(t, gater.InterceptAddrDial(peer.ID(allowedPid), addresses.RemoteMultiaddr()))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, peer.ID(allowedPid), addresses))
+	assert.True(t, gater.InterceptAccept(addresses))
+
+	allow, _ := gater.InterceptUpgraded(&mock.ConnStub{})
+	assert.True(t, allow)
+}
+
+func TestConnectionGater_AddressDenial(t *testing.T) {
+	t.Parallel()
+
+	addressDenialEvaluator, _ := addressDenial.NewAddressDenialEvaluator(config.AddressDenialConfig{}, config.ConnectionGaterConfig{
+		DeniedIPs:     []string{"1.2.3.4", "2001:db8::1"},
+		DeniedSubnets: []string{"10.0.0.0/8"},
+	})
+	gater, _ := libp2p.NewConnectionGater(addressDenialEvaluator)
+	pid := peer.ID("pid")
+
+	deniedAddresses := []string{
+		"/ip4/1.2.3.4/tcp/10000",
+		"/ip4/1.2.3.4/udp/10000/quic-v1",
+		"/ip6/2001:db8::1/tcp/10000",
+		"/ip4/10.20.30.40/tcp/10000",
+	}
+	for _, address := range deniedAddresses {
+		addresses := createConnMultiaddrs(address)
+		assert.False(t, gater.InterceptAccept(addresses), address)
+		assert.False(t, gater.InterceptSecured(network.DirOutbound, pid, addresses), address)
+		assert.False(t, gater.InterceptAddrDial(pid, addresses.RemoteMultiaddr()), address)
+	}
+
+	allowedAddresses := []string{
+		"/ip4/1.2.3.5/tcp/10000",
+		"/ip6/2001:db8::2/tcp/10000",
+		"/ip4/11.0.0.1/tcp/10000",
+		"/dns4/example.com/tcp/10000",
+	}
+	for _, address := range allowedAddresses {
+		addresses := createConnMultiaddrs(address)
+		assert.True(t, gater.InterceptAccept(addresses), address)
+		assert.True(t, gater.InterceptSecured(network.DirOutbound, pid, addresses), address)
+		assert.True(t, gater.InterceptAddrDial(pid, addresses.RemoteMultiaddr()), address)
+	}
+}
+
+func TestConnectionGater_AddressDenialEvaluator(t *testing.T) {
+	t.Parallel()
+
+	deniedAddress := "/ip4/1.2.3.4/tcp/10000"
+	limitedPid := peer.ID("limited pid")
+	gater, _ := libp2p.NewConnectionGater(&mock.AddressDenialEvaluatorStub{
+		IsAddressDeniedCalled: func(address multiaddr.Multiaddr) bool {
+			return address.String() == deniedAddress
+		},
+		CanAcceptPeerCalled: func(pid core.PeerID, address multiaddr.Multiaddr) bool {
+			return pid != core.PeerID(limitedPid)
+		},
+	})
+	pid := peer.ID("pid")
+
+	addresses := createConnMultiaddrs(deniedAddress)
+	assert.False(t, gater.InterceptAccept(addresses))
+	assert.False(t, gater.InterceptAddrDial(pid, addresses.RemoteMultiaddr()))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, pid, addresses))
+
+	addresses = createConnMultiaddrs("/ip4/1.2.3.5/tcp/10000")
+	assert.True(t, gater.InterceptAccept(addresses))
+	assert.True(t, gater.InterceptAddrDial(pid, addresses.RemoteMultiaddr()))
+	assert.True(t, gater.InterceptSecured(network.DirInbound, pid, addresses))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, limitedPid, addresses))
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -92,6 +93,16 @@ func (lcms *libp2pConnectionMonitorSimple) Connected(netw network.Network, conn
 	lcms.connectionsWatcher.NewKnownConnection(peerId, connectionStr)
 	lcms.preferredPeersHolder.PutConnectionAddress(peerId, connectionStr)
 
+	lcms.evictPeers(netw, allPeers)
+}
+
+// EvictUnwantedPeers does an eviction pass on all the connected peers, closing the connections to the peers
+// the sharder does not want to keep
+func (lcms *libp2pConnectionMonitorSimple) EvictUnwantedPeers(netw network.Network) {
+	lcms.evictPeers(netw, netw.Peers())
+}
+
+func (lcms *libp2pConnectionMonitorSimple) evictPeers(netw network.Network, allPeers []peer.ID) {
 	evicted := lcms.sharder.ComputeEvictionList(allPeers)
 	for _, pid := range evicted {
 		_ = netw.ClosePeer(pid)
@@ -163,6 +163,33 @@ func TestLibp2pConnectionMonitorSimple_ConnectedWithSharderShouldCallEvictAndClo
 	assert.True(t, putConnectionAddressCalled)
 }
 
+func TestLibp2pConnectionMonitorSimple_EvictUnwantedPeersShouldCloseEvictedPeers(t *testing.T) {
+	t.Parallel()
+
+	connectedPeers := []peer.ID{"pid1", "pid2", "evicted"}
+	args := createMockArgsConnectionMonitorSimple()
+	args.Sharder = &mock.KadSharderStub{
+		ComputeEvictListCalled: func(pidList []peer.ID) []peer.ID {
+			assert.Equal(t, connectedPeers, pidList)
+			return []peer.ID{"evicted"}
+		},
+	}
+	lcms, _ := connectionMonitor.NewLibp2pConnectionMonitorSimple(args)
+
+	closedPeers := make([]peer.ID, 0)
+	lcms.EvictUnwantedPeers(&mock.NetworkStub{
+		ClosePeerCall: func(id peer.ID) error {
+			closedPeers = append(closedPeers, id)
+			return nil
+		},
+		PeersCall: func() []peer.ID {
+			return connectedPeers
+		},
+	})
+
+	assert.Equal(t, []peer.ID{"evicted"}, closedPeers)
+}
+
 func TestNewLibp2pConnectionMonitorSimple_DisconnectedShouldRemovePeerFromPreferredPeers(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
@@ -17,20 +18,23 @@ var _ ConnectionMonitor = (*connectionMonitorWrapper)(nil)
 // it handles black list peers
 type connectionMonitorWrapper struct {
 	ConnectionMonitor
-	network             network.Network
-	mutPeerBlackList    sync.RWMutex
-	peerDenialEvaluator p2p.PeerDenialEvaluator
+	network                network.Network
+	mutPeerBlackList       sync.RWMutex
+	peerDenialEvaluator    p2p.PeerDenialEvaluator
+	addressDenialEvaluator p2p.AddressDenialEvaluator
 }
 
 func newConnectionMonitorWrapper(
 	network network.Network,
 	connMonitor ConnectionMonitor,
 	peerDenialEvaluator p2p.PeerDenialEvaluator,
+	addressDenialEvaluator p2p.AddressDenialEvaluator,
 ) *connectionMonitorWrapper {
 	return &connectionMonitorWrapper{
-		ConnectionMonitor:   connMonitor,
-		network:             network,
-		peerDenialEvaluator: peerDenialEvaluator,
+		ConnectionMonitor:      connMonitor,
+		network:                network,
+		peerDenialEvaluator:    peerDenialEvaluator,
+		addressDenialEvaluator: addressDenialEvaluator,
 	}
 }
 
@@ -51,24 +55,42 @@ func (cmw *connectionMonitorWrapper) Connected(netw network.Network, conn networ
 	cmw.mutPeerBlackList.RUnlock()
 
 	pid := conn.RemotePeer()
+	remoteAddress := conn.RemoteMultiaddr()
 	if peerBlackList.IsDenied(core.PeerID(pid)) {
 		log.Trace("dropping connection to blacklisted peer",
 			"pid", pid.String(),
 		)
+		// the address is recorded so the IP of the denied peer can also be denied
+		cmw.addressDenialEvaluator.AddConnectedPeer(core.PeerID(pid), remoteAddress)
+		cmw.addressDenialEvaluator.DenyPeerAddresses(core.PeerID(pid))
 		_ = conn.Close()
 
 		return
 	}
+	if !cmw.addressDenialEvaluator.CanAcceptPeer(core.PeerID(pid), remoteAddress) {
+		log.Trace("dropping connection to peer with denied address",
+			"pid", pid.String(),
+			"address", remoteAddress,
+		)
+		_ = conn.Close()
 
+		return
+	}
+
+	cmw.addressDenialEvaluator.AddConnectedPeer(core.PeerID(pid), remoteAddress)
 	cmw.ConnectionMonitor.Connected(netw, conn)
 }
 
 // Disconnected is called when a connection closed
 func (cmw *connectionMonitorWrapper) Disconnected(netw network.Network, conn network.Conn) {
+	if conn != nil {
+		cmw.addressDenialEvaluator.RemoveConnectedPeer(core.PeerID(conn.RemotePeer()), conn.RemoteMultiaddr())
+	}
 	cmw.ConnectionMonitor.Disconnected(netw, conn)
 }
 
-// CheckConnectionsBlocking does a peer sweep, calling Close on those peers that are black listed
+// CheckConnectionsBlocking does a peer sweep, calling Close on those peers that are black listed or are connected
+// from a denied address
 func (cmw *connectionMonitorWrapper) CheckConnectionsBlocking() {
 	peers := cmw.network.Peers()
 	cmw.mutPeerBlackList.RLock()
@@ -80,11 +102,31 @@ func (cmw *connectionMonitorWrapper) CheckConnectionsBlocking() {
 			log.Trace("dropping connection to blacklisted peer",
 				"pid", pid.String(),
 			)
+			cmw.addressDenialEvaluator.DenyPeerAddresses(core.PeerID(pid))
+			_ = cmw.network.ClosePeer(pid)
+
+			continue
+		}
+
+		if cmw.isConnectedFromDeniedAddress(pid) {
+			log.Trace("dropping connection to peer with denied address",
+				"pid", pid.String(),
+			)
 			_ = cmw.network.ClosePeer(pid)
 		}
 	}
 }
 
+func (cmw *connectionMonitorWrapper) isConnectedFromDeniedAddress(pid peer.ID) bool {
+	for _, conn := range cmw.network.ConnsToPeer(pid) {
+		if cmw.addressDenialEvaluator.IsAddressDenied(conn.RemoteMultiaddr()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // SetPeerDenialEvaluator sets the handler that is able to tell if a peer can connect to self or not (is or not blacklisted)
 func (cmw *connectionMonitorWrapper) SetPeerDenialEvaluator(handler p2p.PeerDenialEvaluator) error {
 	if check.IfNil(handler) {
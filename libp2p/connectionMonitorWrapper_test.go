@@ -30,6 +30,7 @@ func TestNewConnectionMonitorWrapper_ShouldWork(t *testing.T) {
 		&mock.NetworkStub{},
 		&mock.ConnectionMonitorStub{},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.AddressDenialEvaluatorStub{},
 	)
 
 	assert.False(t, check.IfNil(cmw))
@@ -56,6 +57,7 @@ func TestConnectionMonitorNotifier_ConnectedBlackListedShouldCallClose(t *testin
 				return true
 			},
 		},
+		&mock.AddressDenialEvaluatorStub{},
 	)
 
 	cmw.Connected(networkInstance, conn)
@@ -81,6 +83,7 @@ func TestConnectionMonitorNotifier_ConnectedNotBlackListedShouldCallConnected(t
 				return false
 			},
 		},
+		&mock.AddressDenialEvaluatorStub{},
 	)
 
 	cmw.Connected(networkInstance, conn)
@@ -88,6 +91,115 @@ func TestConnectionMonitorNotifier_ConnectedNotBlackListedShouldCallConnected(t
 	assert.True(t, peerConnectedCalled)
 }
 
+func TestConnectionMonitorNotifier_ConnectedBlackListedShouldDenyPeerAddresses(t *testing.T) {
+	t.Parallel()
+
+	conn := createStubConn()
+	conn.CloseCalled = func() error {
+		return nil
+	}
+	addConnectedPeerCalled := false
+	denyPeerAddressesCalled := false
+	networkInstance := &mock.NetworkStub{}
+	cmw := libp2p.NewConnectionMonitorWrapper(
+		networkInstance,
+		&mock.ConnectionMonitorStub{
+			ConnectedCalled: func(netw network.Network, conn network.Conn) {
+				assert.Fail(t, "should have not called Connected")
+			},
+		},
+		&mock.PeerDenialEvaluatorStub{
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return true
+			},
+		},
+		&mock.AddressDenialEvaluatorStub{
+			AddConnectedPeerCalled: func(pid core.PeerID, address multiaddr.Multiaddr) {
+				addConnectedPeerCalled = true
+			},
+			DenyPeerAddressesCalled: func(pid core.PeerID) {
+				assert.True(t, addConnectedPeerCalled)
+				denyPeerAddressesCalled = true
+			},
+		},
+	)
+
+	cmw.Connected(networkInstance, conn)
+
+	assert.True(t, denyPeerAddressesCalled)
+}
+
+func TestConnectionMonitorNotifier_ConnectedNotAcceptedShouldCallClose(t *testing.T) {
+	t.Parallel()
+
+	peerCloseCalled := false
+	conn := createStubConn()
+	conn.CloseCalled = func() error {
+		peerCloseCalled = true
+
+		return nil
+	}
+	networkInstance := &mock.NetworkStub{}
+	cmw := libp2p.NewConnectionMonitorWrapper(
+		networkInstance,
+		&mock.ConnectionMonitorStub{
+			ConnectedCalled: func(netw network.Network, conn network.Conn) {
+				assert.Fail(t, "should have not called Connected")
+			},
+		},
+		&mock.PeerDenialEvaluatorStub{
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return false
+			},
+		},
+		&mock.AddressDenialEvaluatorStub{
+			CanAcceptPeerCalled: func(pid core.PeerID, address multiaddr.Multiaddr) bool {
+				return false
+			},
+			AddConnectedPeerCalled: func(pid core.PeerID, address multiaddr.Multiaddr) {
+				assert.Fail(t, "should have not called AddConnectedPeer")
+			},
+		},
+	)
+
+	cmw.Connected(networkInstance, conn)
+
+	assert.True(t, peerCloseCalled)
+}
+
+func TestConnectionMonitorNotifier_ConnectedAndDisconnectedShouldUpdateAddressDenialEvaluator(t *testing.T) {
+	t.Parallel()
+
+	conn := createStubConn()
+	numConnected := 0
+	networkInstance := &mock.NetworkStub{}
+	cmw := libp2p.NewConnectionMonitorWrapper(
+		networkInstance,
+		&mock.ConnectionMonitorStub{},
+		&mock.PeerDenialEvaluatorStub{
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return false
+			},
+		},
+		&mock.AddressDenialEvaluatorStub{
+			AddConnectedPeerCalled: func(pid core.PeerID, address multiaddr.Multiaddr) {
+				assert.Equal(t, core.PeerID(conn.RemotePeer()), pid)
+				numConnected++
+			},
+			RemoveConnectedPeerCalled: func(pid core.PeerID, address multiaddr.Multiaddr) {
+				assert.Equal(t, core.PeerID(conn.RemotePeer()), pid)
+				numConnected--
+			},
+		},
+	)
+
+	cmw.Connected(networkInstance, conn)
+	assert.Equal(t, 1, numConnected)
+
+	cmw.Disconnected(networkInstance, conn)
+	assert.Equal(t, 0, numConnected)
+}
+
 // ------- Functions
 
 func TestConnectionMonitorNotifier_FunctionsShouldCallHandler(t *testing.T) {
@@ -110,6 +222,7 @@ func TestConnectionMonitorNotifier_FunctionsShouldCallHandler(t *testing.T) {
 			},
 		},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.AddressDenialEvaluatorStub{},
 	)
 
 	cmw.Listen(nil, nil)
@@ -130,6 +243,7 @@ func TestConnectionMonitorWrapper_SetBlackListHandlerNilHandlerShouldErr(t *test
 		&mock.NetworkStub{},
 		&mock.ConnectionMonitorStub{},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.AddressDenialEvaluatorStub{},
 	)
 
 	err := cmw.SetPeerDenialEvaluator(nil)
@@ -144,6 +258,7 @@ func TestConnectionMonitorWrapper_SetBlackListHandlerShouldWork(t *testing.T) {
 		&mock.NetworkStub{},
 		&mock.ConnectionMonitorStub{},
 		&mock.PeerDenialEvaluatorStub{},
+		&mock.AddressDenialEvaluatorStub{},
 	)
 	newPeerDenialEvaluator := &mock.PeerDenialEvaluatorStub{}
 
@@ -183,6 +298,61 @@ func TestConnectionMonitorWrapper_CheckConnectionsBlockingShouldWork(t *testing.
 				return bytes.Equal(core.PeerID(blackListPeer).Bytes(), pid.Bytes())
 			},
 		},
+		&mock.AddressDenialEvaluatorStub{},
+	)
+
+	cmw.CheckConnectionsBlocking()
+	assert.Equal(t, 1, closeCalled)
+}
+
+func TestConnectionMonitorWrapper_CheckConnectionsBlockingShouldCloseDeniedAddresses(t *testing.T) {
+	t.Parallel()
+
+	allowedPeer := peer.ID("allowed")
+	deniedAddressPeer := peer.ID("denied address")
+	deniedAddress := multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")
+	allowedAddress := multiaddr.StringCast("/ip4/1.2.3.5/tcp/10000")
+	closeCalled := 0
+	cmw := libp2p.NewConnectionMonitorWrapper(
+		&mock.NetworkStub{
+			PeersCall: func() []peer.ID {
+				return []peer.ID{allowedPeer, deniedAddressPeer}
+			},
+			ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+				address := allowedAddress
+				if p == deniedAddressPeer {
+					address = deniedAddress
+				}
+
+				return []network.Conn{
+					&mock.ConnStub{
+						RemoteMultiaddrCalled: func() multiaddr.Multiaddr {
+							return address
+						},
+					},
+				}
+			},
+			ClosePeerCall: func(id peer.ID) error {
+				if id == deniedAddressPeer {
+					closeCalled++
+					return nil
+				}
+				assert.Fail(t, "should have called only the peer with denied address")
+
+				return nil
+			},
+		},
+		&mock.ConnectionMonitorStub{},
+		&mock.PeerDenialEvaluatorStub{
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return false
+			},
+		},
+		&mock.AddressDenialEvaluatorStub{
+			IsAddressDeniedCalled: func(address multiaddr.Multiaddr) bool {
+				return address.Equal(deniedAddress)
+			},
+		},
 	)
 
 	cmw.CheckConnectionsBlocking()
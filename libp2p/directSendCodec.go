@@ -0,0 +1,130 @@
+package libp2p
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	pubsubPb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+var _ DirectSendCodec = (*protobufDirectSendCodec)(nil)
+var _ DirectSendCodec = (*compressedDirectSendCodec)(nil)
+
+type protobufDirectSendCodec struct {
+}
+
+// NewProtobufDirectSendCodec creates the codec used by the 1.0.0 direct send protocol: the messages are sent as they
+// are, protobuf encoded
+func NewProtobufDirectSendCodec() *protobufDirectSendCodec {
+	return &protobufDirectSendCodec{}
+}
+
+// Encode marshals the message
+func (codec *protobufDirectSendCodec) Encode(msg *pubsubPb.Message) ([]byte, error) {
+	return msg.Marshal()
+}
+
+// Decode unmarshals the message
+func (codec *protobufDirectSendCodec) Decode(buff []byte) (*pubsubPb.Message, error) {
+	msg := &pubsubPb.Message{}
+	err := msg.Unmarshal(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (codec *protobufDirectSendCodec) IsInterfaceNil() bool {
+	return codec == nil
+}
+
+type compressedDirectSendCodec struct {
+	compressor p2p.Compressor
+}
+
+// NewCompressedDirectSendCodec creates a codec that compresses the protobuf encoded messages with the provided
+// compressor
+func NewCompressedDirectSendCodec(compressor p2p.Compressor) (*compressedDirectSendCodec, error) {
+	if check.IfNil(compressor) {
+		return nil, p2p.ErrNilCompressor
+	}
+
+	return &compressedDirectSendCodec{
+		compressor: compressor,
+	}, nil
+}
+
+// Encode marshals and compresses the message
+func (codec *compressedDirectSendCodec) Encode(msg *pubsubPb.Message) ([]byte, error) {
+	buff, err := msg.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.compressor.Compress(buff)
+}
+
+// Decode decompresses and unmarshals the message
+func (codec *compressedDirectSendCodec) Decode(buff []byte) (*pubsubPb.Message, error) {
+	decompressed, err := codec.compressor.Decompress(buff, maxSendBuffSize)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &pubsubPb.Message{}
+	err = msg.Unmarshal(decompressed)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (codec *compressedDirectSendCodec) IsInterfaceNil() bool {
+	return codec == nil
+}
+
+// writeFrame writes the encoded message prefixed by its uvarint encoded length, the same framing used by the
+// delimited protobuf writers
+func writeFrame(w *bufio.Writer, buff []byte) error {
+	lenBuff := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lenBuff, uint64(len(buff)))
+
+	_, err := w.Write(lenBuff[:n])
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(buff)
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// readFrame reads an uvarint length prefixed frame that can not exceed the provided maximum size
+func readFrame(r *bufio.Reader, maxSize int) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(maxSize) {
+		return nil, fmt.Errorf("%w, frame size: %d, maximum: %d", p2p.ErrMessageTooLarge, length, maxSize)
+	}
+
+	buff := make([]byte, length)
+	_, err = io.ReadFull(r, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return buff, nil
+}
@@ -0,0 +1,111 @@
+package libp2p_test
+
+import (
+	"errors"
+	"testing"
+
+	pubsubPb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func createDirectSendCodecTestMessage() *pubsubPb.Message {
+	topic := "topic"
+
+	return &pubsubPb.Message{
+		From:      []byte("from"),
+		Data:      []byte("data data data data data data data data"),
+		Seqno:     []byte("seqno"),
+		Topic:     &topic,
+		Signature: []byte("signature"),
+	}
+}
+
+func TestProtobufDirectSendCodec_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	codec := libp2p.NewProtobufDirectSendCodec()
+	assert.False(t, check.IfNil(codec))
+
+	msg := createDirectSendCodecTestMessage()
+	buff, err := codec.Encode(msg)
+	assert.Nil(t, err)
+
+	expectedBuff, _ := msg.Marshal()
+	assert.Equal(t, expectedBuff, buff)
+
+	decoded, err := codec.Decode(buff)
+	assert.Nil(t, err)
+	assert.Equal(t, msg, decoded)
+
+	decoded, err = codec.Decode([]byte("invalid protobuf"))
+	assert.NotNil(t, err)
+	assert.Nil(t, decoded)
+}
+
+func TestNewCompressedDirectSendCodec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil compressor should error", func(t *testing.T) {
+		t.Parallel()
+
+		codec, err := libp2p.NewCompressedDirectSendCodec(nil)
+		assert.True(t, check.IfNil(codec))
+		assert.Equal(t, p2p.ErrNilCompressor, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		codec, err := libp2p.NewCompressedDirectSendCodec(compression.NewSnappyCompressor())
+		assert.False(t, check.IfNil(codec))
+		assert.Nil(t, err)
+	})
+}
+
+func TestCompressedDirectSendCodec_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		codec, _ := libp2p.NewCompressedDirectSendCodec(compression.NewSnappyCompressor())
+
+		msg := createDirectSendCodecTestMessage()
+		buff, err := codec.Encode(msg)
+		assert.Nil(t, err)
+
+		marshalled, _ := msg.Marshal()
+		assert.NotEqual(t, marshalled, buff)
+
+		decoded, err := codec.Decode(buff)
+		assert.Nil(t, err)
+		assert.Equal(t, msg, decoded)
+	})
+	t.Run("compress error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		codec, _ := libp2p.NewCompressedDirectSendCodec(&mock.CompressorStub{
+			CompressCalled: func(buff []byte) ([]byte, error) {
+				return nil, expectedErr
+			},
+		})
+
+		buff, err := codec.Encode(createDirectSendCodecTestMessage())
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, buff)
+	})
+	t.Run("decompress error should error", func(t *testing.T) {
+		t.Parallel()
+
+		codec, _ := libp2p.NewCompressedDirectSendCodec(compression.NewSnappyCompressor())
+
+		decoded, err := codec.Decode([]byte("not compressed"))
+		assert.NotNil(t, err)
+		assert.Nil(t, decoded)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"sync"
@@ -17,9 +18,12 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/throttler"
 	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/data"
 	"github.com/whyrusleeping/timecache"
 )
 
@@ -28,6 +32,19 @@ var _ p2p.DirectSender = (*directSender)(nil)
 const timeSeenMessages = time.Second * 120
 const maxMutexes = 10000
 const sequenceNumberSize = 8
+const defaultAcknowledgeTimeout = time.Second * 10
+const genericRejectionReason = "message rejected"
+
+// rejectionReasons holds the errors that can be sent back to the remote peer as rejection reasons, any other error
+// being reported with a generic reason as it might expose the node's internals
+var rejectionReasons = []error{
+	p2p.ErrNilMessage,
+	p2p.ErrNilTopic,
+	p2p.ErrInvalidValue,
+	p2p.ErrAlreadySeenMessage,
+	p2p.ErrNilValidator,
+	p2p.ErrNoRequestHandlerForTopic,
+}
 
 type directSender struct {
 	counter         uint64
@@ -38,6 +55,11 @@ type directSender struct {
 	seenMessages    *timecache.TimeCache
 	mutexForPeer    *MutexHolder
 	signer          p2p.SignerVerifier
+	ackThrottler    *throttler.NumGoRoutinesThrottler
+	mutProtocols    sync.RWMutex
+	// protocols are sorted by preference, the most recently registered protocol being the first one
+	protocols []protocol.ID
+	codecs    map[protocol.ID]DirectSendCodec
 }
 
 // NewDirectSender returns a new instance of direct sender object
@@ -66,6 +88,11 @@ func NewDirectSender(
 		return nil, err
 	}
 
+	ackThrottler, err := throttler.NewNumGoRoutinesThrottler(streamHandlerGoRoutines)
+	if err != nil {
+		return nil, err
+	}
+
 	ds := &directSender{
 		counter:        uint64(time.Now().UnixNano()),
 		ctx:            ctx,
@@ -74,22 +101,82 @@ func NewDirectSender(
 		messageHandler: messageHandler,
 		mutexForPeer:   mutexForPeer,
 		signer:         signer,
+		ackThrottler:   ackThrottler,
+		protocols:      make([]protocol.ID, 0),
+		codecs:         make(map[protocol.ID]DirectSendCodec),
 	}
 
 	// wire-up a handler for direct messages
-	h.SetStreamHandler(DirectSendID, ds.directStreamHandler)
+	err = ds.RegisterProtocol(DirectSendID, NewProtobufDirectSendCodec())
+	if err != nil {
+		return nil, err
+	}
+	// wire-up a handler for direct messages that require a delivery status
+	h.SetStreamHandler(AcknowledgedDirectSendID, ds.acknowledgedStreamHandler)
 
 	return ds, nil
 }
 
-func (ds *directSender) directStreamHandler(s network.Stream) {
-	reader := ggio.NewDelimitedReader(s, maxSendBuffSize)
+// RegisterProtocol adds a new direct send protocol, using the provided codec as wire format. The protocols are
+// negotiated through multistream when opening a new stream, the most recently registered protocol being preferred
+// over the older ones, so a new protocol version can be rolled out while still talking to the peers that only
+// support the older versions
+func (ds *directSender) RegisterProtocol(id protocol.ID, codec DirectSendCodec) error {
+	if len(id) == 0 {
+		return fmt.Errorf("%w for the protocol ID", p2p.ErrInvalidValue)
+	}
+	if check.IfNil(codec) {
+		return p2p.ErrNilDirectSendCodec
+	}
 
-	go func(r ggio.ReadCloser) {
-		for {
-			msg := &pubsubPb.Message{}
+	ds.mutProtocols.Lock()
+	_, found := ds.codecs[id]
+	if found {
+		ds.mutProtocols.Unlock()
+		return fmt.Errorf("%w: %s", p2p.ErrDirectSendProtocolAlreadyRegistered, id)
+	}
+
+	ds.codecs[id] = codec
+	ds.protocols = append([]protocol.ID{id}, ds.protocols...)
+	ds.mutProtocols.Unlock()
+
+	ds.hostP2P.SetStreamHandler(id, ds.createDirectStreamHandler(codec))
+
+	return nil
+}
 
-			err := reader.ReadMsg(msg)
+// Protocols returns the registered direct send protocols, sorted by preference
+func (ds *directSender) Protocols() []protocol.ID {
+	ds.mutProtocols.RLock()
+	defer ds.mutProtocols.RUnlock()
+
+	protocols := make([]protocol.ID, len(ds.protocols))
+	copy(protocols, ds.protocols)
+
+	return protocols
+}
+
+func (ds *directSender) getCodec(id protocol.ID) (DirectSendCodec, bool) {
+	ds.mutProtocols.RLock()
+	defer ds.mutProtocols.RUnlock()
+
+	codec, found := ds.codecs[id]
+
+	return codec, found
+}
+
+func (ds *directSender) createDirectStreamHandler(codec DirectSendCodec) network.StreamHandler {
+	return func(s network.Stream) {
+		ds.directStreamHandler(s, codec)
+	}
+}
+
+func (ds *directSender) directStreamHandler(s network.Stream, codec DirectSendCodec) {
+	reader := bufio.NewReader(s)
+
+	go func(r *bufio.Reader) {
+		for {
+			msg, err := readDirectMessage(r, codec)
 			if err != nil {
 				// stream has encountered an error, close this go routine
 
@@ -97,6 +184,7 @@ func (ds *directSender) directStreamHandler(s network.Stream) {
 					_ = s.Reset()
 					log.Trace("error reading rpc",
 						"from", s.Conn().RemotePeer(),
+						"protocol", s.Protocol(),
 						"error", err.Error(),
 					)
 				} else {
@@ -115,7 +203,101 @@ func (ds *directSender) directStreamHandler(s network.Stream) {
 	}(reader)
 }
 
+func readDirectMessage(reader *bufio.Reader, codec DirectSendCodec) (*pubsubPb.Message, error) {
+	buff, err := readFrame(reader, maxSendBuffSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.Decode(buff)
+}
+
+// acknowledgedStreamHandler handles exactly one direct message on each stream: reads the message, processes it
+// and writes back the delivery status before closing the stream. The streams received while too many others are
+// being handled are reset
+func (ds *directSender) acknowledgedStreamHandler(s network.Stream) {
+	if !ds.ackThrottler.CanProcess() {
+		_ = s.Reset()
+		log.Trace("too many acknowledged direct messages in progress", "from", s.Conn().RemotePeer())
+		return
+	}
+
+	ds.ackThrottler.StartProcessing()
+	go func() {
+		defer ds.ackThrottler.EndProcessing()
+
+		_ = s.SetReadDeadline(time.Now().Add(requestReadTimeout))
+
+		msg := &pubsubPb.Message{}
+		reader := ggio.NewDelimitedReader(s, maxSendBuffSize)
+		err := reader.ReadMsg(msg)
+		if err != nil {
+			_ = s.Reset()
+			log.Trace("error reading acknowledged direct message",
+				"from", s.Conn().RemotePeer(),
+				"error", err.Error(),
+			)
+			return
+		}
+
+		status := &data.ResponseMessage{
+			RequestID: msg.GetSeqno(),
+			Status:    responseStatusOk,
+		}
+		err = ds.processReceivedDirectMessage(msg, s.Conn().RemotePeer())
+		if err != nil {
+			log.Trace("p2p processReceivedDirectMessage", "error", err.Error())
+			status.Status = responseStatusRejected
+			status.Error = sanitizeRejectionReason(err)
+		}
+
+		_ = s.SetWriteDeadline(time.Now().Add(responseWriteTimeout))
+		err = writeDelimitedMessage(s, status)
+		if err != nil {
+			_ = s.Reset()
+			log.Trace("error writing delivery status",
+				"to", s.Conn().RemotePeer(),
+				"error", err.Error(),
+			)
+			return
+		}
+
+		_ = s.Close()
+	}()
+}
+
+// sanitizeRejectionReason returns the reason sent back to the remote peer for the provided processing error
+func sanitizeRejectionReason(err error) string {
+	for _, reason := range rejectionReasons {
+		if errors.Is(err, reason) {
+			return reason.Error()
+		}
+	}
+
+	return genericRejectionReason
+}
+
 func (ds *directSender) processReceivedDirectMessage(message *pubsubPb.Message, fromConnectedPeer peer.ID) error {
+	err := checkReceivedDirectMessage(message, fromConnectedPeer)
+	if err != nil {
+		return err
+	}
+	if ds.checkAndSetSeenMessage(message) {
+		return p2p.ErrAlreadySeenMessage
+	}
+	err = ds.checkSig(message)
+	if err != nil {
+		return err
+	}
+
+	pbMessage := &pubsub.Message{
+		Message: message,
+	}
+
+	return ds.messageHandler(pbMessage, core.PeerID(fromConnectedPeer))
+}
+
+func checkReceivedDirectMessage(message *pubsubPb.Message, fromConnectedPeer peer.ID) error {
 	if message == nil {
 		return p2p.ErrNilMessage
 	}
@@ -131,39 +313,35 @@ func (ds *directSender) processReceivedDirectMessage(message *pubsubPb.Message,
 	if len(message.Seqno) > sequenceNumberSize {
 		return fmt.Errorf("%w for SeqNo field as the node accepts only a maximum %d bytes", p2p.ErrInvalidValue, sequenceNumberSize)
 	}
-	if ds.checkAndSetSeenMessage(message) {
-		return p2p.ErrAlreadySeenMessage
-	}
-	err := ds.checkSig(message)
-	if err != nil {
-		return err
-	}
-
-	pbMessage := &pubsub.Message{
-		Message: message,
-	}
 
-	return ds.messageHandler(pbMessage, core.PeerID(fromConnectedPeer))
+	return nil
 }
 
 func (ds *directSender) checkAndSetSeenMessage(msg *pubsubPb.Message) bool {
-	msgId := string(msg.GetFrom()) + string(msg.GetSeqno())
-
 	ds.mutSeenMessages.Lock()
 	defer ds.mutSeenMessages.Unlock()
 
-	if ds.seenMessages.Has(msgId) {
+	return checkAndSetSeenMessage(ds.seenMessages, msg)
+}
+
+func checkAndSetSeenMessage(seenMessages *timecache.TimeCache, msg *pubsubPb.Message) bool {
+	msgId := string(msg.GetFrom()) + string(msg.GetSeqno())
+	if seenMessages.Has(msgId) {
 		return true
 	}
 
-	ds.seenMessages.Add(msgId)
+	seenMessages.Add(msgId)
 	return false
 }
 
 // NextSequenceNumber returns the next uint64 found in *counter as byte slice
 func (ds *directSender) NextSequenceNumber() []byte {
+	return nextSequenceNumber(&ds.counter)
+}
+
+func nextSequenceNumber(counter *uint64) []byte {
 	seqno := make([]byte, sequenceNumberSize)
-	newVal := atomic.AddUint64(&ds.counter, 1)
+	newVal := atomic.AddUint64(counter, 1)
 	binary.BigEndian.PutUint64(seqno, newVal)
 	return seqno
 }
@@ -188,33 +366,92 @@ func (ds *directSender) Send(topic string, buff []byte, peer core.PeerID) error
 		return err
 	}
 
+	codec, found := ds.getCodec(stream.Protocol())
+	if !found {
+		_ = stream.Reset()
+		return fmt.Errorf("%w, negotiated protocol %s", p2p.ErrUnsupportedDirectSendProtocol, stream.Protocol())
+	}
+
 	msg, err := ds.createMessage(topic, buff, conn)
 	if err != nil {
 		return err
 	}
 
-	bufw := bufio.NewWriter(stream)
-	w := ggio.NewDelimitedWriter(bufw)
+	encoded, err := codec.Encode(msg)
+	if err != nil {
+		return err
+	}
 
-	err = w.WriteMsg(msg)
+	err = writeFrame(bufio.NewWriter(stream), encoded)
 	if err != nil {
 		_ = stream.Reset()
 		_ = stream.Close()
 		return err
 	}
 
-	err = bufw.Flush()
+	return nil
+}
+
+// SendWithAck will send a direct message to the connected peer on a new stream and will wait for the delivery status
+// written back by the peer. It returns p2p.ErrDirectMessageRejected if the peer did not accept the message and
+// p2p.ErrAcknowledgeTimeout if the status was not received before the context deadline (or the default acknowledge
+// timeout if the context has no deadline)
+func (ds *directSender) SendWithAck(ctx context.Context, topic string, buff []byte, peer core.PeerID) error {
+	if ctx == nil {
+		return p2p.ErrNilContext
+	}
+	if len(buff) >= maxSendBuffSize {
+		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxSendBuffSize)
+	}
+
+	conn, err := ds.getConnection(peer)
+	if err != nil {
+		return err
+	}
+
+	_, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultAcknowledgeTimeout)
+		defer cancel()
+	}
+
+	stream, err := ds.hostP2P.NewStream(ctx, conn.RemotePeer(), AcknowledgedDirectSendID)
+	if err != nil {
+		return convertTimeoutError(ctx, err, p2p.ErrAcknowledgeTimeout)
+	}
+
+	msg, err := ds.createMessage(topic, buff, conn)
 	if err != nil {
 		_ = stream.Reset()
-		_ = stream.Close()
 		return err
 	}
 
+	status, err := exchangeRequestWithContext(ctx, ds.ctx, stream, msg, p2p.ErrAcknowledgeTimeout)
+	if err != nil {
+		return err
+	}
+
+	return processDeliveryStatus(msg, status)
+}
+
+func processDeliveryStatus(msg *pubsubPb.Message, status *data.ResponseMessage) error {
+	if !bytes.Equal(msg.GetSeqno(), status.RequestID) {
+		return p2p.ErrAcknowledgeIDMismatch
+	}
+	if status.Status != responseStatusOk {
+		return fmt.Errorf("%w, reason: %s", p2p.ErrDirectMessageRejected, status.Error)
+	}
+
 	return nil
 }
 
 func (ds *directSender) getConnection(p core.PeerID) (network.Conn, error) {
-	conns := ds.hostP2P.Network().ConnsToPeer(peer.ID(p))
+	return getConnectionToPeer(ds.hostP2P, p)
+}
+
+func getConnectionToPeer(h host.Host, p core.PeerID) (network.Conn, error) {
+	conns := h.Network().ConnsToPeer(peer.ID(p))
 	if len(conns) == 0 {
 		return nil, p2p.ErrPeerNotDirectlyConnected
 	}
@@ -237,7 +474,7 @@ func (ds *directSender) getOrCreateStream(conn network.Conn) (network.Stream, er
 	streams := conn.GetStreams()
 	var foundStream network.Stream
 	for i := 0; i < len(streams); i++ {
-		isExpectedStream := streams[i].Protocol() == DirectSendID
+		_, isExpectedStream := ds.getCodec(streams[i].Protocol())
 		isSendableStream := streams[i].Stat().Direction == network.DirOutbound
 
 		if isExpectedStream && isSendableStream {
@@ -249,7 +486,8 @@ func (ds *directSender) getOrCreateStream(conn network.Conn) (network.Stream, er
 	var err error
 
 	if foundStream == nil {
-		foundStream, err = ds.hostP2P.NewStream(ds.ctx, conn.RemotePeer(), DirectSendID)
+		// the multistream negotiation picks the first protocol supported by the remote peer
+		foundStream, err = ds.hostP2P.NewStream(ds.ctx, conn.RemotePeer(), ds.Protocols()...)
 		if err != nil {
 			return nil, err
 		}
@@ -259,7 +497,16 @@ func (ds *directSender) getOrCreateStream(conn network.Conn) (network.Stream, er
 }
 
 func (ds *directSender) createMessage(topic string, buff []byte, conn network.Conn) (*pubsubPb.Message, error) {
-	seqno := ds.NextSequenceNumber()
+	return createSignedMessage(ds.signer, ds.NextSequenceNumber(), topic, buff, conn)
+}
+
+func createSignedMessage(
+	signer p2p.SignerVerifier,
+	seqno []byte,
+	topic string,
+	buff []byte,
+	conn network.Conn,
+) (*pubsubPb.Message, error) {
 	mes := pubsubPb.Message{}
 	mes.Data = buff
 	mes.Topic = &topic
@@ -274,7 +521,7 @@ func (ds *directSender) createMessage(topic string, buff []byte, conn network.Co
 
 	buff = withSignPrefix(buff)
 
-	mes.Signature, err = ds.signer.Sign(buff)
+	mes.Signature, err = signer.Sign(buff)
 	if err != nil {
 		return nil, err
 	}
@@ -283,6 +530,10 @@ func (ds *directSender) createMessage(topic string, buff []byte, conn network.Co
 }
 
 func (ds *directSender) checkSig(message *pubsubPb.Message) error {
+	return checkMessageSignature(ds.signer, message)
+}
+
+func checkMessageSignature(signer p2p.SignerVerifier, message *pubsubPb.Message) error {
 	if len(message.Signature) == 0 {
 		return nil // TODO will remove this in the future
 	}
@@ -298,7 +549,7 @@ func (ds *directSender) checkSig(message *pubsubPb.Message) error {
 
 	buff = withSignPrefix(buff)
 
-	return ds.signer.Verify(buff, core.PeerID(message.From), message.Signature)
+	return signer.Verify(buff, core.PeerID(message.From), message.Signature)
 }
 
 func withSignPrefix(bytes []byte) []byte {
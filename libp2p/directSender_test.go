@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	libp2pCrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -21,6 +23,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	p2p "github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
 	"github.com/multiversx/mx-chain-p2p-go/mock"
 	"github.com/stretchr/testify/assert"
 )
@@ -140,13 +143,11 @@ func TestNewDirectSender(t *testing.T) {
 func TestNewDirectSender_OkValsShouldCallSetStreamHandlerWithCorrectValues(t *testing.T) {
 	t.Parallel()
 
-	var pidCalled protocol.ID
-	var handlerCalled network.StreamHandler
+	handlersCalled := make(map[protocol.ID]network.StreamHandler)
 
 	hs := &mock.ConnectableHostStub{
 		SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {
-			pidCalled = pid
-			handlerCalled = handler
+			handlersCalled[pid] = handler
 		},
 	}
 
@@ -157,8 +158,9 @@ func TestNewDirectSender_OkValsShouldCallSetStreamHandlerWithCorrectValues(t *te
 		&mock.P2PSignerStub{},
 	)
 
-	assert.NotNil(t, handlerCalled)
-	assert.Equal(t, libp2p.DirectSendID, pidCalled)
+	assert.Equal(t, 2, len(handlersCalled))
+	assert.NotNil(t, handlersCalled[libp2p.DirectSendID])
+	assert.NotNil(t, handlersCalled[libp2p.AcknowledgedDirectSendID])
 }
 
 // ------- ProcessReceivedDirectMessage
@@ -672,7 +674,9 @@ func TestDirectSender_ReceivedSentMessageShouldCallMessageHandlerTestFullCycle(t
 
 	hs := &mock.ConnectableHostStub{
 		SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {
-			streamHandler = handler
+			if pid == libp2p.DirectSendID {
+				streamHandler = handler
+			}
 		},
 		NetworkCalled: func() network.Network {
 			return netw
@@ -788,3 +792,401 @@ func TestDirectSender_ProcessReceivedDirectMessageSignatureFails(t *testing.T) {
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.True(t, verifyCalled)
 }
+
+func TestDirectSender_SendWithAck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		ds, _ := libp2p.NewDirectSender(
+			context.Background(),
+			generateHostStub(),
+			blankMessageHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		var ctx context.Context = nil
+		err := ds.SendWithAck(ctx, "topic", []byte("data"), "pid")
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("buffer too large should error", func(t *testing.T) {
+		t.Parallel()
+
+		ds, _ := libp2p.NewDirectSender(
+			context.Background(),
+			generateHostStub(),
+			blankMessageHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		messageTooLarge := bytes.Repeat([]byte{65}, libp2p.MaxSendBuffSize)
+		err := ds.SendWithAck(context.Background(), "topic", messageTooLarge, "pid")
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+	})
+	t.Run("not connected peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		netw := &mock.NetworkStub{
+			ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+				return make([]network.Conn, 0)
+			},
+		}
+		ds, _ := libp2p.NewDirectSender(
+			context.Background(),
+			&mock.ConnectableHostStub{
+				SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {},
+				NetworkCalled: func() network.Network {
+					return netw
+				},
+			},
+			blankMessageHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		err := ds.SendWithAck(context.Background(), "topic", []byte("data"), "not connected peer")
+		assert.Equal(t, p2p.ErrPeerNotDirectlyConnected, err)
+	})
+	t.Run("accepted message should work", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		sentData := []byte("data")
+		topic := "topic"
+		ds1, _ := libp2p.NewDirectSender(context.Background(), h1, blankMessageHandler, &mock.P2PSignerStub{})
+		_, _ = libp2p.NewDirectSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				assert.Equal(t, sentData, msg.Data)
+				assert.Equal(t, topic, *msg.Topic)
+				assert.Equal(t, core.PeerID(h1.ID()), fromConnectedPeer)
+
+				return nil
+			},
+			&mock.P2PSignerStub{},
+		)
+
+		err := ds1.SendWithAck(context.Background(), topic, sentData, core.PeerID(h2.ID()))
+		assert.Nil(t, err)
+	})
+	t.Run("message handler rejects the message should error", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		expectedErr := errors.New("expected error")
+		ds1, _ := libp2p.NewDirectSender(context.Background(), h1, blankMessageHandler, &mock.P2PSignerStub{})
+		_, _ = libp2p.NewDirectSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				return expectedErr
+			},
+			&mock.P2PSignerStub{},
+		)
+
+		err := ds1.SendWithAck(context.Background(), "topic", []byte("data"), core.PeerID(h2.ID()))
+		assert.True(t, errors.Is(err, p2p.ErrDirectMessageRejected))
+		assert.Contains(t, err.Error(), libp2p.GenericRejectionReason)
+		assert.NotContains(t, err.Error(), expectedErr.Error())
+	})
+	t.Run("signature verification fails should error", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		expectedErr := errors.New("invalid signature")
+		ds1, _ := libp2p.NewDirectSender(
+			context.Background(),
+			h1,
+			blankMessageHandler,
+			&mock.P2PSignerStub{
+				SignCalled: func(payload []byte) ([]byte, error) {
+					return []byte("signature"), nil
+				},
+			},
+		)
+		_, _ = libp2p.NewDirectSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				assert.Fail(t, "should have not called the message handler")
+				return nil
+			},
+			&mock.P2PSignerStub{
+				VerifyCalled: func(payload []byte, pid core.PeerID, signature []byte) error {
+					return expectedErr
+				},
+			},
+		)
+
+		err := ds1.SendWithAck(context.Background(), "topic", []byte("data"), core.PeerID(h2.ID()))
+		assert.True(t, errors.Is(err, p2p.ErrDirectMessageRejected))
+		assert.Contains(t, err.Error(), libp2p.GenericRejectionReason)
+		assert.NotContains(t, err.Error(), expectedErr.Error())
+	})
+	t.Run("status not received in time should error", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		ds1, _ := libp2p.NewDirectSender(context.Background(), h1, blankMessageHandler, &mock.P2PSignerStub{})
+		_, _ = libp2p.NewDirectSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				time.Sleep(time.Second)
+				return nil
+			},
+			&mock.P2PSignerStub{},
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+
+		err := ds1.SendWithAck(ctx, "topic", []byte("data"), core.PeerID(h2.ID()))
+		assert.True(t, errors.Is(err, p2p.ErrAcknowledgeTimeout))
+	})
+	t.Run("already seen message should error with the rejection reason", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		ds1, _ := libp2p.NewDirectSender(context.Background(), h1, blankMessageHandler, &mock.P2PSignerStub{})
+		_, _ = libp2p.NewDirectSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				return fmt.Errorf("%w: internal details", p2p.ErrAlreadySeenMessage)
+			},
+			&mock.P2PSignerStub{},
+		)
+
+		err := ds1.SendWithAck(context.Background(), "topic", []byte("data"), core.PeerID(h2.ID()))
+		assert.True(t, errors.Is(err, p2p.ErrDirectMessageRejected))
+		assert.Contains(t, err.Error(), p2p.ErrAlreadySeenMessage.Error())
+		assert.NotContains(t, err.Error(), "internal details")
+	})
+	t.Run("too many messages in progress should reset the stream", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		chHandlerStarted := make(chan struct{}, 1)
+		chReleaseHandler := make(chan struct{})
+		numHandlerCalls := uint32(0)
+		ds1, _ := libp2p.NewDirectSender(context.Background(), h1, blankMessageHandler, &mock.P2PSignerStub{})
+		ds2, _ := libp2p.NewDirectSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				atomic.AddUint32(&numHandlerCalls, 1)
+				chHandlerStarted <- struct{}{}
+				<-chReleaseHandler
+				return nil
+			},
+			&mock.P2PSignerStub{},
+		)
+		ds2.SetAckThrottler(1)
+
+		chFirstSendErr := make(chan error, 1)
+		go func() {
+			chFirstSendErr <- ds1.SendWithAck(context.Background(), "topic", []byte("data1"), core.PeerID(h2.ID()))
+		}()
+		<-chHandlerStarted
+
+		err := ds1.SendWithAck(context.Background(), "topic", []byte("data2"), core.PeerID(h2.ID()))
+		assert.NotNil(t, err)
+
+		close(chReleaseHandler)
+		assert.Nil(t, <-chFirstSendErr)
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numHandlerCalls))
+	})
+}
+
+func TestDirectSender_RegisterProtocol(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty protocol ID should error", func(t *testing.T) {
+		t.Parallel()
+
+		ds, _ := libp2p.NewDirectSender(context.Background(), generateHostStub(), blankMessageHandler, &mock.P2PSignerStub{})
+
+		err := ds.RegisterProtocol("", libp2p.NewProtobufDirectSendCodec())
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("nil codec should error", func(t *testing.T) {
+		t.Parallel()
+
+		ds, _ := libp2p.NewDirectSender(context.Background(), generateHostStub(), blankMessageHandler, &mock.P2PSignerStub{})
+
+		err := ds.RegisterProtocol(libp2p.CompressedDirectSendID, nil)
+		assert.Equal(t, p2p.ErrNilDirectSendCodec, err)
+	})
+	t.Run("already registered protocol should error", func(t *testing.T) {
+		t.Parallel()
+
+		ds, _ := libp2p.NewDirectSender(context.Background(), generateHostStub(), blankMessageHandler, &mock.P2PSignerStub{})
+
+		err := ds.RegisterProtocol(libp2p.DirectSendID, libp2p.NewProtobufDirectSendCodec())
+		assert.True(t, errors.Is(err, p2p.ErrDirectSendProtocolAlreadyRegistered))
+	})
+	t.Run("should register the handler and prefer the new protocol", func(t *testing.T) {
+		t.Parallel()
+
+		handlersCalled := make(map[protocol.ID]network.StreamHandler)
+		hs := &mock.ConnectableHostStub{
+			SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {
+				handlersCalled[pid] = handler
+			},
+		}
+		ds, _ := libp2p.NewDirectSender(context.Background(), hs, blankMessageHandler, &mock.P2PSignerStub{})
+		codec, _ := libp2p.NewCompressedDirectSendCodec(&mock.CompressorStub{})
+
+		err := ds.RegisterProtocol(libp2p.CompressedDirectSendID, codec)
+		assert.Nil(t, err)
+		assert.NotNil(t, handlersCalled[libp2p.CompressedDirectSendID])
+		assert.Equal(t, []protocol.ID{libp2p.CompressedDirectSendID, libp2p.DirectSendID}, ds.Protocols())
+	})
+}
+
+func TestDirectSender_SendMixedProtocolVersions(t *testing.T) {
+	t.Parallel()
+
+	createDirectSender := func(h host.Host, withCompressedProtocol bool, chanReceived chan *pubsub.Message) p2p.DirectSender {
+		ds, _ := libp2p.NewDirectSender(
+			context.Background(),
+			h,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) error {
+				chanReceived <- msg
+				return nil
+			},
+			&mock.P2PSignerStub{},
+		)
+		if withCompressedProtocol {
+			codec, _ := libp2p.NewCompressedDirectSendCodec(compression.NewSnappyCompressor())
+			_ = ds.RegisterProtocol(libp2p.CompressedDirectSendID, codec)
+		}
+
+		return ds
+	}
+	testSend := func(t *testing.T, senderCompressed bool, receiverCompressed bool, expectedProtocol protocol.ID) {
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		chanReceived := make(chan *pubsub.Message, 2)
+		ds1 := createDirectSender(h1, senderCompressed, chanReceived)
+		_ = createDirectSender(h2, receiverCompressed, chanReceived)
+
+		sentData := []byte("data data data data data data data data")
+		for i := 0; i < 2; i++ {
+			err := ds1.Send("topic", sentData, core.PeerID(h2.ID()))
+			assert.Nil(t, err)
+
+			select {
+			case msg := <-chanReceived:
+				assert.Equal(t, sentData, msg.Data)
+				assert.Equal(t, "topic", *msg.Topic)
+			case <-time.After(timeout):
+				assert.Fail(t, "timeout while waiting for the message")
+				return
+			}
+		}
+
+		numDirectStreams := 0
+		for _, conn := range h1.Network().ConnsToPeer(h2.ID()) {
+			for _, stream := range conn.GetStreams() {
+				if stream.Protocol() == libp2p.DirectSendID || stream.Protocol() == libp2p.CompressedDirectSendID {
+					numDirectStreams++
+					assert.Equal(t, expectedProtocol, stream.Protocol())
+				}
+			}
+		}
+		// the negotiated stream is reused
+		assert.Equal(t, 1, numDirectStreams)
+	}
+
+	t.Run("both peers support the compressed protocol", func(t *testing.T) {
+		t.Parallel()
+
+		testSend(t, true, true, libp2p.CompressedDirectSendID)
+	})
+	t.Run("only the sender supports the compressed protocol", func(t *testing.T) {
+		t.Parallel()
+
+		testSend(t, true, false, libp2p.DirectSendID)
+	})
+	t.Run("only the receiver supports the compressed protocol", func(t *testing.T) {
+		t.Parallel()
+
+		testSend(t, false, true, libp2p.DirectSendID)
+	})
+	t.Run("no peer supports the compressed protocol", func(t *testing.T) {
+		t.Parallel()
+
+		testSend(t, false, false, libp2p.DirectSendID)
+	})
+	t.Run("unknown negotiated protocol should error", func(t *testing.T) {
+		t.Parallel()
+
+		netw := &mock.NetworkStub{}
+		hs := &mock.ConnectableHostStub{
+			SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {},
+			NetworkCalled: func() network.Network {
+				return netw
+			},
+		}
+		ds, _ := libp2p.NewDirectSender(
+			context.Background(),
+			hs,
+			blankMessageHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		id, sk := createLibP2PCredentialsDirectSender()
+		cs := createConnStub(nil, id, sk, "remote peer")
+		netw.ConnsToPeerCalled = func(p peer.ID) []network.Conn {
+			return []network.Conn{cs}
+		}
+		hs.NewStreamCalled = func(ctx context.Context, p peer.ID, pids ...protocol.ID) (network.Stream, error) {
+			stream := mock.NewStreamMock()
+			_ = stream.SetProtocol("/erd/directsend/3.0.0")
+
+			return stream, nil
+		}
+
+		err := ds.Send("topic", []byte("data"), core.PeerID(cs.RemotePeer()))
+
+		assert.True(t, errors.Is(err, p2p.ErrUnsupportedDirectSendProtocol))
+		assert.True(t, strings.Contains(err.Error(), "/erd/directsend/3.0.0"))
+	})
+}
@@ -0,0 +1,25 @@
+package disabled
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+// Antiflood is a disabled implementation of the antiflood component that accepts all messages
+type Antiflood struct {
+}
+
+// CanProcessMessage returns nil (all messages are accepted)
+func (af *Antiflood) CanProcessMessage(_ p2p.MessageP2P, _ core.PeerID) error {
+	return nil
+}
+
+// Close returns nil and does nothing
+func (af *Antiflood) Close() error {
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (af *Antiflood) IsInterfaceNil() bool {
+	return af == nil
+}
@@ -0,0 +1,20 @@
+package disabled_test
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/disabled"
+	"github.com/multiversx/mx-chain-p2p-go/message"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAntiflood_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	af := &disabled.Antiflood{}
+
+	assert.False(t, check.IfNil(af))
+	assert.Nil(t, af.CanProcessMessage(&message.Message{}, ""))
+	assert.Nil(t, af.Close())
+}
@@ -0,0 +1,162 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+var _ p2p.PeerDiscoverer = (*compositeDiscoverer)(nil)
+var _ p2p.Reconnecter = (*compositeDiscoverer)(nil)
+
+const compositeNamePrefix = "composite discovery"
+
+type peerFinder interface {
+	FindPeer(ctx context.Context, pid peer.ID) (peer.AddrInfo, error)
+}
+
+type seedersStatusProvider interface {
+	SeedersStatus() []p2p.SeederStatus
+}
+
+type compositeDiscoverer struct {
+	discoverers []p2p.PeerDiscoverer
+}
+
+// NewCompositeDiscoverer creates a peer discoverer that runs all the provided discoverers together
+func NewCompositeDiscoverer(discoverers ...p2p.PeerDiscoverer) (*compositeDiscoverer, error) {
+	if len(discoverers) == 0 {
+		return nil, p2p.ErrEmptyPeerDiscoverers
+	}
+	for idx, discoverer := range discoverers {
+		if check.IfNil(discoverer) {
+			return nil, fmt.Errorf("%w at index %d", p2p.ErrNilPeerDiscoverer, idx)
+		}
+	}
+
+	return &compositeDiscoverer{
+		discoverers: discoverers,
+	}, nil
+}
+
+// Bootstrap bootstraps all the discoverers in parallel. It errors only if none of them could be bootstrapped, the
+// returned error containing all the individual errors
+func (cd *compositeDiscoverer) Bootstrap() error {
+	errs := make([]error, len(cd.discoverers))
+	wg := &sync.WaitGroup{}
+	wg.Add(len(cd.discoverers))
+	for idx, discoverer := range cd.discoverers {
+		go func(idx int, discoverer p2p.PeerDiscoverer) {
+			errs[idx] = discoverer.Bootstrap()
+			wg.Done()
+		}(idx, discoverer)
+	}
+	wg.Wait()
+
+	failures := make([]string, 0, len(errs))
+	for idx, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		name := cd.discoverers[idx].Name()
+		log.Debug("compositeDiscoverer.Bootstrap", "discoverer", name, "error", err.Error())
+		failures = append(failures, fmt.Sprintf("%s: %s", name, err.Error()))
+	}
+
+	if len(failures) == len(cd.discoverers) {
+		return fmt.Errorf("%w, errors: [%s]", p2p.ErrPeerDiscoveryBootstrapFailed, strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+// ReconnectToNetwork calls ReconnectToNetwork on all the discoverers able to reconnect
+func (cd *compositeDiscoverer) ReconnectToNetwork(ctx context.Context) {
+	for _, discoverer := range cd.discoverers {
+		reconnecter, ok := discoverer.(p2p.Reconnecter)
+		if !ok {
+			continue
+		}
+
+		reconnecter.ReconnectToNetwork(ctx)
+	}
+}
+
+// FindPeer asks, in order, all the discoverers able to search peers for the provided peer's addresses and returns
+// the first non-empty result found
+func (cd *compositeDiscoverer) FindPeer(ctx context.Context, pid peer.ID) (peer.AddrInfo, error) {
+	err := p2p.ErrPeerAddressesNotFound
+	for _, discoverer := range cd.discoverers {
+		finder, ok := discoverer.(peerFinder)
+		if !ok {
+			continue
+		}
+
+		var pi peer.AddrInfo
+		pi, err = finder.FindPeer(ctx, pid)
+		if err == nil && len(pi.Addrs) > 0 {
+			return pi, nil
+		}
+		if err == nil {
+			err = p2p.ErrPeerAddressesNotFound
+		}
+	}
+
+	return peer.AddrInfo{}, err
+}
+
+// SeedersStatus returns the seeders health state reported by all the discoverers able to track the seeders
+func (cd *compositeDiscoverer) SeedersStatus() []p2p.SeederStatus {
+	result := make([]p2p.SeederStatus, 0)
+	for _, discoverer := range cd.discoverers {
+		provider, ok := discoverer.(seedersStatusProvider)
+		if !ok {
+			continue
+		}
+
+		result = append(result, provider.SeedersStatus()...)
+	}
+
+	return result
+}
+
+// Close closes all the discoverers that need closing, returning the last encountered error
+func (cd *compositeDiscoverer) Close() error {
+	var lastErr error
+	for _, discoverer := range cd.discoverers {
+		closer, ok := discoverer.(io.Closer)
+		if !ok {
+			continue
+		}
+
+		err := closer.Close()
+		if err != nil {
+			log.Debug("compositeDiscoverer.Close", "discoverer", discoverer.Name(), "error", err.Error())
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// Name returns the names of all the discoverers
+func (cd *compositeDiscoverer) Name() string {
+	names := make([]string, 0, len(cd.discoverers))
+	for _, discoverer := range cd.discoverers {
+		names = append(names, discoverer.Name())
+	}
+
+	return fmt.Sprintf("%s: [%s]", compositeNamePrefix, strings.Join(names, ", "))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (cd *compositeDiscoverer) IsInterfaceNil() bool {
+	return cd == nil
+}
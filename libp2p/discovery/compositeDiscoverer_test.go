@@ -0,0 +1,315 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/discovery"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+type reconnecterDiscovererStub struct {
+	*mock.PeerDiscovererStub
+	*mock.ReconnecterStub
+}
+
+// IsInterfaceNil -
+func (stub *reconnecterDiscovererStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+type seedersStatusDiscovererStub struct {
+	*mock.PeerDiscovererStub
+	status []p2p.SeederStatus
+}
+
+// SeedersStatus -
+func (stub *seedersStatusDiscovererStub) SeedersStatus() []p2p.SeederStatus {
+	return stub.status
+}
+
+type closerDiscovererStub struct {
+	*mock.PeerDiscovererStub
+	closeCalled func() error
+}
+
+// Close -
+func (stub *closerDiscovererStub) Close() error {
+	return stub.closeCalled()
+}
+
+type bootstrapOnlyDiscovererStub struct {
+	bootstrapCalled func() error
+}
+
+// Bootstrap -
+func (stub *bootstrapOnlyDiscovererStub) Bootstrap() error {
+	return stub.bootstrapCalled()
+}
+
+// Name -
+func (stub *bootstrapOnlyDiscovererStub) Name() string {
+	return "bootstrap only"
+}
+
+// IsInterfaceNil -
+func (stub *bootstrapOnlyDiscovererStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+func createNamedDiscovererStub(name string, bootstrapErr error) *mock.PeerDiscovererStub {
+	return &mock.PeerDiscovererStub{
+		BootstrapCalled: func() error {
+			return bootstrapErr
+		},
+		NameCalled: func() string {
+			return name
+		},
+	}
+}
+
+func TestNewCompositeDiscoverer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no discoverer should error", func(t *testing.T) {
+		t.Parallel()
+
+		cd, err := discovery.NewCompositeDiscoverer()
+
+		assert.True(t, check.IfNil(cd))
+		assert.Equal(t, p2p.ErrEmptyPeerDiscoverers, err)
+	})
+	t.Run("nil discoverer should error", func(t *testing.T) {
+		t.Parallel()
+
+		cd, err := discovery.NewCompositeDiscoverer(createNamedDiscovererStub("a", nil), nil)
+
+		assert.True(t, check.IfNil(cd))
+		assert.True(t, errors.Is(err, p2p.ErrNilPeerDiscoverer))
+		assert.True(t, strings.Contains(err.Error(), "index 1"))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		cd, err := discovery.NewCompositeDiscoverer(createNamedDiscovererStub("a", nil), createNamedDiscovererStub("b", nil))
+
+		assert.False(t, check.IfNil(cd))
+		assert.Nil(t, err)
+		assert.Equal(t, "composite discovery: [a, b]", cd.Name())
+	})
+}
+
+func TestCompositeDiscoverer_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should bootstrap all discoverers in parallel", func(t *testing.T) {
+		t.Parallel()
+
+		numRunning := int32(0)
+		chAllRunning := make(chan struct{})
+		bootstrapHandler := func() error {
+			if atomic.AddInt32(&numRunning, 1) == 2 {
+				close(chAllRunning)
+			}
+
+			select {
+			case <-chAllRunning:
+				return nil
+			case <-time.After(time.Second):
+				return errors.New("discoverers were not bootstrapped in parallel")
+			}
+		}
+		cd, _ := discovery.NewCompositeDiscoverer(
+			&mock.PeerDiscovererStub{BootstrapCalled: bootstrapHandler},
+			&mock.PeerDiscovererStub{BootstrapCalled: bootstrapHandler},
+		)
+
+		err := cd.Bootstrap()
+
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&numRunning))
+	})
+	t.Run("one failing discoverer should not error", func(t *testing.T) {
+		t.Parallel()
+
+		cd, _ := discovery.NewCompositeDiscoverer(
+			createNamedDiscovererStub("kad", errors.New("kad error")),
+			createNamedDiscovererStub("static", nil),
+		)
+
+		err := cd.Bootstrap()
+
+		assert.Nil(t, err)
+	})
+	t.Run("all failing discoverers should return the aggregated error", func(t *testing.T) {
+		t.Parallel()
+
+		cd, _ := discovery.NewCompositeDiscoverer(
+			createNamedDiscovererStub("kad", errors.New("kad error")),
+			createNamedDiscovererStub("mdns", errors.New("mdns error")),
+		)
+
+		err := cd.Bootstrap()
+
+		assert.True(t, errors.Is(err, p2p.ErrPeerDiscoveryBootstrapFailed))
+		assert.True(t, strings.Contains(err.Error(), "kad: kad error"))
+		assert.True(t, strings.Contains(err.Error(), "mdns: mdns error"))
+	})
+}
+
+func TestCompositeDiscoverer_ReconnectToNetwork(t *testing.T) {
+	t.Parallel()
+
+	numReconnects := 0
+	reconnecter := &reconnecterDiscovererStub{
+		PeerDiscovererStub: createNamedDiscovererStub("reconnecter", nil),
+		ReconnecterStub: &mock.ReconnecterStub{
+			ReconnectToNetworkCalled: func(ctx context.Context) {
+				numReconnects++
+			},
+		},
+	}
+	cd, _ := discovery.NewCompositeDiscoverer(
+		&bootstrapOnlyDiscovererStub{},
+		reconnecter,
+		reconnecter,
+	)
+
+	cd.ReconnectToNetwork(context.Background())
+
+	assert.Equal(t, 2, numReconnects)
+}
+
+func TestCompositeDiscoverer_FindPeer(t *testing.T) {
+	t.Parallel()
+
+	pid := peer.ID("pid")
+	addr, _ := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/9999")
+	expectedErr := errors.New("expected error")
+
+	t.Run("no finder should error", func(t *testing.T) {
+		t.Parallel()
+
+		cd, _ := discovery.NewCompositeDiscoverer(&bootstrapOnlyDiscovererStub{})
+
+		pi, err := cd.FindPeer(context.Background(), pid)
+
+		assert.Equal(t, p2p.ErrPeerAddressesNotFound, err)
+		assert.Empty(t, pi.Addrs)
+	})
+	t.Run("all finders failing should return the last error", func(t *testing.T) {
+		t.Parallel()
+
+		cd, _ := discovery.NewCompositeDiscoverer(
+			&mock.PeerDiscovererStub{},
+			&mock.PeerDiscovererStub{
+				FindPeerCalled: func(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+					return peer.AddrInfo{}, expectedErr
+				},
+			},
+		)
+
+		_, err := cd.FindPeer(context.Background(), pid)
+
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("should return the first non-empty result", func(t *testing.T) {
+		t.Parallel()
+
+		cd, _ := discovery.NewCompositeDiscoverer(
+			&bootstrapOnlyDiscovererStub{},
+			&mock.PeerDiscovererStub{
+				FindPeerCalled: func(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+					return peer.AddrInfo{}, expectedErr
+				},
+			},
+			&mock.PeerDiscovererStub{
+				FindPeerCalled: func(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+					return peer.AddrInfo{ID: id, Addrs: []multiaddr.Multiaddr{addr}}, nil
+				},
+			},
+			&mock.PeerDiscovererStub{
+				FindPeerCalled: func(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+					assert.Fail(t, "should have not been called")
+					return peer.AddrInfo{}, nil
+				},
+			},
+		)
+
+		pi, err := cd.FindPeer(context.Background(), pid)
+
+		assert.Nil(t, err)
+		assert.Equal(t, pid, pi.ID)
+		assert.Equal(t, []multiaddr.Multiaddr{addr}, pi.Addrs)
+	})
+}
+
+func TestCompositeDiscoverer_SeedersStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no provider should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		cd, _ := discovery.NewCompositeDiscoverer(&bootstrapOnlyDiscovererStub{})
+
+		status := cd.SeedersStatus()
+		assert.NotNil(t, status)
+		assert.Empty(t, status)
+	})
+	t.Run("should aggregate the status of all providers", func(t *testing.T) {
+		t.Parallel()
+
+		cd, _ := discovery.NewCompositeDiscoverer(
+			&seedersStatusDiscovererStub{
+				PeerDiscovererStub: &mock.PeerDiscovererStub{},
+				status:             []p2p.SeederStatus{{Address: "s1"}},
+			},
+			&bootstrapOnlyDiscovererStub{},
+			&seedersStatusDiscovererStub{
+				PeerDiscovererStub: &mock.PeerDiscovererStub{},
+				status:             []p2p.SeederStatus{{Address: "s2"}, {Address: "s3"}},
+			},
+		)
+
+		status := cd.SeedersStatus()
+		assert.Equal(t, []p2p.SeederStatus{{Address: "s1"}, {Address: "s2"}, {Address: "s3"}}, status)
+	})
+}
+
+func TestCompositeDiscoverer_Close(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	numClosed := 0
+	closeHandler := func(err error) func() error {
+		return func() error {
+			numClosed++
+			return err
+		}
+	}
+	cd, _ := discovery.NewCompositeDiscoverer(
+		&closerDiscovererStub{
+			PeerDiscovererStub: createNamedDiscovererStub("a", nil),
+			closeCalled:        closeHandler(expectedErr),
+		},
+		&bootstrapOnlyDiscovererStub{},
+		&closerDiscovererStub{
+			PeerDiscovererStub: createNamedDiscovererStub("b", nil),
+			closeCalled:        closeHandler(nil),
+		},
+	)
+
+	err := cd.Close()
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 2, numClosed)
+}
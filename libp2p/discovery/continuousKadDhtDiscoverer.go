@@ -9,6 +9,7 @@ import (
 
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	kbucket "github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
@@ -34,6 +35,10 @@ type ArgKadDht struct {
 	RoutingTableRefresh         time.Duration
 	KddSharder                  p2p.Sharder
 	ConnectionWatcher           p2p.ConnectionsWatcher
+	AddressDenialEvaluator      p2p.AddressDenialEvaluator
+	SnapshotFile                string
+	SnapshotInterval            time.Duration
+	SnapshotTTL                 time.Duration
 }
 
 // ContinuousKadDhtDiscoverer is the kad-dht discovery type implementation
@@ -45,14 +50,17 @@ type ContinuousKadDhtDiscoverer struct {
 	kadDHT        *dht.IpfsDHT
 	refreshCancel context.CancelFunc
 
-	peersRefreshInterval time.Duration
-	protocolID           string
-	initialPeersList     []string
-	bucketSize           uint32
-	routingTableRefresh  time.Duration
-	hostConnManagement   *hostWithConnectionManagement
-	sharder              Sharder
-	connectionWatcher    p2p.ConnectionsWatcher
+	peersRefreshInterval   time.Duration
+	protocolID             string
+	initialPeersList       []string
+	bucketSize             uint32
+	routingTableRefresh    time.Duration
+	hostConnManagement     *hostWithConnectionManagement
+	sharder                Sharder
+	connectionWatcher      p2p.ConnectionsWatcher
+	addressDenialEvaluator p2p.AddressDenialEvaluator
+	snapshotter            *routingTableSnapshotter
+	snapshotInterval       time.Duration
 }
 
 // NewContinuousKadDhtDiscoverer creates a new kad-dht discovery type implementation
@@ -66,15 +74,18 @@ func NewContinuousKadDhtDiscoverer(arg ArgKadDht) (*ContinuousKadDhtDiscoverer,
 	sharder.SetSeeders(arg.InitialPeersList)
 
 	return &ContinuousKadDhtDiscoverer{
-		context:              arg.Context,
-		host:                 arg.Host,
-		sharder:              sharder,
-		peersRefreshInterval: arg.PeersRefreshInterval,
-		protocolID:           arg.ProtocolID,
-		initialPeersList:     arg.InitialPeersList,
-		bucketSize:           arg.BucketSize,
-		routingTableRefresh:  arg.RoutingTableRefresh,
-		connectionWatcher:    arg.ConnectionWatcher,
+		context:                arg.Context,
+		host:                   arg.Host,
+		sharder:                sharder,
+		peersRefreshInterval:   arg.PeersRefreshInterval,
+		protocolID:             arg.ProtocolID,
+		initialPeersList:       arg.InitialPeersList,
+		bucketSize:             arg.BucketSize,
+		routingTableRefresh:    arg.RoutingTableRefresh,
+		connectionWatcher:      arg.ConnectionWatcher,
+		addressDenialEvaluator: arg.AddressDenialEvaluator,
+		snapshotter:            newRoutingTableSnapshotter(arg.SnapshotFile, arg.SnapshotTTL),
+		snapshotInterval:       arg.SnapshotInterval,
 	}, nil
 }
 
@@ -91,6 +102,9 @@ func prepareArguments(arg ArgKadDht) (Sharder, error) {
 	if check.IfNil(arg.ConnectionWatcher) {
 		return nil, p2p.ErrNilConnectionsWatcher
 	}
+	if check.IfNil(arg.AddressDenialEvaluator) {
+		return nil, p2p.ErrNilAddressDenialEvaluator
+	}
 	sharder, ok := arg.KddSharder.(Sharder)
 	if !ok {
 		return nil, fmt.Errorf("%w for sharder: expected discovery.Sharder type of interface", p2p.ErrWrongTypeAssertion)
@@ -101,6 +115,13 @@ func prepareArguments(arg ArgKadDht) (Sharder, error) {
 	if arg.RoutingTableRefresh < time.Second {
 		return nil, fmt.Errorf("%w, RoutingTableRefresh should have been at least 1 second", p2p.ErrInvalidValue)
 	}
+	isSnapshotEnabled := len(arg.SnapshotFile) > 0
+	if isSnapshotEnabled && arg.SnapshotInterval < time.Second {
+		return nil, fmt.Errorf("%w, SnapshotInterval should have been at least 1 second", p2p.ErrInvalidValue)
+	}
+	if isSnapshotEnabled && arg.SnapshotTTL < time.Second {
+		return nil, fmt.Errorf("%w, SnapshotTTL should have been at least 1 second", p2p.ErrInvalidValue)
+	}
 	isListNilOrEmpty := len(arg.InitialPeersList) == 0
 	if isListNilOrEmpty {
 		log.Warn("nil or empty initial peers list provided to kad dht implementation. " +
@@ -126,9 +147,10 @@ func (ckdd *ContinuousKadDhtDiscoverer) startDHT() error {
 	ctxrun, cancel := context.WithCancel(ckdd.context)
 	var err error
 	args := ArgsHostWithConnectionManagement{
-		ConnectableHost:    ckdd.host,
-		Sharder:            ckdd.sharder,
-		ConnectionsWatcher: ckdd.connectionWatcher,
+		ConnectableHost:        ckdd.host,
+		Sharder:                ckdd.sharder,
+		ConnectionsWatcher:     ckdd.connectionWatcher,
+		AddressDenialEvaluator: ckdd.addressDenialEvaluator,
 	}
 	ckdd.hostConnManagement, err = NewHostWithConnectionManagement(args)
 	if err != nil {
@@ -150,6 +172,7 @@ func (ckdd *ContinuousKadDhtDiscoverer) startDHT() error {
 	}
 
 	go ckdd.connectToInitialAndBootstrap(ctxrun)
+	go ckdd.processSnapshot(ctxrun, kademliaDHT, ckdd.hostConnManagement)
 
 	ckdd.kadDHT = kademliaDHT
 	ckdd.refreshCancel = cancel
@@ -214,6 +237,29 @@ func (ckdd *ContinuousKadDhtDiscoverer) bootstrap(ctx context.Context) {
 	}
 }
 
+// processSnapshot dials the peers from the routing table snapshot, in parallel with the seeders, and then
+// periodically saves the routing table
+func (ckdd *ContinuousKadDhtDiscoverer) processSnapshot(
+	ctx context.Context,
+	kadDht *dht.IpfsDHT,
+	hostConnManagement *hostWithConnectionManagement,
+) {
+	if !ckdd.snapshotter.isEnabled() {
+		return
+	}
+
+	dialSnapshotPeers(ctx, ckdd.snapshotter, hostConnManagement)
+
+	for {
+		select {
+		case <-time.After(ckdd.snapshotInterval):
+			_ = saveSnapshot(ckdd.snapshotter, kadDht.RoutingTable(), ckdd.host.Peerstore())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (ckdd *ContinuousKadDhtDiscoverer) connectToOnePeerFromInitialPeersList(
 	intervalBetweenAttempts time.Duration,
 	initialPeersList []string,
@@ -278,6 +324,19 @@ func printConnectionErrorToSeeder(peer string, err error) {
 	)
 }
 
+// FindPeer searches the addresses of the provided peer in the kad dht network
+func (ckdd *ContinuousKadDhtDiscoverer) FindPeer(ctx context.Context, pid peer.ID) (peer.AddrInfo, error) {
+	ckdd.mutKadDht.RLock()
+	kadDht := ckdd.kadDHT
+	ckdd.mutKadDht.RUnlock()
+
+	if kadDht == nil {
+		return peer.AddrInfo{}, p2p.ErrPeerDiscoveryProcessNotStarted
+	}
+
+	return kadDht.FindPeer(ctx, pid)
+}
+
 // Name returns the name of the kad dht peer discovery implementation
 func (ckdd *ContinuousKadDhtDiscoverer) Name() string {
 	return kadDhtName
@@ -292,6 +351,18 @@ func (ckdd *ContinuousKadDhtDiscoverer) ReconnectToNetwork(ctx context.Context)
 	}
 }
 
+// Close saves the routing table snapshot, if enabled. The discovery process is stopped through the context
+func (ckdd *ContinuousKadDhtDiscoverer) Close() error {
+	ckdd.mutKadDht.RLock()
+	defer ckdd.mutKadDht.RUnlock()
+
+	if ckdd.kadDHT == nil {
+		return nil
+	}
+
+	return saveSnapshot(ckdd.snapshotter, ckdd.kadDHT.RoutingTable(), ckdd.host.Peerstore())
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (ckdd *ContinuousKadDhtDiscoverer) IsInterfaceNil() bool {
 	return ckdd == nil
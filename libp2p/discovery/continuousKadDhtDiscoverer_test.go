@@ -3,6 +3,8 @@ package discovery_test
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -29,6 +31,7 @@ func createTestArgument() discovery.ArgKadDht {
 		RoutingTableRefresh:         5 * time.Second,
 		SeedersReconnectionInterval: time.Second * 5,
 		ConnectionWatcher:           &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator:      &mock.AddressDenialEvaluatorStub{},
 	}
 }
 
@@ -112,6 +115,17 @@ func TestNewContinuousKadDhtDiscoverer(t *testing.T) {
 		assert.Nil(t, kdd)
 		assert.True(t, errors.Is(err, p2p.ErrNilConnectionsWatcher))
 	})
+	t.Run("nil address denial evaluator should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createTestArgument()
+		arg.AddressDenialEvaluator = nil
+
+		kdd, err := discovery.NewContinuousKadDhtDiscoverer(arg)
+
+		assert.Nil(t, kdd)
+		assert.True(t, errors.Is(err, p2p.ErrNilAddressDenialEvaluator))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -324,3 +338,30 @@ func TestContinuousKadDhtDiscoverer_Name(t *testing.T) {
 
 	assert.Equal(t, discovery.KadDhtName, kdd.Name())
 }
+
+func TestContinuousKadDhtDiscoverer_FindPeerNotStartedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	kdd, _ := discovery.NewContinuousKadDhtDiscoverer(arg)
+
+	addrInfo, err := kdd.FindPeer(context.Background(), "pid")
+	assert.Equal(t, p2p.ErrPeerDiscoveryProcessNotStarted, err)
+	assert.Empty(t, addrInfo.Addrs)
+}
+
+func TestContinuousKadDhtDiscoverer_CloseNotStartedShouldNotSaveSnapshot(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	arg.SnapshotFile = filepath.Join(t.TempDir(), "snapshot.json")
+	arg.SnapshotInterval = time.Hour
+	arg.SnapshotTTL = time.Hour
+	kdd, _ := discovery.NewContinuousKadDhtDiscoverer(arg)
+
+	err := kdd.Close()
+	assert.Nil(t, err)
+
+	_, err = os.Stat(arg.SnapshotFile)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -4,12 +4,20 @@ import (
 	"context"
 	"time"
 
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
 	"github.com/multiversx/mx-chain-p2p-go"
 )
 
 const KadDhtName = kadDhtName
 const OptimizedKadDhtName = optimizedKadDhtName
 const NullName = nilName
+const MdnsName = mdnsName
+const DefaultMdnsServiceName = defaultMdnsServiceName
+const MaxConcurrentSnapshotDials = maxConcurrentSnapshotDials
 
 // ------- ContinuousKadDhtDiscoverer
 
@@ -54,13 +62,17 @@ func NewOptimizedKadDhtDiscovererWithInitFunc(
 		chanInit:                    make(chan struct{}),
 		errChanInit:                 make(chan error),
 		chanConnectToSeeders:        make(chan struct{}),
+		seedersHealth:               createSeedersHealthTracker(arg),
+		snapshotter:                 newRoutingTableSnapshotter(arg.SnapshotFile, arg.SnapshotTTL),
+		snapshotInterval:            arg.SnapshotInterval,
 	}
 
 	okdd.createKadDhtHandler = createFunc
 	argConnectionManagement := ArgsHostWithConnectionManagement{
-		ConnectableHost:    arg.Host,
-		Sharder:            okdd.sharder,
-		ConnectionsWatcher: arg.ConnectionWatcher,
+		ConnectableHost:        arg.Host,
+		Sharder:                okdd.sharder,
+		ConnectionsWatcher:     arg.ConnectionWatcher,
+		AddressDenialEvaluator: arg.AddressDenialEvaluator,
 	}
 	okdd.hostConnManagement, err = NewHostWithConnectionManagement(argConnectionManagement)
 	if err != nil {
@@ -71,3 +83,134 @@ func NewOptimizedKadDhtDiscovererWithInitFunc(
 
 	return okdd, nil
 }
+
+// ------- mdnsDiscoverer
+
+// SetServiceCreator -
+func (md *mdnsDiscoverer) SetServiceCreator(handler func(h host.Host, serviceName string, notifee mdns.Notifee) MdnsService) {
+	md.createService = handler
+}
+
+// ServiceName -
+func (md *mdnsDiscoverer) ServiceName() string {
+	return md.serviceName
+}
+
+// FoundPeers -
+func (md *mdnsDiscoverer) FoundPeers() map[peer.ID]peer.AddrInfo {
+	md.mutFoundPeers.RLock()
+	defer md.mutFoundPeers.RUnlock()
+
+	foundPeers := make(map[peer.ID]peer.AddrInfo, len(md.foundPeers))
+	for pid, pi := range md.foundPeers {
+		foundPeers[pid] = pi
+	}
+
+	return foundPeers
+}
+
+// ------- staticPeersDiscoverer
+
+const StaticPeersName = staticPeersName
+
+// SetTimeHandler -
+func (spd *staticPeersDiscoverer) SetTimeHandler(handler func() time.Time) {
+	spd.timeHandler = handler
+}
+
+// SetReadFileHandler -
+func (spd *staticPeersDiscoverer) SetReadFileHandler(handler func(name string) ([]byte, error)) {
+	spd.readFileHandler = handler
+}
+
+// ConnectToPeers -
+func (spd *staticPeersDiscoverer) ConnectToPeers(ctx context.Context) {
+	spd.connectToPeers(ctx)
+}
+
+// ReloadPeersFile -
+func (spd *staticPeersDiscoverer) ReloadPeersFile() {
+	spd.reloadPeersFile()
+}
+
+// StaticPeers -
+func (spd *staticPeersDiscoverer) StaticPeers() map[peer.ID]time.Duration {
+	spd.mutPeers.RLock()
+	defer spd.mutPeers.RUnlock()
+
+	peers := make(map[peer.ID]time.Duration, len(spd.peers))
+	for pid, sp := range spd.peers {
+		peers[pid] = sp.backoff
+	}
+
+	return peers
+}
+
+// ------- seedersHealthTracker
+
+// NewSeedersHealthTracker -
+func NewSeedersHealthTracker(addresses []string, minBackoff time.Duration, maxBackoff time.Duration) *seedersHealthTracker {
+	return newSeedersHealthTracker(addresses, minBackoff, maxBackoff)
+}
+
+// SetTimeHandler -
+func (sht *seedersHealthTracker) SetTimeHandler(handler func() time.Time) {
+	sht.timeHandler = handler
+}
+
+// SeedersToDial -
+func (sht *seedersHealthTracker) SeedersToDial() []string {
+	return sht.seedersToDial()
+}
+
+// MarkSuccess -
+func (sht *seedersHealthTracker) MarkSuccess(address string, latency time.Duration) {
+	sht.markSuccess(address, latency)
+}
+
+// MarkFailure -
+func (sht *seedersHealthTracker) MarkFailure(address string, err error) uint32 {
+	return sht.markFailure(address, err)
+}
+
+// ResetBackoffs -
+func (sht *seedersHealthTracker) ResetBackoffs() {
+	sht.resetBackoffs()
+}
+
+// Status -
+func (sht *seedersHealthTracker) Status() []p2p.SeederStatus {
+	return sht.status()
+}
+
+// ------- routingTableSnapshotter
+
+// NewRoutingTableSnapshotter -
+func NewRoutingTableSnapshotter(filePath string, ttl time.Duration) *routingTableSnapshotter {
+	return newRoutingTableSnapshotter(filePath, ttl)
+}
+
+// SetTimeHandler -
+func (rts *routingTableSnapshotter) SetTimeHandler(handler func() time.Time) {
+	rts.timeHandler = handler
+}
+
+// Load -
+func (rts *routingTableSnapshotter) Load() ([]peer.AddrInfo, error) {
+	return rts.load()
+}
+
+// Save -
+func (rts *routingTableSnapshotter) Save(peers []peer.AddrInfo) error {
+	return rts.save(peers)
+}
+
+// ConnectToSnapshotPeers -
+func ConnectToSnapshotPeers(ctx context.Context, hostConnManagement *hostWithConnectionManagement, peers []peer.AddrInfo) {
+	connectToSnapshotPeers(ctx, hostConnManagement, peers)
+}
+
+// RoutingTablePeers -
+func RoutingTablePeers(rt *kbucket.RoutingTable, ps peerstore.Peerstore) []peer.AddrInfo {
+	return routingTablePeers(rt, ps)
+}
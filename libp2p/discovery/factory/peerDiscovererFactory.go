@@ -19,22 +19,56 @@ var log = logger.GetOrCreate("p2p/discovery/factory")
 
 // ArgsPeerDiscoverer is the DTO struct used in the NewPeerDiscoverer function
 type ArgsPeerDiscoverer struct {
-	Context            context.Context
-	Host               discovery.ConnectableHost
-	Sharder            p2p.Sharder
-	P2pConfig          config.P2PConfig
-	ConnectionsWatcher p2p.ConnectionsWatcher
+	Context                context.Context
+	Host                   discovery.ConnectableHost
+	Sharder                p2p.Sharder
+	P2pConfig              config.P2PConfig
+	ConnectionsWatcher     p2p.ConnectionsWatcher
+	AddressDenialEvaluator p2p.AddressDenialEvaluator
+	PreferredPeersHolder   p2p.PreferredPeersHolderHandler
 }
 
 // NewPeerDiscoverer generates an implementation of PeerDiscoverer by parsing the p2pConfig struct
+// If more than one discovery mechanism is enabled, all of them will run together
 // Errors if config is badly formatted
 func NewPeerDiscoverer(args ArgsPeerDiscoverer) (p2p.PeerDiscoverer, error) {
+	discoverers := make([]p2p.PeerDiscoverer, 0)
 	if args.P2pConfig.KadDhtPeerDiscovery.Enabled {
-		return createKadDhtPeerDiscoverer(args)
+		discoverer, err := createKadDhtPeerDiscoverer(args)
+		if err != nil {
+			return nil, err
+		}
+
+		discoverers = append(discoverers, discoverer)
+	}
+	if args.P2pConfig.MdnsPeerDiscovery.Enabled {
+		discoverer, err := createMdnsPeerDiscoverer(args)
+		if err != nil {
+			return nil, err
+		}
+
+		discoverers = append(discoverers, discoverer)
+	}
+
+	if args.P2pConfig.StaticPeerDiscovery.Enabled {
+		discoverer, err := createStaticPeersDiscoverer(args)
+		if err != nil {
+			return nil, err
+		}
+
+		discoverers = append(discoverers, discoverer)
 	}
 
-	log.Debug("using nil discoverer")
-	return discovery.NewNilDiscoverer(), nil
+	switch len(discoverers) {
+	case 0:
+		log.Debug("using nil discoverer")
+		return discovery.NewNilDiscoverer(), nil
+	case 1:
+		return discoverers[0], nil
+	default:
+		log.Debug("using composite discoverer", "num discoverers", len(discoverers))
+		return discovery.NewCompositeDiscoverer(discoverers...)
+	}
 }
 
 func createKadDhtPeerDiscoverer(args ArgsPeerDiscoverer) (p2p.PeerDiscoverer, error) {
@@ -49,6 +83,18 @@ func createKadDhtPeerDiscoverer(args ArgsPeerDiscoverer) (p2p.PeerDiscoverer, er
 		BucketSize:                  args.P2pConfig.KadDhtPeerDiscovery.BucketSize,
 		RoutingTableRefresh:         time.Second * time.Duration(args.P2pConfig.KadDhtPeerDiscovery.RoutingTableRefreshIntervalInSec),
 		ConnectionWatcher:           args.ConnectionsWatcher,
+		AddressDenialEvaluator:      args.AddressDenialEvaluator,
+	}
+
+	snapshotConfig := args.P2pConfig.KadDhtPeerDiscovery.RoutingTableSnapshot
+	if snapshotConfig.Enabled {
+		if len(snapshotConfig.FilePath) == 0 {
+			return nil, fmt.Errorf("%w, empty routing table snapshot file path", p2p.ErrInvalidValue)
+		}
+
+		arg.SnapshotFile = snapshotConfig.FilePath
+		arg.SnapshotInterval = time.Second * time.Duration(snapshotConfig.SaveIntervalInSec)
+		arg.SnapshotTTL = time.Second * time.Duration(snapshotConfig.EntryTTLInSec)
 	}
 
 	switch args.P2pConfig.Sharding.Type {
@@ -73,3 +119,36 @@ func createKadDhtDiscoverer(p2pConfig config.P2PConfig, arg discovery.ArgKadDht)
 			p2p.ErrInvalidValue, p2pConfig.KadDhtPeerDiscovery.Type)
 	}
 }
+
+func createMdnsPeerDiscoverer(args ArgsPeerDiscoverer) (p2p.PeerDiscoverer, error) {
+	arg := discovery.ArgsMdnsDiscoverer{
+		Context:                args.Context,
+		Host:                   args.Host,
+		Sharder:                args.Sharder,
+		ServiceName:            args.P2pConfig.MdnsPeerDiscovery.ServiceName,
+		ConnectionWatcher:      args.ConnectionsWatcher,
+		AddressDenialEvaluator: args.AddressDenialEvaluator,
+	}
+
+	log.Debug("using mdns discoverer")
+	return discovery.NewMdnsDiscoverer(arg)
+}
+
+func createStaticPeersDiscoverer(args ArgsPeerDiscoverer) (p2p.PeerDiscoverer, error) {
+	cfg := args.P2pConfig.StaticPeerDiscovery
+	arg := discovery.ArgsStaticPeersDiscoverer{
+		Context:                args.Context,
+		Host:                   args.Host,
+		Sharder:                args.Sharder,
+		ConnectionWatcher:      args.ConnectionsWatcher,
+		AddressDenialEvaluator: args.AddressDenialEvaluator,
+		PreferredPeersHolder:   args.PreferredPeersHolder,
+		PeersList:              cfg.PeerList,
+		PeersFile:              cfg.PeerListFile,
+		RefreshInterval:        time.Second * time.Duration(cfg.RefreshIntervalInSec),
+		MaxRedialBackoff:       time.Second * time.Duration(cfg.MaxRedialBackoffInSec),
+	}
+
+	log.Debug("using static peers discoverer", "num peers in config", len(cfg.PeerList), "peers file", cfg.PeerListFile)
+	return discovery.NewStaticPeersDiscoverer(arg)
+}
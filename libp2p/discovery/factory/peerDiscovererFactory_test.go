@@ -26,7 +26,8 @@ func TestNewPeerDiscoverer_NoDiscoveryEnabledShouldRetNullDiscoverer(t *testing.
 				Enabled: false,
 			},
 		},
-		ConnectionsWatcher: &mock.ConnectionsWatcherStub{},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
 	}
 	pDiscoverer, err := factory.NewPeerDiscoverer(args)
 	_, ok := pDiscoverer.(*discovery.NilDiscoverer)
@@ -53,7 +54,8 @@ func TestNewPeerDiscoverer_ListsSharderShouldWork(t *testing.T) {
 				Type: p2p.ListsSharder,
 			},
 		},
-		ConnectionsWatcher: &mock.ConnectionsWatcherStub{},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
 	}
 
 	pDiscoverer, err := factory.NewPeerDiscoverer(args)
@@ -82,7 +84,8 @@ func TestNewPeerDiscoverer_OptimizedKadDhtShouldWork(t *testing.T) {
 				Type: p2p.ListsSharder,
 			},
 		},
-		ConnectionsWatcher: &mock.ConnectionsWatcherStub{},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
 	}
 	pDiscoverer, err := factory.NewPeerDiscoverer(args)
 
@@ -107,7 +110,8 @@ func TestNewPeerDiscoverer_UnknownSharderShouldErr(t *testing.T) {
 				Type: "unknown",
 			},
 		},
-		ConnectionsWatcher: &mock.ConnectionsWatcherStub{},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
 	}
 
 	pDiscoverer, err := factory.NewPeerDiscoverer(args)
@@ -134,7 +138,8 @@ func TestNewPeerDiscoverer_UnknownKadDhtShouldErr(t *testing.T) {
 				Type: p2p.ListsSharder,
 			},
 		},
-		ConnectionsWatcher: &mock.ConnectionsWatcherStub{},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
 	}
 
 	pDiscoverer, err := factory.NewPeerDiscoverer(args)
@@ -142,3 +147,227 @@ func TestNewPeerDiscoverer_UnknownKadDhtShouldErr(t *testing.T) {
 	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
 	assert.True(t, check.IfNil(pDiscoverer))
 }
+
+func TestNewPeerDiscoverer_MdnsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	args := factory.ArgsPeerDiscoverer{
+		Context: context.Background(),
+		Host:    &mock.ConnectableHostStub{},
+		Sharder: &mock.KadSharderStub{},
+		P2pConfig: config.P2PConfig{
+			MdnsPeerDiscovery: config.MdnsPeerDiscoveryConfig{
+				Enabled:     true,
+				ServiceName: "_test._udp",
+			},
+			Sharding: config.ShardingConfig{
+				Type: p2p.ListsSharder,
+			},
+		},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+	}
+	pDiscoverer, err := factory.NewPeerDiscoverer(args)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pDiscoverer)
+	assert.Equal(t, "mdns discovery", pDiscoverer.Name())
+}
+
+func TestNewPeerDiscoverer_KadDhtAndMdnsEnabledShouldCreateComposite(t *testing.T) {
+	t.Parallel()
+
+	args := factory.ArgsPeerDiscoverer{
+		Context: context.Background(),
+		Host:    &mock.ConnectableHostStub{},
+		Sharder: &mock.KadSharderStub{},
+		P2pConfig: config.P2PConfig{
+			KadDhtPeerDiscovery: config.KadDhtPeerDiscoveryConfig{
+				Enabled:                          true,
+				RefreshIntervalInSec:             1,
+				RoutingTableRefreshIntervalInSec: 300,
+				Type:                             "optimized",
+			},
+			MdnsPeerDiscovery: config.MdnsPeerDiscoveryConfig{
+				Enabled: true,
+			},
+			Sharding: config.ShardingConfig{
+				Type: p2p.ListsSharder,
+			},
+		},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+	}
+
+	pDiscoverer, err := factory.NewPeerDiscoverer(args)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "composite discovery: [optimized kad-dht discovery, mdns discovery]", pDiscoverer.Name())
+	_, ok := pDiscoverer.(p2p.Reconnecter)
+	assert.True(t, ok)
+}
+
+func TestNewPeerDiscoverer_CompositeWithChildErrorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	args := factory.ArgsPeerDiscoverer{
+		Context: context.Background(),
+		Host:    &mock.ConnectableHostStub{},
+		Sharder: &mock.KadSharderStub{},
+		P2pConfig: config.P2PConfig{
+			KadDhtPeerDiscovery: config.KadDhtPeerDiscoveryConfig{
+				Enabled:                          true,
+				RefreshIntervalInSec:             1,
+				RoutingTableRefreshIntervalInSec: 300,
+				Type:                             "unknown",
+			},
+			MdnsPeerDiscovery: config.MdnsPeerDiscoveryConfig{
+				Enabled: true,
+			},
+			Sharding: config.ShardingConfig{
+				Type: p2p.ListsSharder,
+			},
+		},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+	}
+
+	pDiscoverer, err := factory.NewPeerDiscoverer(args)
+
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.True(t, check.IfNil(pDiscoverer))
+}
+
+func TestNewPeerDiscoverer_StaticPeers(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func() factory.ArgsPeerDiscoverer {
+		return factory.ArgsPeerDiscoverer{
+			Context: context.Background(),
+			Host:    &mock.ConnectableHostStub{},
+			Sharder: &mock.KadSharderStub{},
+			P2pConfig: config.P2PConfig{
+				StaticPeerDiscovery: config.StaticPeerDiscoveryConfig{
+					Enabled:               true,
+					PeerList:              []string{"/ip4/10.0.0.1/tcp/10000/p2p/16Uiu2HAkw5SNNtSvH1zJiQ6Gc3WoGNSxiyNueRKe6fuAuh57G3Bk"},
+					RefreshIntervalInSec:  1,
+					MaxRedialBackoffInSec: 60,
+				},
+				Sharding: config.ShardingConfig{
+					Type: p2p.ListsSharder,
+				},
+			},
+			ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+			AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+			PreferredPeersHolder:   &mock.PeersHolderStub{},
+		}
+	}
+
+	t.Run("nil preferred peers holder should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgs()
+		args.PreferredPeersHolder = nil
+		pDiscoverer, err := factory.NewPeerDiscoverer(args)
+
+		assert.Equal(t, p2p.ErrNilPreferredPeersHolder, err)
+		assert.True(t, check.IfNil(pDiscoverer))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		pDiscoverer, err := factory.NewPeerDiscoverer(createArgs())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "static peers discovery", pDiscoverer.Name())
+	})
+	t.Run("with kad dht should create composite", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgs()
+		args.P2pConfig.KadDhtPeerDiscovery = config.KadDhtPeerDiscoveryConfig{
+			Enabled:                          true,
+			RefreshIntervalInSec:             1,
+			RoutingTableRefreshIntervalInSec: 300,
+			Type:                             "optimized",
+		}
+		pDiscoverer, err := factory.NewPeerDiscoverer(args)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "composite discovery: [optimized kad-dht discovery, static peers discovery]", pDiscoverer.Name())
+	})
+}
+
+func TestNewPeerDiscoverer_RoutingTableSnapshot(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func(snapshotConfig config.RoutingTableSnapshotConfig) factory.ArgsPeerDiscoverer {
+		return factory.ArgsPeerDiscoverer{
+			Context: context.Background(),
+			Host:    &mock.ConnectableHostStub{},
+			Sharder: &mock.KadSharderStub{},
+			P2pConfig: config.P2PConfig{
+				KadDhtPeerDiscovery: config.KadDhtPeerDiscoveryConfig{
+					Enabled:                          true,
+					RefreshIntervalInSec:             1,
+					RoutingTableRefreshIntervalInSec: 300,
+					Type:                             "optimized",
+					RoutingTableSnapshot:             snapshotConfig,
+				},
+				Sharding: config.ShardingConfig{
+					Type: p2p.ListsSharder,
+				},
+			},
+			ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+			AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+		}
+	}
+
+	t.Run("empty file path should error", func(t *testing.T) {
+		t.Parallel()
+
+		pDiscoverer, err := factory.NewPeerDiscoverer(createArgs(config.RoutingTableSnapshotConfig{
+			Enabled:           true,
+			SaveIntervalInSec: 60,
+			EntryTTLInSec:     3600,
+		}))
+
+		assert.True(t, check.IfNil(pDiscoverer))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid save interval should error", func(t *testing.T) {
+		t.Parallel()
+
+		pDiscoverer, err := factory.NewPeerDiscoverer(createArgs(config.RoutingTableSnapshotConfig{
+			Enabled:       true,
+			FilePath:      "snapshot.json",
+			EntryTTLInSec: 3600,
+		}))
+
+		assert.True(t, check.IfNil(pDiscoverer))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("disabled snapshot should ignore the invalid values", func(t *testing.T) {
+		t.Parallel()
+
+		pDiscoverer, err := factory.NewPeerDiscoverer(createArgs(config.RoutingTableSnapshotConfig{
+			Enabled: false,
+		}))
+
+		assert.False(t, check.IfNil(pDiscoverer))
+		assert.Nil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		pDiscoverer, err := factory.NewPeerDiscoverer(createArgs(config.RoutingTableSnapshotConfig{
+			Enabled:           true,
+			FilePath:          "snapshot.json",
+			SaveIntervalInSec: 60,
+			EntryTTLInSec:     3600,
+		}))
+
+		assert.False(t, check.IfNil(pDiscoverer))
+		assert.Nil(t, err)
+	})
+}
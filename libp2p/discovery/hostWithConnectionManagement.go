@@ -15,15 +15,17 @@ import (
 
 // ArgsHostWithConnectionManagement is the argument DTO used in the NewHostWithConnectionManagement function
 type ArgsHostWithConnectionManagement struct {
-	ConnectableHost    ConnectableHost
-	Sharder            Sharder
-	ConnectionsWatcher p2p.ConnectionsWatcher
+	ConnectableHost        ConnectableHost
+	Sharder                Sharder
+	ConnectionsWatcher     p2p.ConnectionsWatcher
+	AddressDenialEvaluator p2p.AddressDenialEvaluator
 }
 
 type hostWithConnectionManagement struct {
 	ConnectableHost
-	sharder            Sharder
-	connectionsWatcher p2p.ConnectionsWatcher
+	sharder                Sharder
+	connectionsWatcher     p2p.ConnectionsWatcher
+	addressDenialEvaluator p2p.AddressDenialEvaluator
 }
 
 // NewHostWithConnectionManagement returns a host wrapper able to decide if connection initiated to a peer
@@ -38,15 +40,19 @@ func NewHostWithConnectionManagement(args ArgsHostWithConnectionManagement) (*ho
 	if check.IfNil(args.ConnectionsWatcher) {
 		return nil, p2p.ErrNilConnectionsWatcher
 	}
+	if check.IfNil(args.AddressDenialEvaluator) {
+		return nil, p2p.ErrNilAddressDenialEvaluator
+	}
 
 	return &hostWithConnectionManagement{
-		ConnectableHost:    args.ConnectableHost,
-		sharder:            args.Sharder,
-		connectionsWatcher: args.ConnectionsWatcher,
+		ConnectableHost:        args.ConnectableHost,
+		sharder:                args.Sharder,
+		connectionsWatcher:     args.ConnectionsWatcher,
+		addressDenialEvaluator: args.AddressDenialEvaluator,
 	}, nil
 }
 
-// Connect tries to connect to the provided address info if the sharder allows it
+// Connect tries to connect to the provided address info if the sharder and the address denial evaluator allow it
 func (hwcm *hostWithConnectionManagement) Connect(ctx context.Context, pi peer.AddrInfo) error {
 	addresses := concatenateAddresses(pi.Addrs)
 	hwcm.connectionsWatcher.NewKnownConnection(core.PeerID(pi.ID), addresses)
@@ -55,9 +61,31 @@ func (hwcm *hostWithConnectionManagement) Connect(ctx context.Context, pi peer.A
 		return err
 	}
 
+	allowedAddresses := hwcm.filterDeniedAddresses(pi)
+	if len(pi.Addrs) > 0 && len(allowedAddresses) == 0 {
+		return fmt.Errorf("%w, pid: %s", p2p.ErrDeniedAddress, pi.ID.String())
+	}
+	pi.Addrs = allowedAddresses
+
 	return hwcm.ConnectableHost.Connect(ctx, pi)
 }
 
+func (hwcm *hostWithConnectionManagement) filterDeniedAddresses(pi peer.AddrInfo) []multiaddr.Multiaddr {
+	allowedAddresses := make([]multiaddr.Multiaddr, 0, len(pi.Addrs))
+	for _, address := range pi.Addrs {
+		if hwcm.addressDenialEvaluator.IsAddressDenied(address) {
+			continue
+		}
+		if !hwcm.addressDenialEvaluator.CanAcceptPeer(core.PeerID(pi.ID), address) {
+			continue
+		}
+
+		allowedAddresses = append(allowedAddresses, address)
+	}
+
+	return allowedAddresses
+}
+
 func concatenateAddresses(addresses []multiaddr.Multiaddr) string {
 	sb := strings.Builder{}
 	for _, ma := range addresses {
@@ -67,15 +95,18 @@ func concatenateAddresses(addresses []multiaddr.Multiaddr) string {
 	return sb.String()
 }
 
+// canConnectToPeer dry-runs the sharder as to not record the pre-dial check as an eviction decision
 func (hwcm *hostWithConnectionManagement) canConnectToPeer(pid peer.ID) error {
 	allPeers := hwcm.ConnectableHost.Network().Peers()
 	if !hwcm.sharder.Has(pid, allPeers) {
 		allPeers = append(allPeers, pid)
 	}
 
-	evicted := hwcm.sharder.ComputeEvictionList(allPeers)
-	if hwcm.sharder.Has(pid, evicted) {
-		return fmt.Errorf("%w, pid: %s", p2p.ErrUnwantedPeer, pid.String())
+	decision := hwcm.sharder.ExplainEviction(allPeers)
+	for _, explanation := range decision.Peers {
+		if explanation.Pid == core.PeerID(pid) && explanation.IsEvicted {
+			return fmt.Errorf("%w, pid: %s", p2p.ErrUnwantedPeer, pid.String())
+		}
 	}
 
 	return nil
@@ -2,10 +2,12 @@ package discovery_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-p2p-go"
@@ -24,9 +26,10 @@ func createStubNetwork() network.Network {
 
 func createMockArgsHostWithConnectionManagement() discovery.ArgsHostWithConnectionManagement {
 	return discovery.ArgsHostWithConnectionManagement{
-		ConnectableHost:    &mock.ConnectableHostStub{},
-		Sharder:            &mock.KadSharderStub{},
-		ConnectionsWatcher: &mock.ConnectionsWatcherStub{},
+		ConnectableHost:        &mock.ConnectableHostStub{},
+		Sharder:                &mock.KadSharderStub{},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
 	}
 }
 
@@ -63,6 +66,16 @@ func TestNewHostWithConnectionManagement(t *testing.T) {
 		assert.True(t, check.IfNil(hwcm))
 		assert.Equal(t, p2p.ErrNilConnectionsWatcher, err)
 	})
+	t.Run("nil address denial evaluator should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsHostWithConnectionManagement()
+		args.AddressDenialEvaluator = nil
+		hwcm, err := discovery.NewHostWithConnectionManagement(args)
+
+		assert.True(t, check.IfNil(hwcm))
+		assert.Equal(t, p2p.ErrNilAddressDenialEvaluator, err)
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -91,8 +104,8 @@ func TestHostWithConnectionManagement_ConnectWithSharderNotEvictedShouldCallConn
 		},
 	}
 	args.Sharder = &mock.KadSharderStub{
-		ComputeEvictListCalled: func(pidList []peer.ID) []peer.ID {
-			return make([]peer.ID, 0)
+		ExplainEvictionCalled: func(pidList []peer.ID) p2p.EvictionDecision {
+			return p2p.EvictionDecision{}
 		},
 		HasCalled: func(pid peer.ID, list []peer.ID) bool {
 			return false
@@ -128,8 +141,17 @@ func TestHostWithConnectionManagement_ConnectWithSharderEvictedShouldNotCallConn
 	}
 	args.Sharder = &mock.KadSharderStub{
 		ComputeEvictListCalled: func(pidList []peer.ID) []peer.ID {
+			assert.Fail(t, "should have not recorded an eviction decision")
 			return make([]peer.ID, 0)
 		},
+		ExplainEvictionCalled: func(pidList []peer.ID) p2p.EvictionDecision {
+			return p2p.EvictionDecision{
+				NumEvicted: 1,
+				Peers: []p2p.PeerEvictionExplanation{
+					{Pid: "", IsEvicted: true},
+				},
+			}
+		},
 		HasCalled: func(pid peer.ID, list []peer.ID) bool {
 			return true
 		},
@@ -147,3 +169,75 @@ func TestHostWithConnectionManagement_ConnectWithSharderEvictedShouldNotCallConn
 	assert.False(t, connectCalled)
 	assert.True(t, newKnownConnectionCalled)
 }
+
+func TestHostWithConnectionManagement_ConnectShouldFilterDeniedAddresses(t *testing.T) {
+	t.Parallel()
+
+	deniedAddress := multiaddr.StringCast("/ip4/1.2.3.4/tcp/10000")
+	notAcceptedAddress := multiaddr.StringCast("/ip4/1.2.3.5/tcp/10000")
+	allowedAddress := multiaddr.StringCast("/ip4/1.2.3.6/tcp/10000")
+
+	createArgs := func(connectCalled *bool, connectedAddresses *[]multiaddr.Multiaddr) discovery.ArgsHostWithConnectionManagement {
+		args := createMockArgsHostWithConnectionManagement()
+		args.ConnectableHost = &mock.ConnectableHostStub{
+			ConnectCalled: func(_ context.Context, pi peer.AddrInfo) error {
+				*connectCalled = true
+				*connectedAddresses = pi.Addrs
+				return nil
+			},
+			NetworkCalled: func() network.Network {
+				return createStubNetwork()
+			},
+		}
+		args.Sharder = &mock.KadSharderStub{
+			ExplainEvictionCalled: func(pidList []peer.ID) p2p.EvictionDecision {
+				return p2p.EvictionDecision{}
+			},
+			HasCalled: func(pid peer.ID, list []peer.ID) bool {
+				return false
+			},
+		}
+		args.AddressDenialEvaluator = &mock.AddressDenialEvaluatorStub{
+			IsAddressDeniedCalled: func(address multiaddr.Multiaddr) bool {
+				return address.Equal(deniedAddress)
+			},
+			CanAcceptPeerCalled: func(pid core.PeerID, address multiaddr.Multiaddr) bool {
+				return !address.Equal(notAcceptedAddress)
+			},
+		}
+
+		return args
+	}
+
+	t.Run("all addresses denied should error", func(t *testing.T) {
+		t.Parallel()
+
+		connectCalled := false
+		var connectedAddresses []multiaddr.Multiaddr
+		hwcm, _ := discovery.NewHostWithConnectionManagement(createArgs(&connectCalled, &connectedAddresses))
+
+		err := hwcm.Connect(context.Background(), peer.AddrInfo{
+			ID:    "pid",
+			Addrs: []multiaddr.Multiaddr{deniedAddress, notAcceptedAddress},
+		})
+
+		assert.True(t, errors.Is(err, p2p.ErrDeniedAddress))
+		assert.False(t, connectCalled)
+	})
+	t.Run("should connect only on allowed addresses", func(t *testing.T) {
+		t.Parallel()
+
+		connectCalled := false
+		var connectedAddresses []multiaddr.Multiaddr
+		hwcm, _ := discovery.NewHostWithConnectionManagement(createArgs(&connectCalled, &connectedAddresses))
+
+		err := hwcm.Connect(context.Background(), peer.AddrInfo{
+			ID:    "pid",
+			Addrs: []multiaddr.Multiaddr{deniedAddress, notAcceptedAddress, allowedAddress},
+		})
+
+		assert.Nil(t, err)
+		assert.True(t, connectCalled)
+		assert.Equal(t, []multiaddr.Multiaddr{allowedAddress}, connectedAddresses)
+	})
+}
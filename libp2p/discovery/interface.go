@@ -3,9 +3,11 @@ package discovery
 import (
 	"context"
 
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiversx/mx-chain-core-go/core"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
 )
 
 // ConnectableHost is an enhanced Host interface that has the ability to connect to a string address
@@ -18,7 +20,7 @@ type ConnectableHost interface {
 
 // Sharder defines the eviction computing process of unwanted peers
 type Sharder interface {
-	ComputeEvictionList(pidList []peer.ID) []peer.ID
+	ExplainEviction(pidList []peer.ID) p2p.EvictionDecision
 	Has(pid peer.ID, list []peer.ID) bool
 	SetSeeders(addresses []string)
 	IsSeeder(pid core.PeerID) bool
@@ -28,4 +30,13 @@ type Sharder interface {
 // KadDhtHandler defines the behavior of a component that can find new peers in a p2p network through kad dht mechanism
 type KadDhtHandler interface {
 	Bootstrap(ctx context.Context) error
+	FindPeer(ctx context.Context, id peer.ID) (peer.AddrInfo, error)
+	RoutingTable() *kbucket.RoutingTable
+}
+
+// MdnsService defines the behavior of a multicast DNS service able to advertise the host and find the other hosts
+// on the local network
+type MdnsService interface {
+	Start() error
+	Close() error
 }
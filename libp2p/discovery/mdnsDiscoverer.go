@@ -0,0 +1,176 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+var _ p2p.PeerDiscoverer = (*mdnsDiscoverer)(nil)
+var _ p2p.Reconnecter = (*mdnsDiscoverer)(nil)
+var _ mdns.Notifee = (*mdnsDiscoverer)(nil)
+
+const mdnsName = "mdns discovery"
+const defaultMdnsServiceName = "_erd-p2p._udp"
+const mdnsConnectTimeout = time.Second * 10
+
+// ArgsMdnsDiscoverer represents the mdns discoverer argument DTO
+type ArgsMdnsDiscoverer struct {
+	Context                context.Context
+	Host                   ConnectableHost
+	Sharder                p2p.Sharder
+	ServiceName            string
+	ConnectionWatcher      p2p.ConnectionsWatcher
+	AddressDenialEvaluator p2p.AddressDenialEvaluator
+}
+
+type mdnsDiscoverer struct {
+	context            context.Context
+	serviceName        string
+	hostConnManagement *hostWithConnectionManagement
+	createService      func(h host.Host, serviceName string, notifee mdns.Notifee) MdnsService
+
+	mutService sync.Mutex
+	service    MdnsService
+
+	mutFoundPeers sync.RWMutex
+	foundPeers    map[peer.ID]peer.AddrInfo
+}
+
+// NewMdnsDiscoverer creates a peer discoverer that finds the peers from the local network through multicast DNS
+// Every connection attempt towards a found peer is filtered by the sharder and the address denial evaluator
+func NewMdnsDiscoverer(args ArgsMdnsDiscoverer) (*mdnsDiscoverer, error) {
+	if check.IfNilReflect(args.Context) {
+		return nil, p2p.ErrNilContext
+	}
+	if check.IfNil(args.Sharder) {
+		return nil, p2p.ErrNilSharder
+	}
+	sharder, ok := args.Sharder.(Sharder)
+	if !ok {
+		return nil, fmt.Errorf("%w for sharder: expected discovery.Sharder type of interface", p2p.ErrWrongTypeAssertion)
+	}
+
+	argsConnectionManagement := ArgsHostWithConnectionManagement{
+		ConnectableHost:        args.Host,
+		Sharder:                sharder,
+		ConnectionsWatcher:     args.ConnectionWatcher,
+		AddressDenialEvaluator: args.AddressDenialEvaluator,
+	}
+	hostConnManagement, err := NewHostWithConnectionManagement(argsConnectionManagement)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := args.ServiceName
+	if len(serviceName) == 0 {
+		serviceName = defaultMdnsServiceName
+	}
+
+	return &mdnsDiscoverer{
+		context:            args.Context,
+		serviceName:        serviceName,
+		hostConnManagement: hostConnManagement,
+		createService:      createMdnsService,
+		foundPeers:         make(map[peer.ID]peer.AddrInfo),
+	}, nil
+}
+
+func createMdnsService(h host.Host, serviceName string, notifee mdns.Notifee) MdnsService {
+	return mdns.NewMdnsService(h, serviceName, notifee)
+}
+
+// Bootstrap starts advertising the current host and listening for the other hosts on the local network
+func (md *mdnsDiscoverer) Bootstrap() error {
+	md.mutService.Lock()
+	defer md.mutService.Unlock()
+
+	if md.service != nil {
+		return p2p.ErrPeerDiscoveryProcessAlreadyStarted
+	}
+
+	service := md.createService(md.hostConnManagement, md.serviceName, md)
+	err := service.Start()
+	if err != nil {
+		return err
+	}
+
+	md.service = service
+	go md.closeServiceOnContextDone()
+
+	return nil
+}
+
+func (md *mdnsDiscoverer) closeServiceOnContextDone() {
+	<-md.context.Done()
+	log.Debug("closing the mdns discovery service")
+
+	md.mutService.Lock()
+	err := md.service.Close()
+	md.mutService.Unlock()
+
+	if err != nil {
+		log.Debug("error closing the mdns discovery service", "error", err.Error())
+	}
+}
+
+// HandlePeerFound is called by the mdns service each time a peer is found on the local network
+func (md *mdnsDiscoverer) HandlePeerFound(pi peer.AddrInfo) {
+	if pi.ID == md.hostConnManagement.ID() {
+		return
+	}
+
+	md.mutFoundPeers.Lock()
+	md.foundPeers[pi.ID] = pi
+	md.mutFoundPeers.Unlock()
+
+	md.connectToPeer(md.context, pi)
+}
+
+func (md *mdnsDiscoverer) connectToPeer(ctx context.Context, pi peer.AddrInfo) {
+	if md.hostConnManagement.IsConnected(pi) {
+		return
+	}
+
+	ctxConnect, cancel := context.WithTimeout(ctx, mdnsConnectTimeout)
+	defer cancel()
+
+	err := md.hostConnManagement.Connect(ctxConnect, pi)
+	if err != nil {
+		log.Trace("mdnsDiscoverer.connectToPeer",
+			"pid", pi.ID.String(),
+			"error", err.Error(),
+		)
+	}
+}
+
+// Name returns the name of the mdns peer discovery implementation
+func (md *mdnsDiscoverer) Name() string {
+	return mdnsName
+}
+
+// ReconnectToNetwork will try to reconnect to all the peers found so far on the local network
+func (md *mdnsDiscoverer) ReconnectToNetwork(ctx context.Context) {
+	md.mutFoundPeers.RLock()
+	foundPeers := make([]peer.AddrInfo, 0, len(md.foundPeers))
+	for _, pi := range md.foundPeers {
+		foundPeers = append(foundPeers, pi)
+	}
+	md.mutFoundPeers.RUnlock()
+
+	for _, pi := range foundPeers {
+		md.connectToPeer(ctx, pi)
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (md *mdnsDiscoverer) IsInterfaceNil() bool {
+	return md == nil
+}
@@ -0,0 +1,312 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/discovery"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const selfPid = peer.ID("self")
+
+func createMockArgsMdnsDiscoverer() discovery.ArgsMdnsDiscoverer {
+	return discovery.ArgsMdnsDiscoverer{
+		Context: context.Background(),
+		Host: &mock.ConnectableHostStub{
+			IDCalled: func() peer.ID {
+				return selfPid
+			},
+			NetworkCalled: func() network.Network {
+				return createStubNetwork()
+			},
+		},
+		Sharder:                &mock.KadSharderStub{},
+		ServiceName:            "_test._udp",
+		ConnectionWatcher:      &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+	}
+}
+
+func TestNewMdnsDiscoverer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		args.Context = nil
+		md, err := discovery.NewMdnsDiscoverer(args)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("nil sharder should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		args.Sharder = nil
+		md, err := discovery.NewMdnsDiscoverer(args)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilSharder, err)
+	})
+	t.Run("wrong sharder type should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		args.Sharder = &mock.SharderStub{}
+		md, err := discovery.NewMdnsDiscoverer(args)
+
+		assert.True(t, check.IfNil(md))
+		assert.True(t, errors.Is(err, p2p.ErrWrongTypeAssertion))
+	})
+	t.Run("nil host should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		args.Host = nil
+		md, err := discovery.NewMdnsDiscoverer(args)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilHost, err)
+	})
+	t.Run("nil connection watcher should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		args.ConnectionWatcher = nil
+		md, err := discovery.NewMdnsDiscoverer(args)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilConnectionsWatcher, err)
+	})
+	t.Run("nil address denial evaluator should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		args.AddressDenialEvaluator = nil
+		md, err := discovery.NewMdnsDiscoverer(args)
+
+		assert.True(t, check.IfNil(md))
+		assert.Equal(t, p2p.ErrNilAddressDenialEvaluator, err)
+	})
+	t.Run("empty service name should use the default one", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		args.ServiceName = ""
+		md, err := discovery.NewMdnsDiscoverer(args)
+
+		assert.Nil(t, err)
+		assert.Equal(t, discovery.DefaultMdnsServiceName, md.ServiceName())
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		md, err := discovery.NewMdnsDiscoverer(createMockArgsMdnsDiscoverer())
+
+		assert.False(t, check.IfNil(md))
+		assert.Nil(t, err)
+		assert.Equal(t, discovery.MdnsName, md.Name())
+		assert.Equal(t, "_test._udp", md.ServiceName())
+	})
+}
+
+func TestMdnsDiscoverer_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start error should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		md, _ := discovery.NewMdnsDiscoverer(createMockArgsMdnsDiscoverer())
+		md.SetServiceCreator(func(h host.Host, serviceName string, notifee mdns.Notifee) discovery.MdnsService {
+			return &mock.MdnsServiceStub{
+				StartCalled: func() error {
+					return expectedErr
+				},
+			}
+		})
+
+		err := md.Bootstrap()
+		assert.Equal(t, expectedErr, err)
+
+		// a failed start can be retried
+		md.SetServiceCreator(func(h host.Host, serviceName string, notifee mdns.Notifee) discovery.MdnsService {
+			return &mock.MdnsServiceStub{}
+		})
+		err = md.Bootstrap()
+		assert.Nil(t, err)
+	})
+	t.Run("should start the service once and close it when the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		args := createMockArgsMdnsDiscoverer()
+		args.Context = ctx
+		md, _ := discovery.NewMdnsDiscoverer(args)
+
+		numCreated := 0
+		chClosed := make(chan struct{})
+		md.SetServiceCreator(func(h host.Host, serviceName string, notifee mdns.Notifee) discovery.MdnsService {
+			numCreated++
+			assert.Equal(t, selfPid, h.ID())
+			assert.Equal(t, "_test._udp", serviceName)
+			assert.Equal(t, md, notifee)
+
+			return &mock.MdnsServiceStub{
+				CloseCalled: func() error {
+					close(chClosed)
+					return nil
+				},
+			}
+		})
+
+		err := md.Bootstrap()
+		assert.Nil(t, err)
+
+		err = md.Bootstrap()
+		assert.Equal(t, p2p.ErrPeerDiscoveryProcessAlreadyStarted, err)
+		assert.Equal(t, 1, numCreated)
+
+		cancel()
+		select {
+		case <-chClosed:
+		case <-time.After(time.Second):
+			assert.Fail(t, "the mdns service should have been closed")
+		}
+	})
+}
+
+func TestMdnsDiscoverer_HandlePeerFound(t *testing.T) {
+	t.Parallel()
+
+	t.Run("self should not connect", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsMdnsDiscoverer()
+		host := args.Host.(*mock.ConnectableHostStub)
+		host.ConnectCalled = func(ctx context.Context, pi peer.AddrInfo) error {
+			assert.Fail(t, "should have not called connect")
+			return nil
+		}
+		md, _ := discovery.NewMdnsDiscoverer(args)
+
+		md.HandlePeerFound(peer.AddrInfo{ID: selfPid})
+
+		assert.Empty(t, md.FoundPeers())
+	})
+	t.Run("already connected peer should not connect", func(t *testing.T) {
+		t.Parallel()
+
+		pid := peer.ID("pid")
+		args := createMockArgsMdnsDiscoverer()
+		host := args.Host.(*mock.ConnectableHostStub)
+		host.NetworkCalled = func() network.Network {
+			return &mock.NetworkStub{
+				ConnectednessCalled: func(id peer.ID) network.Connectedness {
+					return network.Connected
+				},
+			}
+		}
+		host.ConnectCalled = func(ctx context.Context, pi peer.AddrInfo) error {
+			assert.Fail(t, "should have not called connect")
+			return nil
+		}
+		md, _ := discovery.NewMdnsDiscoverer(args)
+
+		md.HandlePeerFound(peer.AddrInfo{ID: pid})
+
+		assert.Contains(t, md.FoundPeers(), pid)
+	})
+	t.Run("peer evicted by the sharder should not connect", func(t *testing.T) {
+		t.Parallel()
+
+		pid := peer.ID("pid")
+		args := createMockArgsMdnsDiscoverer()
+		host := args.Host.(*mock.ConnectableHostStub)
+		host.ConnectCalled = func(ctx context.Context, pi peer.AddrInfo) error {
+			assert.Fail(t, "should have not called connect")
+			return nil
+		}
+		args.Sharder = &mock.KadSharderStub{
+			ExplainEvictionCalled: func(pidList []peer.ID) p2p.EvictionDecision {
+				return p2p.EvictionDecision{
+					NumEvicted: 1,
+					Peers: []p2p.PeerEvictionExplanation{
+						{Pid: core.PeerID(pid), IsEvicted: true},
+					},
+				}
+			},
+			HasCalled: func(pid peer.ID, list []peer.ID) bool {
+				return len(list) > 0 && list[0] == pid
+			},
+		}
+		md, _ := discovery.NewMdnsDiscoverer(args)
+
+		md.HandlePeerFound(peer.AddrInfo{ID: pid})
+
+		assert.Contains(t, md.FoundPeers(), pid)
+	})
+	t.Run("new peer should connect", func(t *testing.T) {
+		t.Parallel()
+
+		pid := peer.ID("pid")
+		args := createMockArgsMdnsDiscoverer()
+		host := args.Host.(*mock.ConnectableHostStub)
+		connected := make([]peer.ID, 0)
+		host.ConnectCalled = func(ctx context.Context, pi peer.AddrInfo) error {
+			connected = append(connected, pi.ID)
+			return nil
+		}
+		md, _ := discovery.NewMdnsDiscoverer(args)
+
+		md.HandlePeerFound(peer.AddrInfo{ID: pid})
+
+		assert.Equal(t, []peer.ID{pid}, connected)
+		assert.Contains(t, md.FoundPeers(), pid)
+	})
+}
+
+func TestMdnsDiscoverer_ReconnectToNetwork(t *testing.T) {
+	t.Parallel()
+
+	pid1 := peer.ID("pid1")
+	pid2 := peer.ID("pid2")
+	args := createMockArgsMdnsDiscoverer()
+	host := args.Host.(*mock.ConnectableHostStub)
+	mut := sync.Mutex{}
+	connected := make(map[peer.ID]int)
+	host.ConnectCalled = func(ctx context.Context, pi peer.AddrInfo) error {
+		mut.Lock()
+		connected[pi.ID]++
+		mut.Unlock()
+
+		return nil
+	}
+	md, _ := discovery.NewMdnsDiscoverer(args)
+
+	md.HandlePeerFound(peer.AddrInfo{ID: pid1})
+	md.HandlePeerFound(peer.AddrInfo{ID: pid2})
+	md.ReconnectToNetwork(context.Background())
+
+	mut.Lock()
+	defer mut.Unlock()
+
+	require.Equal(t, 2, len(connected))
+	assert.Equal(t, 2, connected[pid1])
+	assert.Equal(t, 2, connected[pid2])
+}
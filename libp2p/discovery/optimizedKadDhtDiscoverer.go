@@ -2,9 +2,11 @@ package discovery
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	p2p "github.com/multiversx/mx-chain-p2p-go"
 )
@@ -15,8 +17,11 @@ const statNotInitialized discovererStatus = "not initialized"
 const statInitialized discovererStatus = "initialized"
 const minIntervalForSeedersReconnection = time.Second
 const optimizedKadDhtName = "optimized kad-dht discovery"
+const maxSeederReconnectionBackoff = time.Hour
+const minSeederBackoffReconnectionIntervals = 16
 
 type optimizedKadDhtDiscoverer struct {
+	mutKadDht                   sync.RWMutex
 	kadDHT                      KadDhtHandler
 	peersRefreshInterval        time.Duration
 	seedersReconnectionInterval time.Duration
@@ -32,6 +37,10 @@ type optimizedKadDhtDiscoverer struct {
 	chanConnectToSeeders        chan struct{}
 	createKadDhtHandler         func(ctx context.Context) (KadDhtHandler, error)
 	connectionWatcher           p2p.ConnectionsWatcher
+	seedersHealth               *seedersHealthTracker
+	isConnectedToSeeders        bool
+	snapshotter                 *routingTableSnapshotter
+	snapshotInterval            time.Duration
 }
 
 // NewOptimizedKadDhtDiscoverer creates an optimized kad-dht discovery type implementation
@@ -62,13 +71,17 @@ func NewOptimizedKadDhtDiscoverer(arg ArgKadDht) (*optimizedKadDhtDiscoverer, er
 		errChanInit:                 make(chan error),
 		chanConnectToSeeders:        make(chan struct{}),
 		connectionWatcher:           arg.ConnectionWatcher,
+		seedersHealth:               createSeedersHealthTracker(arg),
+		snapshotter:                 newRoutingTableSnapshotter(arg.SnapshotFile, arg.SnapshotTTL),
+		snapshotInterval:            arg.SnapshotInterval,
 	}
 
 	okdd.createKadDhtHandler = okdd.createKadDht
 	args := ArgsHostWithConnectionManagement{
-		ConnectableHost:    arg.Host,
-		Sharder:            okdd.sharder,
-		ConnectionsWatcher: okdd.connectionWatcher,
+		ConnectableHost:        arg.Host,
+		Sharder:                okdd.sharder,
+		ConnectionsWatcher:     okdd.connectionWatcher,
+		AddressDenialEvaluator: arg.AddressDenialEvaluator,
 	}
 	okdd.hostConnManagement, err = NewHostWithConnectionManagement(args)
 	if err != nil {
@@ -89,6 +102,7 @@ func (okdd *optimizedKadDhtDiscoverer) Bootstrap() error {
 func (okdd *optimizedKadDhtDiscoverer) processLoop(ctx context.Context) {
 	chTimeSeedersReconnect := time.After(okdd.seedersReconnectionInterval)
 	chTimeFindPeers := time.After(okdd.peersRefreshInterval)
+	chTimeSnapshot := okdd.createChTimeSnapshot()
 
 	for {
 		select {
@@ -99,12 +113,17 @@ func (okdd *optimizedKadDhtDiscoverer) processLoop(ctx context.Context) {
 			chTimeSeedersReconnect = okdd.processSeedersReconnect(ctx)
 
 		case <-okdd.chanConnectToSeeders:
+			okdd.seedersHealth.resetBackoffs()
 			chTimeSeedersReconnect = okdd.processSeedersReconnect(ctx)
 
 		case <-chTimeFindPeers:
 			okdd.findPeers(ctx)
 			chTimeFindPeers = time.After(okdd.peersRefreshInterval)
 
+		case <-chTimeSnapshot:
+			_ = okdd.saveSnapshot()
+			chTimeSnapshot = okdd.createChTimeSnapshot()
+
 		case <-ctx.Done():
 			log.Debug("closing the p2p bootstrapping process")
 
@@ -121,6 +140,9 @@ func (okdd *optimizedKadDhtDiscoverer) processInit(ctx context.Context) <-chan t
 		return okdd.createChTimeSeedersReconnect(false)
 	}
 
+	// the snapshot peers are dialed in parallel with the seeders
+	go dialSnapshotPeers(ctx, okdd.snapshotter, okdd.hostConnManagement)
+
 	ch := okdd.processSeedersReconnect(ctx)
 	okdd.findPeers(ctx)
 
@@ -128,8 +150,13 @@ func (okdd *optimizedKadDhtDiscoverer) processInit(ctx context.Context) <-chan t
 }
 
 func (okdd *optimizedKadDhtDiscoverer) processSeedersReconnect(ctx context.Context) <-chan time.Time {
-	isConnectedToSeeders := okdd.tryToReconnectAtLeastToASeeder(ctx)
-	return okdd.createChTimeSeedersReconnect(isConnectedToSeeders)
+	if !okdd.isConnectedToSeeders {
+		// no seeder was connected on the previous round, all of them are retried regardless of their backoff
+		okdd.seedersHealth.resetBackoffs()
+	}
+
+	okdd.isConnectedToSeeders = okdd.tryToReconnectAtLeastToASeeder(ctx)
+	return okdd.createChTimeSeedersReconnect(okdd.isConnectedToSeeders)
 }
 
 func (okdd *optimizedKadDhtDiscoverer) finishMainLoopProcessing(ctx context.Context) {
@@ -149,6 +176,27 @@ func (okdd *optimizedKadDhtDiscoverer) createChTimeSeedersReconnect(isConnectedT
 	return time.After(okdd.peersRefreshInterval)
 }
 
+// createChTimeSnapshot returns a nil channel, which will never be selected, if the snapshot is disabled
+func (okdd *optimizedKadDhtDiscoverer) createChTimeSnapshot() <-chan time.Time {
+	if !okdd.snapshotter.isEnabled() {
+		return nil
+	}
+
+	return time.After(okdd.snapshotInterval)
+}
+
+func (okdd *optimizedKadDhtDiscoverer) saveSnapshot() error {
+	okdd.mutKadDht.RLock()
+	kadDht := okdd.kadDHT
+	okdd.mutKadDht.RUnlock()
+
+	if kadDht == nil {
+		return nil
+	}
+
+	return saveSnapshot(okdd.snapshotter, kadDht.RoutingTable(), okdd.hostConnManagement.Peerstore())
+}
+
 func (okdd *optimizedKadDhtDiscoverer) init(ctx context.Context) error {
 	if okdd.status != statNotInitialized {
 		return p2p.ErrPeerDiscoveryProcessAlreadyStarted
@@ -159,7 +207,9 @@ func (okdd *optimizedKadDhtDiscoverer) init(ctx context.Context) error {
 		return err
 	}
 
+	okdd.mutKadDht.Lock()
 	okdd.kadDHT = kadDhtHandler
+	okdd.mutKadDht.Unlock()
 	okdd.status = statInitialized
 
 	return nil
@@ -176,6 +226,19 @@ func (okdd *optimizedKadDhtDiscoverer) createKadDht(ctx context.Context) (KadDht
 	)
 }
 
+// createSeedersHealthTracker creates the seeders health tracker. The backoff of a failing seeder is allowed to grow
+// past several reconnection intervals, so a dead seeder will not be dialed on each round
+func createSeedersHealthTracker(arg ArgKadDht) *seedersHealthTracker {
+	maxBackoff := maxSeederReconnectionBackoff
+	minMaxBackoff := minSeederBackoffReconnectionIntervals * arg.SeedersReconnectionInterval
+	if minMaxBackoff > maxBackoff {
+		maxBackoff = minMaxBackoff
+	}
+
+	return newSeedersHealthTracker(arg.InitialPeersList, arg.SeedersReconnectionInterval, maxBackoff)
+}
+
+// tryToReconnectAtLeastToASeeder dials the seeders whose backoff expired, the healthy ones first
 func (okdd *optimizedKadDhtDiscoverer) tryToReconnectAtLeastToASeeder(ctx context.Context) bool {
 	if okdd.status != statInitialized {
 		return false
@@ -185,13 +248,17 @@ func (okdd *optimizedKadDhtDiscoverer) tryToReconnectAtLeastToASeeder(ctx contex
 		return true
 	}
 
-	connectedToOneSeeder := false
-	for _, seederAddress := range okdd.initialPeersList {
-		err := okdd.connectToSeeder(ctx, seederAddress)
+	seedersToDial := okdd.seedersHealth.seedersToDial()
+	numDialedSeeders := 0
+	numConnectedSeeders := 0
+	for _, seederAddress := range seedersToDial {
+		numDialedSeeders++
+		latency, err := okdd.connectToSeeder(ctx, seederAddress)
 		if err != nil {
-			printConnectionErrorToSeeder(seederAddress, err)
+			okdd.handleSeederConnectionError(seederAddress, err)
 		} else {
-			connectedToOneSeeder = true
+			okdd.seedersHealth.markSuccess(seederAddress, latency)
+			numConnectedSeeders++
 		}
 
 		select {
@@ -204,22 +271,47 @@ func (okdd *optimizedKadDhtDiscoverer) tryToReconnectAtLeastToASeeder(ctx contex
 	}
 
 	log.Debug("optimizedKadDhtDiscoverer.tryToReconnectAtLeastToASeeder",
-		"num seeders", len(okdd.initialPeersList), "connected to a seeder", connectedToOneSeeder)
+		"num seeders", len(okdd.initialPeersList), "num dialed seeders", numDialedSeeders,
+		"num connected seeders", numConnectedSeeders)
 
-	return connectedToOneSeeder
+	return numConnectedSeeders > 0
 }
 
-func (okdd *optimizedKadDhtDiscoverer) connectToSeeder(ctx context.Context, seederAddress string) error {
+// handleSeederConnectionError records the failure and prints the error only on the first consecutive failure, the
+// next ones being printed on trace level, so a broken seeder will not flood the logs
+func (okdd *optimizedKadDhtDiscoverer) handleSeederConnectionError(seederAddress string, err error) {
+	numFailures := okdd.seedersHealth.markFailure(seederAddress, err)
+	if numFailures <= 1 {
+		printConnectionErrorToSeeder(seederAddress, err)
+		return
+	}
+
+	log.Trace("error connecting to seeder",
+		"seeder", seederAddress,
+		"consecutive failures", numFailures,
+		"error", err.Error(),
+	)
+}
+
+// connectToSeeder connects to the provided seeder, returning the time spent dialing it. The returned duration is 0
+// if the seeder was already connected
+func (okdd *optimizedKadDhtDiscoverer) connectToSeeder(ctx context.Context, seederAddress string) (time.Duration, error) {
 	seederInfo, err := okdd.hostConnManagement.AddressToPeerInfo(seederAddress)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if okdd.hostConnManagement.IsConnected(*seederInfo) {
-		return nil
+		return 0, nil
+	}
+
+	startTime := time.Now()
+	err = okdd.hostConnManagement.Connect(ctx, *seederInfo)
+	if err != nil {
+		return 0, err
 	}
 
-	return okdd.hostConnManagement.Connect(ctx, *seederInfo)
+	return time.Since(startTime), nil
 }
 
 func (okdd *optimizedKadDhtDiscoverer) findPeers(ctx context.Context) {
@@ -233,12 +325,31 @@ func (okdd *optimizedKadDhtDiscoverer) findPeers(ctx context.Context) {
 	}
 }
 
+// FindPeer searches the addresses of the provided peer in the kad dht network
+func (okdd *optimizedKadDhtDiscoverer) FindPeer(ctx context.Context, pid peer.ID) (peer.AddrInfo, error) {
+	okdd.mutKadDht.RLock()
+	kadDht := okdd.kadDHT
+	okdd.mutKadDht.RUnlock()
+
+	if kadDht == nil {
+		return peer.AddrInfo{}, p2p.ErrPeerDiscoveryProcessNotStarted
+	}
+
+	return kadDht.FindPeer(ctx, pid)
+}
+
+// SeedersStatus returns the health state of all the seeders from the initial peers list
+func (okdd *optimizedKadDhtDiscoverer) SeedersStatus() []p2p.SeederStatus {
+	return okdd.seedersHealth.status()
+}
+
 // Name returns the name of the kad dht peer discovery implementation
 func (okdd *optimizedKadDhtDiscoverer) Name() string {
 	return optimizedKadDhtName
 }
 
-// ReconnectToNetwork will try to connect to one peer from the initial peer list
+// ReconnectToNetwork will try to connect to the peers from the initial peer list, ignoring the backoff of the
+// failing ones
 func (okdd *optimizedKadDhtDiscoverer) ReconnectToNetwork(_ context.Context) {
 	select {
 	case okdd.chanConnectToSeeders <- struct{}{}:
@@ -246,6 +357,11 @@ func (okdd *optimizedKadDhtDiscoverer) ReconnectToNetwork(_ context.Context) {
 	}
 }
 
+// Close saves the routing table snapshot, if enabled. The discovery process is stopped through the context
+func (okdd *optimizedKadDhtDiscoverer) Close() error {
+	return okdd.saveSnapshot()
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (okdd *optimizedKadDhtDiscoverer) IsInterfaceNil() bool {
 	return okdd == nil
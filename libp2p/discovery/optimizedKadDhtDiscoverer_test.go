@@ -3,17 +3,24 @@ package discovery_test
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/discovery"
 	"github.com/multiversx/mx-chain-p2p-go/mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewOptimizedKadDhtDiscoverer(t *testing.T) {
@@ -33,6 +40,22 @@ func TestNewOptimizedKadDhtDiscoverer(t *testing.T) {
 		okdd, err = discovery.NewOptimizedKadDhtDiscoverer(arg)
 		assert.Equal(t, p2p.ErrInvalidSeedersReconnectionInterval, err)
 		assert.True(t, check.IfNil(okdd))
+
+		arg = createTestArgument()
+		arg.SnapshotFile = "snapshot.json"
+		arg.SnapshotTTL = time.Hour
+		okdd, err = discovery.NewOptimizedKadDhtDiscoverer(arg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "SnapshotInterval"))
+		assert.True(t, check.IfNil(okdd))
+
+		arg = createTestArgument()
+		arg.SnapshotFile = "snapshot.json"
+		arg.SnapshotInterval = time.Hour
+		okdd, err = discovery.NewOptimizedKadDhtDiscoverer(arg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "SnapshotTTL"))
+		assert.True(t, check.IfNil(okdd))
 	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
@@ -261,3 +284,262 @@ func TestOptimizedKadDhtDiscoverer_ReconnectToNetwork(t *testing.T) {
 	assert.True(t, connectCalled > 0)
 	mutConnect.Unlock()
 }
+
+func TestOptimizedKadDhtDiscoverer_BrokenSeederShouldBackoff(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	arg.SeedersReconnectionInterval = time.Second
+	arg.InitialPeersList = []string{"broken", "healthy"}
+	mutConnect := sync.Mutex{}
+	connectCalls := make(map[peer.ID]int)
+	arg.Host = &mock.ConnectableHostStub{
+		ConnectCalled: func(ctx context.Context, pi peer.AddrInfo) error {
+			mutConnect.Lock()
+			defer mutConnect.Unlock()
+
+			connectCalls[pi.ID]++
+			if pi.ID == "broken" {
+				return errors.New("cannot connect")
+			}
+
+			return nil
+		},
+		AddressToPeerInfoCalled: func(address string) (*peer.AddrInfo, error) {
+			return &peer.AddrInfo{ID: peer.ID(address)}, nil
+		},
+	}
+	var cancelFunc func()
+	arg.Context, cancelFunc = context.WithCancel(context.Background())
+	kadDhtStub := &mock.KadDhtHandlerStub{
+		BootstrapCalled: func(ctx context.Context) error {
+			return nil
+		},
+	}
+
+	okdd, _ := discovery.NewOptimizedKadDhtDiscovererWithInitFunc(
+		arg,
+		func(ctx context.Context) (discovery.KadDhtHandler, error) {
+			return kadDhtStub, nil
+		},
+	)
+
+	err := okdd.Bootstrap()
+	time.Sleep(time.Second*4 + time.Millisecond*500) // the value is chosen as such as to avoid edgecases on select statement
+	cancelFunc()
+
+	assert.Nil(t, err)
+	mutConnect.Lock()
+	// the healthy seeder is dialed each round while the broken one is dialed at 0s, 1s and 3s
+	assert.True(t, connectCalls["broken"] < connectCalls["healthy"])
+	mutConnect.Unlock()
+
+	status := okdd.SeedersStatus()
+	require.Equal(t, 2, len(status))
+	assert.Equal(t, "broken", status[0].Address)
+	assert.False(t, status[0].IsHealthy)
+	assert.True(t, status[0].ConsecutiveFailures > 1)
+	assert.Equal(t, "cannot connect", status[0].LastError)
+	assert.Equal(t, "healthy", status[1].Address)
+	assert.True(t, status[1].IsHealthy)
+	assert.False(t, status[1].LastSuccess.IsZero())
+}
+
+func TestOptimizedKadDhtDiscoverer_AllHealthySeedersShouldBeDialed(t *testing.T) {
+	t.Parallel()
+
+	arg := createTestArgument()
+	arg.SeedersReconnectionInterval = time.Second
+	arg.InitialPeersList = []string{"broken", "healthy1", "healthy2", "healthy3"}
+	mutConnect := sync.Mutex{}
+	connectCalls := make(map[peer.ID]int)
+	arg.Host = &mock.ConnectableHostStub{
+		ConnectCalled: func(ctx context.Context, pi peer.AddrInfo) error {
+			mutConnect.Lock()
+			defer mutConnect.Unlock()
+
+			connectCalls[pi.ID]++
+			if pi.ID == "broken" {
+				return errors.New("cannot connect")
+			}
+
+			return nil
+		},
+		AddressToPeerInfoCalled: func(address string) (*peer.AddrInfo, error) {
+			return &peer.AddrInfo{ID: peer.ID(address)}, nil
+		},
+	}
+	var cancelFunc func()
+	arg.Context, cancelFunc = context.WithCancel(context.Background())
+	kadDhtStub := &mock.KadDhtHandlerStub{
+		BootstrapCalled: func(ctx context.Context) error {
+			return nil
+		},
+	}
+
+	okdd, _ := discovery.NewOptimizedKadDhtDiscovererWithInitFunc(
+		arg,
+		func(ctx context.Context) (discovery.KadDhtHandler, error) {
+			return kadDhtStub, nil
+		},
+	)
+
+	err := okdd.Bootstrap()
+	time.Sleep(time.Second*4 + time.Millisecond*500) // the value is chosen as such as to avoid edgecases on select statement
+	cancelFunc()
+
+	assert.Nil(t, err)
+	mutConnect.Lock()
+	// all the healthy seeders are dialed each round, regardless of the number of already connected seeders
+	assert.True(t, connectCalls["broken"] < connectCalls["healthy1"])
+	assert.Equal(t, connectCalls["healthy1"], connectCalls["healthy2"])
+	assert.Equal(t, connectCalls["healthy1"], connectCalls["healthy3"])
+	assert.True(t, connectCalls["healthy1"] > 1)
+	mutConnect.Unlock()
+}
+
+func TestOptimizedKadDhtDiscoverer_FindPeer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not initialized should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createTestArgument()
+		var cancelFunc func()
+		arg.Context, cancelFunc = context.WithCancel(context.Background())
+		defer cancelFunc()
+
+		okdd, _ := discovery.NewOptimizedKadDhtDiscoverer(arg)
+
+		addrInfo, err := okdd.FindPeer(context.Background(), "pid")
+		assert.Equal(t, p2p.ErrPeerDiscoveryProcessNotStarted, err)
+		assert.Empty(t, addrInfo.Addrs)
+	})
+	t.Run("initialized should call the kad dht", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createTestArgument()
+		arg.InitialPeersList = make([]string, 0)
+		var cancelFunc func()
+		arg.Context, cancelFunc = context.WithCancel(context.Background())
+		defer cancelFunc()
+
+		expectedAddrInfo := peer.AddrInfo{
+			ID: "pid",
+		}
+		kadDhtStub := &mock.KadDhtHandlerStub{
+			FindPeerCalled: func(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+				assert.Equal(t, expectedAddrInfo.ID, id)
+				return expectedAddrInfo, nil
+			},
+		}
+		okdd, _ := discovery.NewOptimizedKadDhtDiscovererWithInitFunc(
+			arg,
+			func(ctx context.Context) (discovery.KadDhtHandler, error) {
+				return kadDhtStub, nil
+			},
+		)
+
+		err := okdd.Bootstrap()
+		require.Nil(t, err)
+
+		addrInfo, err := okdd.FindPeer(context.Background(), "pid")
+		assert.Nil(t, err)
+		assert.Equal(t, expectedAddrInfo, addrInfo)
+	})
+}
+
+func TestOptimizedKadDhtDiscoverer_RoutingTableSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bootstrap should dial the snapshot peers", func(t *testing.T) {
+		t.Parallel()
+
+		snapshotPeer := createSnapshotAddrInfo(t, snapshotPid1, "/ip4/127.0.0.1/tcp/10000")
+		filePath := filepath.Join(t.TempDir(), "snapshot.json")
+		err := discovery.NewRoutingTableSnapshotter(filePath, time.Hour).Save([]peer.AddrInfo{snapshotPeer})
+		require.Nil(t, err)
+
+		arg := createTestArgument()
+		arg.InitialPeersList = make([]string, 0)
+		arg.SnapshotFile = filePath
+		arg.SnapshotInterval = time.Hour
+		arg.SnapshotTTL = time.Hour
+		chConnected := make(chan peer.AddrInfo, 1)
+		arg.Host = &mock.ConnectableHostStub{
+			ConnectCalled: func(ctx context.Context, pi peer.AddrInfo) error {
+				chConnected <- pi
+				return nil
+			},
+		}
+		var cancelFunc func()
+		arg.Context, cancelFunc = context.WithCancel(context.Background())
+		defer cancelFunc()
+
+		okdd, _ := discovery.NewOptimizedKadDhtDiscovererWithInitFunc(
+			arg,
+			func(ctx context.Context) (discovery.KadDhtHandler, error) {
+				return &mock.KadDhtHandlerStub{}, nil
+			},
+		)
+
+		err = okdd.Bootstrap()
+		require.Nil(t, err)
+
+		select {
+		case pi := <-chConnected:
+			assert.Equal(t, snapshotPeer, pi)
+		case <-time.After(time.Second * 2):
+			assert.Fail(t, "the snapshot peer should have been dialed")
+		}
+	})
+	t.Run("close should save the routing table", func(t *testing.T) {
+		t.Parallel()
+
+		rtPeer := createSnapshotAddrInfo(t, snapshotPid2, "/ip4/127.0.0.1/tcp/10001")
+		filePath := filepath.Join(t.TempDir(), "snapshot.json")
+		arg := createTestArgument()
+		arg.InitialPeersList = make([]string, 0)
+		arg.SnapshotFile = filePath
+		arg.SnapshotInterval = time.Hour
+		arg.SnapshotTTL = time.Hour
+		arg.Host = &mock.ConnectableHostStub{
+			PeerstoreCalled: func() peerstore.Peerstore {
+				return &mock.PeerstoreStub{
+					AddrsCalled: func(p peer.ID) []multiaddr.Multiaddr {
+						return rtPeer.Addrs
+					},
+				}
+			},
+		}
+		var cancelFunc func()
+		arg.Context, cancelFunc = context.WithCancel(context.Background())
+		defer cancelFunc()
+
+		okdd, _ := discovery.NewOptimizedKadDhtDiscovererWithInitFunc(
+			arg,
+			func(ctx context.Context) (discovery.KadDhtHandler, error) {
+				return &mock.KadDhtHandlerStub{
+					RoutingTableCalled: func() *kbucket.RoutingTable {
+						return createRoutingTableWithPeers(t, rtPeer.ID)
+					},
+				}, nil
+			},
+		)
+
+		// not bootstrapped yet, nothing to save
+		err := okdd.Close()
+		assert.Nil(t, err)
+		_, err = os.Stat(filePath)
+		assert.True(t, os.IsNotExist(err))
+
+		err = okdd.Bootstrap()
+		require.Nil(t, err)
+		err = okdd.Close()
+		assert.Nil(t, err)
+
+		peers, err := discovery.NewRoutingTableSnapshotter(filePath, time.Hour).Load()
+		assert.Nil(t, err)
+		assert.Equal(t, []peer.AddrInfo{rtPeer}, peers)
+	})
+}
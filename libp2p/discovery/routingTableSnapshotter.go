@@ -0,0 +1,301 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+const snapshotPeerConnectTimeout = time.Second * 10
+const maxConcurrentSnapshotDials = 10
+const snapshotFilePermissions = 0644
+
+type snapshotEntry struct {
+	Pid       string   `json:"pid"`
+	Addresses []string `json:"addresses"`
+	LastSeen  int64    `json:"lastSeen"`
+}
+
+type routingTableSnapshot struct {
+	Peers []snapshotEntry `json:"peers"`
+}
+
+// routingTableSnapshotter persists the kad-dht routing table peers, together with their peerstore addresses, in a
+// local file so a restarted node can dial them right away. The snapshot holds only the current routing table peers.
+// While the routing table is empty, the previously saved peers are kept until they were not seen for longer than the
+// configured TTL. An empty file path disables the snapshotter
+type routingTableSnapshotter struct {
+	filePath    string
+	ttl         time.Duration
+	timeHandler func() time.Time
+
+	mutEntries sync.Mutex
+	entries    map[peer.ID]snapshotEntry
+}
+
+func newRoutingTableSnapshotter(filePath string, ttl time.Duration) *routingTableSnapshotter {
+	return &routingTableSnapshotter{
+		filePath:    filePath,
+		ttl:         ttl,
+		timeHandler: time.Now,
+		entries:     make(map[peer.ID]snapshotEntry),
+	}
+}
+
+func (rts *routingTableSnapshotter) isEnabled() bool {
+	return len(rts.filePath) > 0
+}
+
+// load reads the snapshot file and returns the peers that did not expire. A missing file is not an error as there
+// is no snapshot on the first start of the node
+func (rts *routingTableSnapshotter) load() ([]peer.AddrInfo, error) {
+	if !rts.isEnabled() {
+		return make([]peer.AddrInfo, 0), nil
+	}
+
+	buff, err := os.ReadFile(rts.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return make([]peer.AddrInfo, 0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &routingTableSnapshot{}
+	err = json.Unmarshal(buff, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	rts.mutEntries.Lock()
+	defer rts.mutEntries.Unlock()
+
+	now := rts.timeHandler()
+	peers := make([]peer.AddrInfo, 0, len(snapshot.Peers))
+	for _, entry := range snapshot.Peers {
+		if rts.isExpired(entry, now) {
+			continue
+		}
+
+		pi, errConvert := entryToAddrInfo(entry)
+		if errConvert != nil {
+			log.Trace("routingTableSnapshotter.load: invalid entry", "pid", entry.Pid, "error", errConvert.Error())
+			continue
+		}
+
+		rts.entries[pi.ID] = entry
+		peers = append(peers, pi)
+	}
+
+	return peers, nil
+}
+
+func entryToAddrInfo(entry snapshotEntry) (peer.AddrInfo, error) {
+	pid, err := peer.Decode(entry.Pid)
+	if err != nil {
+		return peer.AddrInfo{}, err
+	}
+
+	addresses := make([]multiaddr.Multiaddr, 0, len(entry.Addresses))
+	for _, address := range entry.Addresses {
+		ma, errNewMultiaddr := multiaddr.NewMultiaddr(address)
+		if errNewMultiaddr != nil {
+			continue
+		}
+
+		addresses = append(addresses, ma)
+	}
+	if len(addresses) == 0 {
+		return peer.AddrInfo{}, p2p.ErrPeerAddressesNotFound
+	}
+
+	return peer.AddrInfo{
+		ID:    pid,
+		Addrs: addresses,
+	}, nil
+}
+
+// save replaces the stored entries with the provided peers, drops the expired entries and writes the result in the
+// snapshot file. An empty peers list keeps the previous entries, so a node that momentarily lost its routing table
+// peers will still have what to dial. The file is replaced atomically so a crash while saving will not corrupt the
+// previous snapshot
+func (rts *routingTableSnapshotter) save(peers []peer.AddrInfo) error {
+	if !rts.isEnabled() {
+		return nil
+	}
+
+	rts.mutEntries.Lock()
+	defer rts.mutEntries.Unlock()
+
+	now := rts.timeHandler()
+	if len(peers) > 0 {
+		// the snapshot does not grow with the routing table churn
+		rts.entries = make(map[peer.ID]snapshotEntry, len(peers))
+	}
+	for _, pi := range peers {
+		if len(pi.Addrs) == 0 {
+			continue
+		}
+
+		addresses := make([]string, 0, len(pi.Addrs))
+		for _, address := range pi.Addrs {
+			addresses = append(addresses, address.String())
+		}
+
+		rts.entries[pi.ID] = snapshotEntry{
+			Pid:       pi.ID.String(),
+			Addresses: addresses,
+			LastSeen:  now.Unix(),
+		}
+	}
+
+	snapshot := &routingTableSnapshot{
+		Peers: make([]snapshotEntry, 0, len(rts.entries)),
+	}
+	for pid, entry := range rts.entries {
+		if rts.isExpired(entry, now) {
+			delete(rts.entries, pid)
+			continue
+		}
+
+		snapshot.Peers = append(snapshot.Peers, entry)
+	}
+
+	buff, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(rts.filePath, buff)
+}
+
+func (rts *routingTableSnapshotter) isExpired(entry snapshotEntry, now time.Time) bool {
+	return now.Sub(time.Unix(entry.LastSeen, 0)) > rts.ttl
+}
+
+func writeFileAtomically(filePath string, buff []byte) error {
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return err
+	}
+	tempFilePath := tempFile.Name()
+
+	_, err = tempFile.Write(buff)
+	if err != nil {
+		_ = tempFile.Close()
+		_ = os.Remove(tempFilePath)
+		return err
+	}
+
+	err = tempFile.Close()
+	if err != nil {
+		_ = os.Remove(tempFilePath)
+		return err
+	}
+
+	err = os.Chmod(tempFilePath, snapshotFilePermissions)
+	if err != nil {
+		_ = os.Remove(tempFilePath)
+		return err
+	}
+
+	return os.Rename(tempFilePath, filePath)
+}
+
+// routingTablePeers returns the peers from the routing table, together with their addresses from the peerstore.
+// Peers with no known address are skipped
+func routingTablePeers(rt *kbucket.RoutingTable, ps peerstore.Peerstore) []peer.AddrInfo {
+	if rt == nil {
+		return make([]peer.AddrInfo, 0)
+	}
+
+	pids := rt.ListPeers()
+	peers := make([]peer.AddrInfo, 0, len(pids))
+	for _, pid := range pids {
+		addresses := ps.Addrs(pid)
+		if len(addresses) == 0 {
+			continue
+		}
+
+		peers = append(peers, peer.AddrInfo{
+			ID:    pid,
+			Addrs: addresses,
+		})
+	}
+
+	return peers
+}
+
+// dialSnapshotPeers loads the routing table snapshot, if enabled, and dials the stored peers
+func dialSnapshotPeers(ctx context.Context, snapshotter *routingTableSnapshotter, hostConnManagement *hostWithConnectionManagement) {
+	peers, err := snapshotter.load()
+	if err != nil {
+		log.Warn("unable to load the routing table snapshot", "file", snapshotter.filePath, "error", err.Error())
+		return
+	}
+	if len(peers) == 0 {
+		return
+	}
+
+	connectToSnapshotPeers(ctx, hostConnManagement, peers)
+}
+
+// saveSnapshot stores the peers from the provided routing table in the snapshot file, if enabled
+func saveSnapshot(snapshotter *routingTableSnapshotter, rt *kbucket.RoutingTable, ps peerstore.Peerstore) error {
+	if !snapshotter.isEnabled() {
+		return nil
+	}
+
+	peers := routingTablePeers(rt, ps)
+	err := snapshotter.save(peers)
+	if err != nil {
+		log.Warn("unable to save the routing table snapshot", "file", snapshotter.filePath, "error", err.Error())
+		return err
+	}
+
+	log.Debug("saved the routing table snapshot", "file", snapshotter.filePath, "num routing table peers", len(peers))
+
+	return nil
+}
+
+// connectToSnapshotPeers dials in parallel, at most maxConcurrentSnapshotDials at a time, all the provided peers,
+// ignoring the errors as the snapshot peers might have left the network meanwhile
+func connectToSnapshotPeers(ctx context.Context, hostConnManagement *hostWithConnectionManagement, peers []peer.AddrInfo) {
+	wg := &sync.WaitGroup{}
+	wg.Add(len(peers))
+	chDialSlots := make(chan struct{}, maxConcurrentSnapshotDials)
+	for _, pi := range peers {
+		chDialSlots <- struct{}{}
+		go func(pi peer.AddrInfo) {
+			defer func() {
+				<-chDialSlots
+				wg.Done()
+			}()
+
+			if hostConnManagement.IsConnected(pi) {
+				return
+			}
+
+			ctxConnect, cancel := context.WithTimeout(ctx, snapshotPeerConnectTimeout)
+			defer cancel()
+
+			err := hostConnManagement.Connect(ctxConnect, pi)
+			if err != nil {
+				log.Trace("error connecting to snapshot peer", "pid", pi.ID.String(), "error", err.Error())
+			}
+		}(pi)
+	}
+	wg.Wait()
+
+	log.Debug("finished dialing the routing table snapshot peers", "num peers", len(peers))
+}
@@ -0,0 +1,265 @@
+package discovery_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p/core/peer"
+	pstore "github.com/libp2p/go-libp2p/p2p/host/peerstore"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/discovery"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const snapshotPid1 = "16Uiu2HAkw5SNNtSvH1zJiQ6Gc3WoGNSxiyNueRKe6fuAuh57G3Bk"
+const snapshotPid2 = "16Uiu2HAm6hPymvkZyFgbvWaVBKhEoPjmXhkV32r9JaFvQ7Rk8ynU"
+
+func createSnapshotAddrInfo(t *testing.T, pidString string, address string) peer.AddrInfo {
+	pid, err := peer.Decode(pidString)
+	require.Nil(t, err)
+
+	ma, err := multiaddr.NewMultiaddr(address)
+	require.Nil(t, err)
+
+	return peer.AddrInfo{
+		ID:    pid,
+		Addrs: []multiaddr.Multiaddr{ma},
+	}
+}
+
+func createRoutingTableWithPeers(t *testing.T, pids ...peer.ID) *kbucket.RoutingTable {
+	rt, err := kbucket.NewRoutingTable(10, kbucket.ConvertPeerID("self"), time.Hour, pstore.NewMetrics(), time.Hour, nil)
+	require.Nil(t, err)
+
+	for _, pid := range pids {
+		_, err = rt.TryAddPeer(pid, true, false)
+		require.Nil(t, err)
+	}
+
+	return rt
+}
+
+func TestRoutingTableSnapshotter_Load(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		rts := discovery.NewRoutingTableSnapshotter("", time.Hour)
+
+		peers, err := rts.Load()
+		assert.Nil(t, err)
+		assert.Empty(t, peers)
+	})
+	t.Run("missing file should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		rts := discovery.NewRoutingTableSnapshotter(filepath.Join(t.TempDir(), "snapshot.json"), time.Hour)
+
+		peers, err := rts.Load()
+		assert.Nil(t, err)
+		assert.Empty(t, peers)
+	})
+	t.Run("corrupted file should error", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "snapshot.json")
+		err := os.WriteFile(filePath, []byte("not a json"), 0644)
+		require.Nil(t, err)
+		rts := discovery.NewRoutingTableSnapshotter(filePath, time.Hour)
+
+		peers, err := rts.Load()
+		assert.NotNil(t, err)
+		assert.Nil(t, peers)
+	})
+	t.Run("invalid and expired entries should be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now().Unix()
+		filePath := filepath.Join(t.TempDir(), "snapshot.json")
+		content := fmt.Sprintf(`{"peers":[`+
+			`{"pid":"invalid","addresses":["/ip4/127.0.0.1/tcp/10000"],"lastSeen":%d},`+
+			`{"pid":"%s","addresses":["invalid"],"lastSeen":%d},`+
+			`{"pid":"%s","addresses":["invalid","/ip4/127.0.0.1/tcp/10001"],"lastSeen":%d},`+
+			`{"pid":"%s","addresses":["/ip4/127.0.0.1/tcp/10002"],"lastSeen":%d}]}`,
+			now, snapshotPid1, now, snapshotPid2, now, snapshotPid1, now-7200)
+		err := os.WriteFile(filePath, []byte(content), 0644)
+		require.Nil(t, err)
+		rts := discovery.NewRoutingTableSnapshotter(filePath, time.Hour)
+
+		peers, err := rts.Load()
+		assert.Nil(t, err)
+		assert.Equal(t, []peer.AddrInfo{createSnapshotAddrInfo(t, snapshotPid2, "/ip4/127.0.0.1/tcp/10001")}, peers)
+	})
+}
+
+func TestRoutingTableSnapshotter_Save(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled should not write", func(t *testing.T) {
+		t.Parallel()
+
+		rts := discovery.NewRoutingTableSnapshotter("", time.Hour)
+
+		err := rts.Save([]peer.AddrInfo{createSnapshotAddrInfo(t, snapshotPid1, "/ip4/127.0.0.1/tcp/10000")})
+		assert.Nil(t, err)
+	})
+	t.Run("missing directory should error", func(t *testing.T) {
+		t.Parallel()
+
+		rts := discovery.NewRoutingTableSnapshotter(filepath.Join(t.TempDir(), "missing", "snapshot.json"), time.Hour)
+
+		err := rts.Save([]peer.AddrInfo{createSnapshotAddrInfo(t, snapshotPid1, "/ip4/127.0.0.1/tcp/10000")})
+		assert.NotNil(t, err)
+	})
+	t.Run("saved peers should be loaded", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "snapshot.json")
+		peer1 := createSnapshotAddrInfo(t, snapshotPid1, "/ip4/127.0.0.1/tcp/10000")
+		rts := discovery.NewRoutingTableSnapshotter(filePath, time.Hour)
+
+		err := rts.Save([]peer.AddrInfo{peer1, {ID: "no address peer"}})
+		require.Nil(t, err)
+
+		peers, err := discovery.NewRoutingTableSnapshotter(filePath, time.Hour).Load()
+		assert.Nil(t, err)
+		assert.Equal(t, []peer.AddrInfo{peer1}, peers)
+
+		files, err := os.ReadDir(filepath.Dir(filePath))
+		require.Nil(t, err)
+		assert.Equal(t, 1, len(files), "the temporary file should have been renamed")
+	})
+	t.Run("peers not in the routing table anymore should be dropped", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "snapshot.json")
+		peer1 := createSnapshotAddrInfo(t, snapshotPid1, "/ip4/127.0.0.1/tcp/10000")
+		peer2 := createSnapshotAddrInfo(t, snapshotPid2, "/ip4/127.0.0.1/tcp/10001")
+		rts := discovery.NewRoutingTableSnapshotter(filePath, time.Hour)
+
+		err := rts.Save([]peer.AddrInfo{peer1, peer2})
+		require.Nil(t, err)
+
+		// peer1 left the routing table
+		err = rts.Save([]peer.AddrInfo{peer2})
+		require.Nil(t, err)
+		peers, err := discovery.NewRoutingTableSnapshotter(filePath, time.Hour).Load()
+		assert.Nil(t, err)
+		assert.Equal(t, []peer.AddrInfo{peer2}, peers)
+	})
+	t.Run("empty routing table should keep the previous peers until they expire", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		filePath := filepath.Join(t.TempDir(), "snapshot.json")
+		peer1 := createSnapshotAddrInfo(t, snapshotPid1, "/ip4/127.0.0.1/tcp/10000")
+		rts := discovery.NewRoutingTableSnapshotter(filePath, time.Hour)
+		rts.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		err := rts.Save([]peer.AddrInfo{peer1})
+		require.Nil(t, err)
+
+		currentTime = currentTime.Add(time.Minute * 30)
+		err = rts.Save(make([]peer.AddrInfo, 0))
+		require.Nil(t, err)
+		peers, err := discovery.NewRoutingTableSnapshotter(filePath, time.Hour).Load()
+		assert.Nil(t, err)
+		assert.Equal(t, []peer.AddrInfo{peer1}, peers)
+
+		currentTime = currentTime.Add(time.Minute * 31)
+		err = rts.Save(make([]peer.AddrInfo, 0))
+		require.Nil(t, err)
+		peers, err = discovery.NewRoutingTableSnapshotter(filePath, time.Hour).Load()
+		assert.Nil(t, err)
+		assert.Empty(t, peers)
+	})
+}
+
+func TestConnectToSnapshotPeers(t *testing.T) {
+	t.Parallel()
+
+	numPeers := discovery.MaxConcurrentSnapshotDials * 3
+	mutDials := sync.Mutex{}
+	numDials := 0
+	numConcurrentDials := 0
+	maxConcurrentDials := 0
+	args := discovery.ArgsHostWithConnectionManagement{
+		ConnectableHost: &mock.ConnectableHostStub{
+			ConnectCalled: func(ctx context.Context, pi peer.AddrInfo) error {
+				mutDials.Lock()
+				numDials++
+				numConcurrentDials++
+				if numConcurrentDials > maxConcurrentDials {
+					maxConcurrentDials = numConcurrentDials
+				}
+				mutDials.Unlock()
+
+				time.Sleep(time.Millisecond * 50)
+
+				mutDials.Lock()
+				numConcurrentDials--
+				mutDials.Unlock()
+
+				return nil
+			},
+		},
+		Sharder:                &mock.KadSharderStub{},
+		ConnectionsWatcher:     &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+	}
+	hwcm, _ := discovery.NewHostWithConnectionManagement(args)
+
+	peers := make([]peer.AddrInfo, 0, numPeers)
+	for i := 0; i < numPeers; i++ {
+		peers = append(peers, peer.AddrInfo{ID: peer.ID(fmt.Sprintf("pid%d", i))})
+	}
+
+	discovery.ConnectToSnapshotPeers(context.Background(), hwcm, peers)
+
+	mutDials.Lock()
+	assert.Equal(t, numPeers, numDials)
+	assert.Equal(t, discovery.MaxConcurrentSnapshotDials, maxConcurrentDials)
+	mutDials.Unlock()
+}
+
+func TestRoutingTablePeers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil routing table should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		peers := discovery.RoutingTablePeers(nil, &mock.PeerstoreStub{})
+		assert.NotNil(t, peers)
+		assert.Empty(t, peers)
+	})
+	t.Run("should return the routing table peers with known addresses", func(t *testing.T) {
+		t.Parallel()
+
+		peer1 := createSnapshotAddrInfo(t, snapshotPid1, "/ip4/127.0.0.1/tcp/10000")
+		peer2 := createSnapshotAddrInfo(t, snapshotPid2, "/ip4/127.0.0.1/tcp/10001")
+		rt := createRoutingTableWithPeers(t, peer1.ID, peer2.ID)
+		ps := &mock.PeerstoreStub{
+			AddrsCalled: func(p peer.ID) []multiaddr.Multiaddr {
+				if p == peer1.ID {
+					return peer1.Addrs
+				}
+
+				return nil
+			},
+		}
+
+		peers := discovery.RoutingTablePeers(rt, ps)
+		assert.Equal(t, []peer.AddrInfo{peer1}, peers)
+	})
+}
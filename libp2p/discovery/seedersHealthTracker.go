@@ -0,0 +1,198 @@
+package discovery
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+type seederHealth struct {
+	address             string
+	lastSuccess         time.Time
+	lastAttempt         time.Time
+	lastError           string
+	consecutiveFailures uint32
+	latency             time.Duration
+	backoff             time.Duration
+	nextAttempt         time.Time
+}
+
+func (sh *seederHealth) isHealthy() bool {
+	return sh.consecutiveFailures == 0
+}
+
+// seedersHealthTracker keeps the health state of each seeder and decides which seeders should be dialed, and in
+// which order. Failing seeders are redialed with an exponential backoff
+type seedersHealthTracker struct {
+	mut         sync.RWMutex
+	seeders     []*seederHealth
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	timeHandler func() time.Time
+}
+
+func newSeedersHealthTracker(addresses []string, minBackoff time.Duration, maxBackoff time.Duration) *seedersHealthTracker {
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	sht := &seedersHealthTracker{
+		seeders:     make([]*seederHealth, 0, len(addresses)),
+		minBackoff:  minBackoff,
+		maxBackoff:  maxBackoff,
+		timeHandler: time.Now,
+	}
+	for _, address := range addresses {
+		sht.seeders = append(sht.seeders, &seederHealth{
+			address: address,
+		})
+	}
+
+	return sht
+}
+
+// seedersToDial returns the addresses of the seeders whose backoff expired. The healthy seeders come first, sorted
+// by their latency, followed by the failing ones, sorted by their number of consecutive failures
+func (sht *seedersHealthTracker) seedersToDial() []string {
+	sht.mut.RLock()
+	defer sht.mut.RUnlock()
+
+	now := sht.timeHandler()
+	candidates := make([]*seederHealth, 0, len(sht.seeders))
+	for _, sh := range sht.seeders {
+		if now.Before(sh.nextAttempt) {
+			continue
+		}
+
+		candidates = append(candidates, sh)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return isPreferredSeeder(candidates[i], candidates[j])
+	})
+
+	addresses := make([]string, 0, len(candidates))
+	for _, sh := range candidates {
+		addresses = append(addresses, sh.address)
+	}
+
+	return addresses
+}
+
+func isPreferredSeeder(first *seederHealth, second *seederHealth) bool {
+	if first.isHealthy() != second.isHealthy() {
+		return first.isHealthy()
+	}
+	if !first.isHealthy() {
+		return first.consecutiveFailures < second.consecutiveFailures
+	}
+
+	// seeders with a not yet measured latency are placed after the measured ones
+	if first.latency == 0 || second.latency == 0 {
+		return first.latency != 0 && second.latency == 0
+	}
+
+	return first.latency < second.latency
+}
+
+// markSuccess records a successful connection to the seeder. A zero latency, as in the case of an already connected
+// seeder, keeps the previously measured one
+func (sht *seedersHealthTracker) markSuccess(address string, latency time.Duration) {
+	sht.mut.Lock()
+	defer sht.mut.Unlock()
+
+	sh := sht.getSeeder(address)
+	if sh == nil {
+		return
+	}
+
+	now := sht.timeHandler()
+	sh.lastAttempt = now
+	sh.lastSuccess = now
+	sh.lastError = ""
+	sh.consecutiveFailures = 0
+	sh.backoff = 0
+	sh.nextAttempt = time.Time{}
+	if latency > 0 {
+		sh.latency = latency
+	}
+}
+
+// markFailure records a failed connection to the seeder, delaying its next attempt, and returns the number of
+// consecutive failures
+func (sht *seedersHealthTracker) markFailure(address string, err error) uint32 {
+	sht.mut.Lock()
+	defer sht.mut.Unlock()
+
+	sh := sht.getSeeder(address)
+	if sh == nil {
+		return 0
+	}
+
+	now := sht.timeHandler()
+	sh.lastAttempt = now
+	sh.lastError = err.Error()
+	sh.consecutiveFailures++
+	sh.backoff = sht.computeNextBackoff(sh.backoff)
+	sh.nextAttempt = now.Add(sh.backoff)
+
+	return sh.consecutiveFailures
+}
+
+func (sht *seedersHealthTracker) computeNextBackoff(backoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return sht.minBackoff
+	}
+
+	backoff *= 2
+	if backoff > sht.maxBackoff {
+		return sht.maxBackoff
+	}
+
+	return backoff
+}
+
+// resetBackoffs makes all the seeders eligible for dialing, keeping their health state
+func (sht *seedersHealthTracker) resetBackoffs() {
+	sht.mut.Lock()
+	defer sht.mut.Unlock()
+
+	for _, sh := range sht.seeders {
+		sh.backoff = 0
+		sh.nextAttempt = time.Time{}
+	}
+}
+
+func (sht *seedersHealthTracker) getSeeder(address string) *seederHealth {
+	for _, sh := range sht.seeders {
+		if sh.address == address {
+			return sh
+		}
+	}
+
+	return nil
+}
+
+// status returns the health state of all the seeders, in the configured order
+func (sht *seedersHealthTracker) status() []p2p.SeederStatus {
+	sht.mut.RLock()
+	defer sht.mut.RUnlock()
+
+	result := make([]p2p.SeederStatus, 0, len(sht.seeders))
+	for _, sh := range sht.seeders {
+		result = append(result, p2p.SeederStatus{
+			Address:             sh.address,
+			IsHealthy:           sh.isHealthy(),
+			LastSuccess:         sh.lastSuccess,
+			LastAttempt:         sh.lastAttempt,
+			LastError:           sh.lastError,
+			ConsecutiveFailures: sh.consecutiveFailures,
+			Latency:             sh.latency,
+			NextAttempt:         sh.nextAttempt,
+		})
+	}
+
+	return result
+}
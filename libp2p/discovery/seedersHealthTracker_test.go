@@ -0,0 +1,132 @@
+package discovery_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedersHealthTracker_SeedersToDial(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+
+	t.Run("new tracker should return all seeders in the configured order", func(t *testing.T) {
+		t.Parallel()
+
+		sht := discovery.NewSeedersHealthTracker([]string{"s1", "s2", "s3"}, time.Second, time.Minute)
+
+		assert.Equal(t, []string{"s1", "s2", "s3"}, sht.SeedersToDial())
+	})
+	t.Run("healthy seeders should be sorted by latency and placed before the failing ones", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		sht := discovery.NewSeedersHealthTracker([]string{"s1", "s2", "s3", "s4", "s5"}, time.Second, time.Minute)
+		sht.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		sht.MarkFailure("s1", expectedErr)
+		sht.MarkFailure("s1", expectedErr)
+		sht.MarkFailure("s2", expectedErr)
+		sht.MarkSuccess("s3", time.Millisecond*30)
+		sht.MarkSuccess("s5", time.Millisecond*10)
+
+		// all backoffs expired
+		currentTime = currentTime.Add(time.Hour)
+
+		assert.Equal(t, []string{"s5", "s3", "s4", "s2", "s1"}, sht.SeedersToDial())
+	})
+	t.Run("failing seeders should be skipped until their backoff expires", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		sht := discovery.NewSeedersHealthTracker([]string{"s1", "s2"}, time.Second, time.Minute)
+		sht.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		numFailures := sht.MarkFailure("s1", expectedErr)
+		assert.Equal(t, uint32(1), numFailures)
+		assert.Equal(t, []string{"s2"}, sht.SeedersToDial())
+
+		currentTime = currentTime.Add(time.Second)
+		assert.Equal(t, []string{"s2", "s1"}, sht.SeedersToDial())
+
+		numFailures = sht.MarkFailure("s1", expectedErr)
+		assert.Equal(t, uint32(2), numFailures)
+		currentTime = currentTime.Add(time.Second)
+		assert.Equal(t, []string{"s2"}, sht.SeedersToDial())
+		currentTime = currentTime.Add(time.Second)
+		assert.Equal(t, []string{"s2", "s1"}, sht.SeedersToDial())
+	})
+	t.Run("reset backoffs should return all seeders", func(t *testing.T) {
+		t.Parallel()
+
+		sht := discovery.NewSeedersHealthTracker([]string{"s1", "s2"}, time.Second, time.Minute)
+		sht.MarkFailure("s1", expectedErr)
+		sht.MarkFailure("s2", expectedErr)
+		assert.Empty(t, sht.SeedersToDial())
+
+		sht.ResetBackoffs()
+
+		assert.Equal(t, []string{"s1", "s2"}, sht.SeedersToDial())
+		status := sht.Status()
+		assert.Equal(t, uint32(1), status[0].ConsecutiveFailures)
+		assert.Equal(t, uint32(1), status[1].ConsecutiveFailures)
+	})
+}
+
+func TestSeedersHealthTracker_Status(t *testing.T) {
+	t.Parallel()
+
+	currentTime := time.Now()
+	sht := discovery.NewSeedersHealthTracker([]string{"s1", "s2", "unknown"}, time.Second, time.Second*5)
+	sht.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+
+	sht.MarkSuccess("s1", time.Millisecond*20)
+	sht.MarkSuccess("s1", 0) // already connected, the latency should be kept
+	sht.MarkFailure("s2", errors.New("error 1"))
+	sht.MarkFailure("s2", errors.New("error 2"))
+	sht.MarkFailure("s2", errors.New("error 3"))
+	sht.MarkFailure("s2", errors.New("error 4"))
+	sht.MarkSuccess("missing", time.Second)
+
+	status := sht.Status()
+	require.Equal(t, 3, len(status))
+
+	assert.Equal(t, "s1", status[0].Address)
+	assert.True(t, status[0].IsHealthy)
+	assert.Equal(t, currentTime, status[0].LastSuccess)
+	assert.Equal(t, currentTime, status[0].LastAttempt)
+	assert.Equal(t, time.Millisecond*20, status[0].Latency)
+	assert.Zero(t, status[0].ConsecutiveFailures)
+	assert.True(t, status[0].NextAttempt.IsZero())
+
+	assert.Equal(t, "s2", status[1].Address)
+	assert.False(t, status[1].IsHealthy)
+	assert.True(t, status[1].LastSuccess.IsZero())
+	assert.Equal(t, "error 4", status[1].LastError)
+	assert.Equal(t, uint32(4), status[1].ConsecutiveFailures)
+	// backoffs: 1s, 2s, 4s and capped at 5s
+	assert.Equal(t, currentTime.Add(time.Second*5), status[1].NextAttempt)
+
+	assert.Equal(t, "unknown", status[2].Address)
+	assert.True(t, status[2].IsHealthy)
+	assert.True(t, status[2].LastAttempt.IsZero())
+
+	// a successful connection should make the seeder healthy again
+	sht.MarkSuccess("s2", time.Millisecond*50)
+	status = sht.Status()
+	assert.True(t, status[1].IsHealthy)
+	assert.Empty(t, status[1].LastError)
+	assert.Zero(t, status[1].ConsecutiveFailures)
+	assert.True(t, status[1].NextAttempt.IsZero())
+}
@@ -0,0 +1,405 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+var _ p2p.PeerDiscoverer = (*staticPeersDiscoverer)(nil)
+var _ p2p.Reconnecter = (*staticPeersDiscoverer)(nil)
+
+const staticPeersName = "static peers discovery"
+const staticPeerConnectTimeout = time.Second * 10
+const peersFileCommentPrefix = "#"
+
+// ArgsStaticPeersDiscoverer represents the static peers discoverer argument DTO
+type ArgsStaticPeersDiscoverer struct {
+	Context                context.Context
+	Host                   ConnectableHost
+	Sharder                p2p.Sharder
+	ConnectionWatcher      p2p.ConnectionsWatcher
+	AddressDenialEvaluator p2p.AddressDenialEvaluator
+	PreferredPeersHolder   p2p.PreferredPeersHolderHandler
+	PeersList              []string
+	PeersFile              string
+	RefreshInterval        time.Duration
+	MaxRedialBackoff       time.Duration
+}
+
+type staticPeer struct {
+	address     string
+	info        peer.AddrInfo
+	backoff     time.Duration
+	nextAttempt time.Time
+	// markedPreferred is set if the peer was marked as preferred by this discoverer, so only these peers lose
+	// their preferred status when removed from the static peers
+	markedPreferred bool
+}
+
+type staticPeersDiscoverer struct {
+	context              context.Context
+	hostConnManagement   *hostWithConnectionManagement
+	preferredPeersHolder p2p.PreferredPeersHolderHandler
+	configPeersList      []string
+	peersFile            string
+	refreshInterval      time.Duration
+	maxRedialBackoff     time.Duration
+	timeHandler          func() time.Time
+	readFileHandler      func(name string) ([]byte, error)
+	chReconnect          chan struct{}
+
+	mutStatus sync.Mutex
+	started   bool
+
+	mutPeers        sync.RWMutex
+	peers           map[peer.ID]*staticPeer
+	lastFileContent []byte
+}
+
+// NewStaticPeersDiscoverer creates a peer discoverer that keeps connected a fixed set of peers, read from the config
+// and from an optional file. The file is watched and the set of peers is updated each time its content changes.
+// All the static peers are marked as preferred and are redialed with an exponential backoff when disconnected. A
+// removed static peer loses its preferred status only if it was not already a preferred peer
+func NewStaticPeersDiscoverer(args ArgsStaticPeersDiscoverer) (*staticPeersDiscoverer, error) {
+	if check.IfNilReflect(args.Context) {
+		return nil, p2p.ErrNilContext
+	}
+	if check.IfNil(args.Sharder) {
+		return nil, p2p.ErrNilSharder
+	}
+	sharder, ok := args.Sharder.(Sharder)
+	if !ok {
+		return nil, fmt.Errorf("%w for sharder: expected discovery.Sharder type of interface", p2p.ErrWrongTypeAssertion)
+	}
+	if check.IfNil(args.PreferredPeersHolder) {
+		return nil, p2p.ErrNilPreferredPeersHolder
+	}
+	if len(args.PeersList) == 0 && len(args.PeersFile) == 0 {
+		return nil, fmt.Errorf("%w, no static peers list or peers file provided", p2p.ErrInvalidValue)
+	}
+	if args.RefreshInterval < time.Second {
+		return nil, fmt.Errorf("%w, RefreshInterval should have been at least 1 second", p2p.ErrInvalidValue)
+	}
+	if args.MaxRedialBackoff < args.RefreshInterval {
+		return nil, fmt.Errorf("%w, MaxRedialBackoff should have been at least equal to RefreshInterval", p2p.ErrInvalidValue)
+	}
+
+	argsConnectionManagement := ArgsHostWithConnectionManagement{
+		ConnectableHost:        args.Host,
+		Sharder:                sharder,
+		ConnectionsWatcher:     args.ConnectionWatcher,
+		AddressDenialEvaluator: args.AddressDenialEvaluator,
+	}
+	hostConnManagement, err := NewHostWithConnectionManagement(argsConnectionManagement)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range args.PeersList {
+		_, err = hostConnManagement.AddressToPeerInfo(address)
+		if err != nil {
+			return nil, fmt.Errorf("%w for static peer address %s: %s", p2p.ErrInvalidValue, address, err.Error())
+		}
+	}
+
+	return &staticPeersDiscoverer{
+		context:              args.Context,
+		hostConnManagement:   hostConnManagement,
+		preferredPeersHolder: args.PreferredPeersHolder,
+		configPeersList:      args.PeersList,
+		peersFile:            args.PeersFile,
+		refreshInterval:      args.RefreshInterval,
+		maxRedialBackoff:     args.MaxRedialBackoff,
+		timeHandler:          time.Now,
+		readFileHandler:      os.ReadFile,
+		chReconnect:          make(chan struct{}),
+		peers:                make(map[peer.ID]*staticPeer),
+	}, nil
+}
+
+// Bootstrap loads the static peers and starts the process that keeps them connected
+func (spd *staticPeersDiscoverer) Bootstrap() error {
+	spd.mutStatus.Lock()
+	defer spd.mutStatus.Unlock()
+
+	if spd.started {
+		return p2p.ErrPeerDiscoveryProcessAlreadyStarted
+	}
+
+	fileContent, err := spd.readPeersFile()
+	if err != nil {
+		return err
+	}
+
+	spd.mutPeers.Lock()
+	spd.lastFileContent = fileContent
+	spd.mutPeers.Unlock()
+	spd.applyPeers(fileContent)
+
+	spd.started = true
+	go spd.processLoop(spd.context)
+
+	return nil
+}
+
+func (spd *staticPeersDiscoverer) processLoop(ctx context.Context) {
+	spd.connectToPeers(ctx)
+	chTimeRefresh := time.After(spd.refreshInterval)
+
+	for {
+		select {
+		case <-chTimeRefresh:
+			spd.reloadPeersFile()
+			spd.connectToPeers(ctx)
+			chTimeRefresh = time.After(spd.refreshInterval)
+
+		case <-spd.chReconnect:
+			spd.resetBackoffs()
+			spd.connectToPeers(ctx)
+
+		case <-ctx.Done():
+			log.Debug("closing the static peers discovery process")
+			return
+		}
+	}
+}
+
+func (spd *staticPeersDiscoverer) readPeersFile() ([]byte, error) {
+	if len(spd.peersFile) == 0 {
+		return nil, nil
+	}
+
+	return spd.readFileHandler(spd.peersFile)
+}
+
+// reloadPeersFile re-reads the peers file and, if its content changed, updates the static peers
+func (spd *staticPeersDiscoverer) reloadPeersFile() {
+	fileContent, err := spd.readPeersFile()
+	if err != nil {
+		log.Debug("staticPeersDiscoverer: error reading the peers file, keeping the current peers",
+			"file", spd.peersFile, "error", err.Error())
+		return
+	}
+
+	spd.mutPeers.Lock()
+	isUnchanged := bytes.Equal(fileContent, spd.lastFileContent)
+	spd.lastFileContent = fileContent
+	spd.mutPeers.Unlock()
+	if isUnchanged {
+		return
+	}
+
+	log.Debug("staticPeersDiscoverer: peers file changed, reloading the static peers", "file", spd.peersFile)
+	spd.applyPeers(fileContent)
+}
+
+// applyPeers replaces the current static peers with the ones from the config and from the provided file content.
+// The redial state of the peers found in both sets is kept
+func (spd *staticPeersDiscoverer) applyPeers(fileContent []byte) {
+	addresses := append(make([]string, 0, len(spd.configPeersList)), spd.configPeersList...)
+	addresses = append(addresses, parsePeersFile(fileContent)...)
+
+	newPeers := make(map[peer.ID]*staticPeer)
+	for _, address := range addresses {
+		info, err := spd.hostConnManagement.AddressToPeerInfo(address)
+		if err != nil {
+			log.Warn("staticPeersDiscoverer: invalid static peer address", "address", address, "error", err.Error())
+			continue
+		}
+
+		existing, found := newPeers[info.ID]
+		if found {
+			existing.info.Addrs = append(existing.info.Addrs, info.Addrs...)
+			continue
+		}
+
+		newPeers[info.ID] = &staticPeer{
+			address: address,
+			info:    *info,
+		}
+	}
+
+	spd.mutPeers.Lock()
+	defer spd.mutPeers.Unlock()
+
+	for pid := range spd.peers {
+		_, found := newPeers[pid]
+		if found {
+			continue
+		}
+
+		log.Debug("staticPeersDiscoverer: removed static peer", "pid", pid.String())
+		if spd.peers[pid].markedPreferred {
+			spd.preferredPeersHolder.RemovePreferredConnectionAddress(pid.String())
+		}
+		delete(spd.peers, pid)
+	}
+
+	for pid, newPeer := range newPeers {
+		existing, found := spd.peers[pid]
+		if found {
+			existing.address = newPeer.address
+			existing.info = newPeer.info
+			continue
+		}
+
+		newPeer.markedPreferred = spd.markPreferred(pid)
+		spd.peers[pid] = newPeer
+	}
+}
+
+// markPreferred marks the static peer as preferred and returns true if it was not already a preferred peer
+func (spd *staticPeersDiscoverer) markPreferred(pid peer.ID) bool {
+	if spd.preferredPeersHolder.HasPreferredConnectionAddress(pid.String()) {
+		return false
+	}
+
+	err := spd.preferredPeersHolder.AddPreferredConnectionAddress(pid.String())
+	if err != nil {
+		log.Warn("staticPeersDiscoverer: can not mark the static peer as preferred",
+			"pid", pid.String(), "error", err.Error())
+		return false
+	}
+
+	return true
+}
+
+func parsePeersFile(fileContent []byte) []string {
+	addresses := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(fileContent))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, peersFileCommentPrefix) {
+			continue
+		}
+
+		addresses = append(addresses, line)
+	}
+
+	return addresses
+}
+
+// connectToPeers dials all the disconnected static peers whose redial backoff expired
+func (spd *staticPeersDiscoverer) connectToPeers(ctx context.Context) {
+	now := spd.timeHandler()
+
+	spd.mutPeers.RLock()
+	peersToDial := make([]staticPeer, 0, len(spd.peers))
+	for _, sp := range spd.peers {
+		if spd.hostConnManagement.IsConnected(sp.info) {
+			// also covers the connections initiated by the static peers
+			spd.preferredPeersHolder.PutConnectionAddress(core.PeerID(sp.info.ID), sp.address)
+			continue
+		}
+		if now.Before(sp.nextAttempt) {
+			continue
+		}
+
+		peersToDial = append(peersToDial, *sp)
+	}
+	spd.mutPeers.RUnlock()
+
+	for _, sp := range peersToDial {
+		err := spd.connectToPeer(ctx, sp.info)
+		spd.updateRedialState(sp.info.ID, err)
+		if err != nil {
+			log.Debug("staticPeersDiscoverer: error connecting to static peer",
+				"address", sp.address, "error", err.Error())
+			continue
+		}
+
+		spd.preferredPeersHolder.PutConnectionAddress(core.PeerID(sp.info.ID), sp.address)
+	}
+}
+
+func (spd *staticPeersDiscoverer) connectToPeer(ctx context.Context, info peer.AddrInfo) error {
+	ctxConnect, cancel := context.WithTimeout(ctx, staticPeerConnectTimeout)
+	defer cancel()
+
+	return spd.hostConnManagement.Connect(ctxConnect, info)
+}
+
+func (spd *staticPeersDiscoverer) updateRedialState(pid peer.ID, connectErr error) {
+	spd.mutPeers.Lock()
+	defer spd.mutPeers.Unlock()
+
+	sp, found := spd.peers[pid]
+	if !found {
+		// removed meanwhile
+		return
+	}
+
+	if connectErr == nil {
+		sp.backoff = 0
+		sp.nextAttempt = time.Time{}
+		return
+	}
+
+	sp.backoff = spd.computeNextBackoff(sp.backoff)
+	sp.nextAttempt = spd.timeHandler().Add(sp.backoff)
+}
+
+func (spd *staticPeersDiscoverer) computeNextBackoff(backoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return spd.refreshInterval
+	}
+
+	backoff *= 2
+	if backoff > spd.maxRedialBackoff {
+		return spd.maxRedialBackoff
+	}
+
+	return backoff
+}
+
+func (spd *staticPeersDiscoverer) resetBackoffs() {
+	spd.mutPeers.Lock()
+	defer spd.mutPeers.Unlock()
+
+	for _, sp := range spd.peers {
+		sp.backoff = 0
+		sp.nextAttempt = time.Time{}
+	}
+}
+
+// FindPeer returns the addresses of the provided peer, if it is a static peer
+func (spd *staticPeersDiscoverer) FindPeer(_ context.Context, pid peer.ID) (peer.AddrInfo, error) {
+	spd.mutPeers.RLock()
+	defer spd.mutPeers.RUnlock()
+
+	sp, found := spd.peers[pid]
+	if !found {
+		return peer.AddrInfo{}, fmt.Errorf("%w, %s is not a static peer", p2p.ErrPeerAddressesNotFound, pid.String())
+	}
+
+	return sp.info, nil
+}
+
+// Name returns the name of the static peers discovery implementation
+func (spd *staticPeersDiscoverer) Name() string {
+	return staticPeersName
+}
+
+// ReconnectToNetwork will immediately redial all the disconnected static peers, ignoring their backoff
+func (spd *staticPeersDiscoverer) ReconnectToNetwork(_ context.Context) {
+	select {
+	case spd.chReconnect <- struct{}{}:
+	default:
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (spd *staticPeersDiscoverer) IsInterfaceNil() bool {
+	return spd == nil
+}
@@ -0,0 +1,466 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/discovery"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const staticPid1 = "16Uiu2HAkw5SNNtSvH1zJiQ6Gc3WoGNSxiyNueRKe6fuAuh57G3Bk"
+const staticPid2 = "16Uiu2HAkyqtHSEJDkYhVWTtm9j58Mq5xQJgrApBYXMwS6sdamXuE"
+const staticPid3 = "16Uiu2HAm6hPymvkZyFgbvWaVBKhEoPjmXhkV32r9JaFvQ7Rk8ynU"
+const staticAddress1 = "/ip4/10.0.0.1/tcp/10000/p2p/" + staticPid1
+const staticAddress2 = "/ip4/10.0.0.2/tcp/10000/p2p/" + staticPid2
+const staticAddress3 = "/ip4/10.0.0.3/tcp/10000/p2p/" + staticPid3
+
+func decodePid(t *testing.T, pidString string) peer.ID {
+	pid, err := peer.Decode(pidString)
+	require.Nil(t, err)
+
+	return pid
+}
+
+func createMockArgsStaticPeersDiscoverer() discovery.ArgsStaticPeersDiscoverer {
+	return discovery.ArgsStaticPeersDiscoverer{
+		Context: context.Background(),
+		Host: &mock.ConnectableHostStub{
+			NetworkCalled: func() network.Network {
+				return createStubNetwork()
+			},
+		},
+		Sharder:                &mock.KadSharderStub{},
+		ConnectionWatcher:      &mock.ConnectionsWatcherStub{},
+		AddressDenialEvaluator: &mock.AddressDenialEvaluatorStub{},
+		PreferredPeersHolder:   &mock.PeersHolderStub{},
+		PeersList:              []string{staticAddress1},
+		RefreshInterval:        time.Second,
+		MaxRedialBackoff:       time.Second * 8,
+	}
+}
+
+type connectRecorder struct {
+	mut        sync.Mutex
+	connected  map[peer.ID]int
+	connectErr error
+}
+
+func (cr *connectRecorder) connect(_ context.Context, pi peer.AddrInfo) error {
+	cr.mut.Lock()
+	defer cr.mut.Unlock()
+
+	cr.connected[pi.ID]++
+
+	return cr.connectErr
+}
+
+func (cr *connectRecorder) numConnects(pid peer.ID) int {
+	cr.mut.Lock()
+	defer cr.mut.Unlock()
+
+	return cr.connected[pid]
+}
+
+func TestNewStaticPeersDiscoverer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.Context = nil
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("nil sharder should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.Sharder = nil
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.Equal(t, p2p.ErrNilSharder, err)
+	})
+	t.Run("wrong sharder type should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.Sharder = &mock.SharderStub{}
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.True(t, errors.Is(err, p2p.ErrWrongTypeAssertion))
+	})
+	t.Run("nil preferred peers holder should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.PreferredPeersHolder = nil
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.Equal(t, p2p.ErrNilPreferredPeersHolder, err)
+	})
+	t.Run("no peers list and no peers file should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.PeersList = nil
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid refresh interval should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.RefreshInterval = time.Millisecond
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("max redial backoff lower than the refresh interval should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.MaxRedialBackoff = args.RefreshInterval - time.Millisecond
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("nil host should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.Host = nil
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.Equal(t, p2p.ErrNilHost, err)
+	})
+	t.Run("invalid address in the peers list should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.PeersList = []string{staticAddress1, "invalid address"}
+		spd, err := discovery.NewStaticPeersDiscoverer(args)
+
+		assert.True(t, check.IfNil(spd))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		spd, err := discovery.NewStaticPeersDiscoverer(createMockArgsStaticPeersDiscoverer())
+
+		assert.False(t, check.IfNil(spd))
+		assert.Nil(t, err)
+		assert.Equal(t, discovery.StaticPeersName, spd.Name())
+	})
+}
+
+func TestStaticPeersDiscoverer_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unreadable peers file should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsStaticPeersDiscoverer()
+		args.PeersFile = "peers.txt"
+		spd, _ := discovery.NewStaticPeersDiscoverer(args)
+		spd.SetReadFileHandler(func(name string) ([]byte, error) {
+			return nil, expectedErr
+		})
+
+		err := spd.Bootstrap()
+
+		assert.Equal(t, expectedErr, err)
+		assert.Empty(t, spd.StaticPeers())
+	})
+	t.Run("should load the peers, mark them preferred and connect to them", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		args := createMockArgsStaticPeersDiscoverer()
+		args.Context = ctx
+		args.PeersFile = "peers.txt"
+		recorder := &connectRecorder{connected: make(map[peer.ID]int)}
+		args.Host.(*mock.ConnectableHostStub).ConnectCalled = recorder.connect
+		mutPreferred := sync.Mutex{}
+		preferredAddresses := make([]string, 0)
+		putConnections := make(map[core.PeerID]string)
+		args.PreferredPeersHolder = &mock.PeersHolderStub{
+			AddPreferredConnectionAddressCalled: func(connectionAddress string) error {
+				mutPreferred.Lock()
+				preferredAddresses = append(preferredAddresses, connectionAddress)
+				mutPreferred.Unlock()
+
+				return nil
+			},
+			PutConnectionAddressCalled: func(peerID core.PeerID, address string) {
+				mutPreferred.Lock()
+				putConnections[peerID] = address
+				mutPreferred.Unlock()
+			},
+		}
+		spd, _ := discovery.NewStaticPeersDiscoverer(args)
+		spd.SetReadFileHandler(func(name string) ([]byte, error) {
+			assert.Equal(t, "peers.txt", name)
+			return []byte("# sentry peers\n\n  " + staticAddress2 + "  \ninvalid address\n"), nil
+		})
+
+		err := spd.Bootstrap()
+		require.Nil(t, err)
+
+		err = spd.Bootstrap()
+		assert.Equal(t, p2p.ErrPeerDiscoveryProcessAlreadyStarted, err)
+
+		pid1 := decodePid(t, staticPid1)
+		pid2 := decodePid(t, staticPid2)
+		assert.Eventually(t, func() bool {
+			return recorder.numConnects(pid1) == 1 && recorder.numConnects(pid2) == 1
+		}, time.Second, time.Millisecond*10)
+
+		mutPreferred.Lock()
+		defer mutPreferred.Unlock()
+
+		assert.ElementsMatch(t, []string{staticPid1, staticPid2}, preferredAddresses)
+		assert.Equal(t, staticAddress1, putConnections[core.PeerID(pid1)])
+		assert.Equal(t, staticAddress2, putConnections[core.PeerID(pid2)])
+	})
+}
+
+func TestStaticPeersDiscoverer_ConnectToPeersShouldRedialWithExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsStaticPeersDiscoverer()
+	recorder := &connectRecorder{
+		connected:  make(map[peer.ID]int),
+		connectErr: errors.New("connection refused"),
+	}
+	args.Host.(*mock.ConnectableHostStub).ConnectCalled = recorder.connect
+	args.PeersFile = "peers.txt"
+	spd, _ := discovery.NewStaticPeersDiscoverer(args)
+
+	currentTime := time.Unix(1000, 0)
+	spd.SetTimeHandler(func() time.Time {
+		return currentTime
+	})
+	spd.SetReadFileHandler(func(name string) ([]byte, error) {
+		return []byte("# no other static peers"), nil
+	})
+	spd.ReloadPeersFile()
+	require.Equal(t, 1, len(spd.StaticPeers()))
+
+	pid1 := decodePid(t, staticPid1)
+	expectedBackoffs := []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 8}
+	for idx, expectedBackoff := range expectedBackoffs {
+		spd.ConnectToPeers(context.Background())
+		assert.Equal(t, idx+1, recorder.numConnects(pid1))
+		assert.Equal(t, expectedBackoff, spd.StaticPeers()[pid1])
+
+		// before the backoff expires, no redial is done
+		currentTime = currentTime.Add(expectedBackoff - time.Millisecond)
+		spd.ConnectToPeers(context.Background())
+		assert.Equal(t, idx+1, recorder.numConnects(pid1))
+
+		currentTime = currentTime.Add(time.Millisecond)
+	}
+
+	// a successful connection resets the backoff
+	recorder.mut.Lock()
+	recorder.connectErr = nil
+	recorder.mut.Unlock()
+	spd.ConnectToPeers(context.Background())
+	assert.Equal(t, time.Duration(0), spd.StaticPeers()[pid1])
+}
+
+func TestStaticPeersDiscoverer_ConnectToPeersShouldNotDialConnectedPeers(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsStaticPeersDiscoverer()
+	args.Host.(*mock.ConnectableHostStub).NetworkCalled = func() network.Network {
+		return &mock.NetworkStub{
+			ConnectednessCalled: func(id peer.ID) network.Connectedness {
+				return network.Connected
+			},
+		}
+	}
+	args.Host.(*mock.ConnectableHostStub).ConnectCalled = func(ctx context.Context, pi peer.AddrInfo) error {
+		assert.Fail(t, "should have not called connect")
+		return nil
+	}
+	putConnectionCalled := false
+	args.PreferredPeersHolder = &mock.PeersHolderStub{
+		PutConnectionAddressCalled: func(peerID core.PeerID, address string) {
+			putConnectionCalled = true
+			assert.Equal(t, staticAddress1, address)
+		},
+	}
+	args.PeersFile = "peers.txt"
+	spd, _ := discovery.NewStaticPeersDiscoverer(args)
+	spd.SetReadFileHandler(func(name string) ([]byte, error) {
+		return []byte("# no other static peers"), nil
+	})
+	spd.ReloadPeersFile()
+	require.Equal(t, 1, len(spd.StaticPeers()))
+
+	spd.ConnectToPeers(context.Background())
+
+	assert.True(t, putConnectionCalled)
+}
+
+func TestStaticPeersDiscoverer_ReloadPeersFile(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsStaticPeersDiscoverer()
+	args.PeersFile = "peers.txt"
+	removed := make([]string, 0)
+	added := make([]string, 0)
+	args.PreferredPeersHolder = &mock.PeersHolderStub{
+		AddPreferredConnectionAddressCalled: func(connectionAddress string) error {
+			added = append(added, connectionAddress)
+			return nil
+		},
+		RemovePreferredConnectionAddressCalled: func(connectionAddress string) {
+			removed = append(removed, connectionAddress)
+		},
+	}
+	spd, _ := discovery.NewStaticPeersDiscoverer(args)
+
+	fileContent := staticAddress2
+	var readErr error
+	spd.SetReadFileHandler(func(name string) ([]byte, error) {
+		return []byte(fileContent), readErr
+	})
+
+	spd.ReloadPeersFile()
+	pid1 := decodePid(t, staticPid1)
+	pid2 := decodePid(t, staticPid2)
+	pid3 := decodePid(t, staticPid3)
+	assert.Equal(t, 2, len(spd.StaticPeers()))
+	assert.ElementsMatch(t, []string{staticPid1, staticPid2}, added)
+
+	t.Run("unchanged file should not reload", func(t *testing.T) {
+		spd.ReloadPeersFile()
+		assert.Equal(t, 2, len(added))
+		assert.Empty(t, removed)
+	})
+	t.Run("read error should keep the current peers", func(t *testing.T) {
+		readErr = errors.New("file not found")
+		fileContent = ""
+		spd.ReloadPeersFile()
+		readErr = nil
+
+		assert.Equal(t, 2, len(spd.StaticPeers()))
+		assert.Empty(t, removed)
+	})
+	t.Run("changed file should update the peers", func(t *testing.T) {
+		fileContent = staticAddress3
+		spd.ReloadPeersFile()
+
+		staticPeers := spd.StaticPeers()
+		assert.Equal(t, 2, len(staticPeers))
+		assert.Contains(t, staticPeers, pid1) // from config, never removed
+		assert.Contains(t, staticPeers, pid3)
+		assert.NotContains(t, staticPeers, pid2)
+		assert.Equal(t, []string{staticPid2}, removed)
+		assert.Equal(t, staticPid3, added[len(added)-1])
+
+		pi, err := spd.FindPeer(context.Background(), pid3)
+		assert.Nil(t, err)
+		assert.Equal(t, pid3, pi.ID)
+		assert.Equal(t, 1, len(pi.Addrs))
+
+		_, err = spd.FindPeer(context.Background(), pid2)
+		assert.True(t, errors.Is(err, p2p.ErrPeerAddressesNotFound))
+	})
+}
+
+func TestStaticPeersDiscoverer_ReloadPeersFileShouldKeepTheAlreadyPreferredPeers(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsStaticPeersDiscoverer()
+	args.PeersFile = "peers.txt"
+	removed := make([]string, 0)
+	added := make([]string, 0)
+	args.PreferredPeersHolder = &mock.PeersHolderStub{
+		AddPreferredConnectionAddressCalled: func(connectionAddress string) error {
+			added = append(added, connectionAddress)
+			return nil
+		},
+		RemovePreferredConnectionAddressCalled: func(connectionAddress string) {
+			removed = append(removed, connectionAddress)
+		},
+		HasPreferredConnectionAddressCalled: func(connectionAddress string) bool {
+			// the second peer is configured as preferred elsewhere
+			return connectionAddress == staticPid2
+		},
+	}
+	spd, _ := discovery.NewStaticPeersDiscoverer(args)
+
+	fileContent := staticAddress2 + "\n" + staticAddress3
+	spd.SetReadFileHandler(func(name string) ([]byte, error) {
+		return []byte(fileContent), nil
+	})
+
+	spd.ReloadPeersFile()
+	assert.Equal(t, 3, len(spd.StaticPeers()))
+	assert.ElementsMatch(t, []string{staticPid1, staticPid3}, added)
+
+	fileContent = ""
+	spd.ReloadPeersFile()
+	assert.Equal(t, 1, len(spd.StaticPeers()))
+	assert.Equal(t, []string{staticPid3}, removed)
+}
+
+func TestStaticPeersDiscoverer_ReconnectToNetworkShouldRedialIgnoringBackoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	args := createMockArgsStaticPeersDiscoverer()
+	args.Context = ctx
+	args.RefreshInterval = time.Hour
+	args.MaxRedialBackoff = time.Hour
+	recorder := &connectRecorder{
+		connected:  make(map[peer.ID]int),
+		connectErr: errors.New("connection refused"),
+	}
+	args.Host.(*mock.ConnectableHostStub).ConnectCalled = recorder.connect
+	spd, _ := discovery.NewStaticPeersDiscoverer(args)
+
+	err := spd.Bootstrap()
+	require.Nil(t, err)
+
+	pid1 := decodePid(t, staticPid1)
+	assert.Eventually(t, func() bool {
+		return recorder.numConnects(pid1) == 1
+	}, time.Second, time.Millisecond*10)
+
+	assert.Eventually(t, func() bool {
+		spd.ReconnectToNetwork(context.Background())
+		return recorder.numConnects(pid1) >= 2
+	}, time.Second, time.Millisecond*10)
+}
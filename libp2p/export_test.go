@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/throttler"
 	p2p "github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/config"
 	"github.com/multiversx/mx-chain-storage-go/types"
@@ -23,13 +24,45 @@ var AcceptMessagesInAdvanceDuration = acceptMessagesInAdvanceDuration
 var SequenceNumberSize = sequenceNumberSize
 
 const CurrentTopicMessageVersion = currentTopicMessageVersion
+const CompressedTopicMessageVersion = compressedTopicMessageVersion
+const MaxDecompressedPayloadSize = maxDecompressedPayloadSize
+const MaxChunkedPayloadSize = maxChunkedPayloadSize
 const PollWaitForConnectionsInterval = pollWaitForConnectionsInterval
+const GenericRejectionReason = genericRejectionReason
 
 // SetHost -
 func (netMes *networkMessenger) SetHost(newHost ConnectableHost) {
 	netMes.p2pHost = newHost
 }
 
+// CreateMessageBytes -
+func (netMes *networkMessenger) CreateMessageBytes(topic string, buff []byte) ([]byte, error) {
+	return netMes.createMessageBytes(topic, buff, maxSendBuffSize)
+}
+
+// CheckMessageSignature -
+func CheckMessageSignature(signer p2p.SignerVerifier, message *pb.Message) error {
+	return checkMessageSignature(signer, message)
+}
+
+// ReserveBufferedSize -
+func (cs *chunkedSender) ReserveBufferedSize(pid peer.ID, size int) bool {
+	return cs.reserveBufferedSize(pid, size)
+}
+
+// BufferedSize -
+func (cs *chunkedSender) BufferedSize() int {
+	cs.mutBufferedSize.Lock()
+	defer cs.mutBufferedSize.Unlock()
+
+	return cs.bufferedSize
+}
+
+// SetTransfersThrottler -
+func (cs *chunkedSender) SetTransfersThrottler(maxGoRoutines int32) {
+	cs.transfersThrottler, _ = throttler.NewNumGoRoutinesThrottler(maxGoRoutines)
+}
+
 // SetLoadBalancer -
 func (netMes *networkMessenger) SetLoadBalancer(outgoingPLB ChannelLoadBalancer) {
 	netMes.outgoingPLB = outgoingPLB
@@ -99,6 +132,16 @@ func (ds *directSender) Counter() uint64 {
 	return ds.counter
 }
 
+// SetAckThrottler -
+func (ds *directSender) SetAckThrottler(maxGoRoutines int32) {
+	ds.ackThrottler, _ = throttler.NewNumGoRoutinesThrottler(maxGoRoutines)
+}
+
+// SetRequestsThrottler -
+func (rs *requestSender) SetRequestsThrottler(maxGoRoutines int32) {
+	rs.requestsThrottler, _ = throttler.NewNumGoRoutinesThrottler(maxGoRoutines)
+}
+
 // Mutexes -
 func (mh *MutexHolder) Mutexes() types.Cacher {
 	return mh.mutexes
@@ -109,16 +152,25 @@ func (netMes *networkMessenger) SetSignerInDirectSender(signer p2p.SignerVerifie
 	netMes.ds.(*directSender).signer = signer
 }
 
-func (oplb *OutgoingChannelLoadBalancer) Chans() []chan *SendableData {
-	return oplb.chans
-}
-
 func (oplb *OutgoingChannelLoadBalancer) Names() []string {
-	return oplb.names
+	oplb.mut.Lock()
+	defer oplb.mut.Unlock()
+
+	names := make([]string, 0, len(oplb.channels))
+	for _, ch := range oplb.channels {
+		names = append(names, ch.name)
+	}
+
+	return names
 }
 
-func (oplb *OutgoingChannelLoadBalancer) NamesChans() map[string]chan *SendableData {
-	return oplb.namesChans
+func (oplb *OutgoingChannelLoadBalancer) HasChannel(name string) bool {
+	oplb.mut.Lock()
+	defer oplb.mut.Unlock()
+
+	_, found := oplb.namesChannels[name]
+
+	return found
 }
 
 func DefaultSendChannel() string {
@@ -129,8 +181,9 @@ func NewConnectionMonitorWrapper(
 	network network.Network,
 	connMonitor ConnectionMonitor,
 	peerDenialEvaluator p2p.PeerDenialEvaluator,
+	addressDenialEvaluator p2p.AddressDenialEvaluator,
 ) *connectionMonitorWrapper {
-	return newConnectionMonitorWrapper(network, connMonitor, peerDenialEvaluator)
+	return newConnectionMonitorWrapper(network, connMonitor, peerDenialEvaluator, addressDenialEvaluator)
 }
 
 func NewPeersOnChannel(
@@ -160,6 +213,10 @@ func (poc *peersOnChannel) SetTimeHandler(handler func() time.Time) {
 	poc.getTimeHandler = handler
 }
 
+func (poc *peersOnChannel) RemoveTopic(topic string) {
+	poc.removeTopic(topic)
+}
+
 func GetPort(port string, handler func(int) error) (int, error) {
 	return getPort(port, handler)
 }
@@ -192,3 +249,55 @@ func NewUnknownPeerShardResolver() *unknownPeerShardResolver {
 func ParseTransportOptions(configs config.TransportConfig, port int) ([]libp2p.Option, []string, error) {
 	return parseTransportOptions(configs, port)
 }
+
+// NewConnectionGater -
+func NewConnectionGater(addressDenialEvaluator p2p.AddressDenialEvaluator) (*connectionGater, error) {
+	return newConnectionGater(addressDenialEvaluator)
+}
+
+// NewConnectionAgeProvider -
+func NewConnectionAgeProvider(network network.Network) *connectionAgeProvider {
+	return newConnectionAgeProvider(network)
+}
+
+// NewTemporaryConnections -
+func NewTemporaryConnections() *temporaryConnections {
+	return newTemporaryConnections()
+}
+
+// SetTimeHandler -
+func (tc *temporaryConnections) SetTimeHandler(handler func() time.Time) {
+	tc.timeHandler = handler
+}
+
+// Upsert -
+func (tc *temporaryConnections) Upsert(pid peer.ID, ttl time.Duration) {
+	tc.upsert(pid, ttl)
+}
+
+// Has -
+func (tc *temporaryConnections) Has(pid peer.ID) bool {
+	return tc.has(pid)
+}
+
+// Remove -
+func (tc *temporaryConnections) Remove(pid peer.ID) {
+	tc.remove(pid)
+}
+
+// PopExpired -
+func (tc *temporaryConnections) PopExpired() []peer.ID {
+	return tc.popExpired()
+}
+
+// SetTemporaryConnectionsTimeHandler -
+func (netMes *networkMessenger) SetTemporaryConnectionsTimeHandler(handler func() time.Time) {
+	netMes.temporaryConns.mut.Lock()
+	netMes.temporaryConns.timeHandler = handler
+	netMes.temporaryConns.mut.Unlock()
+}
+
+// IsTemporaryConnection -
+func (netMes *networkMessenger) IsTemporaryConnection(pid core.PeerID) bool {
+	return netMes.temporaryConns.has(peer.ID(pid))
+}
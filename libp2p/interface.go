@@ -1,11 +1,19 @@
 package libp2p
 
 import (
+	"context"
+	"io"
+	"net/http"
+
+	pubsubPb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/metrics"
 )
 
 // ConnectionMonitor defines the behavior of a connection monitor
@@ -14,6 +22,7 @@ type ConnectionMonitor interface {
 	IsConnectedToTheNetwork(netw network.Network) bool
 	SetThresholdMinConnectedPeers(thresholdMinConnectedPeers int, netw network.Network)
 	ThresholdMinConnectedPeers() int
+	EvictUnwantedPeers(netw network.Network)
 	Close() error
 	IsInterfaceNil() bool
 }
@@ -24,13 +33,47 @@ type PeerDiscovererWithSharder interface {
 	SetSharder(sharder p2p.Sharder) error
 }
 
+// DirectSendCodec defines the wire format of the messages sent on a direct send protocol
+type DirectSendCodec interface {
+	Encode(msg *pubsubPb.Message) ([]byte, error)
+	Decode(buff []byte) (*pubsubPb.Message, error)
+	IsInterfaceNil() bool
+}
+
+// PeerConnector defines a component able to connect to a peer
+type PeerConnector interface {
+	Connect(ctx context.Context, pi peer.AddrInfo) error
+	IsInterfaceNil() bool
+}
+
+// PeerFinder defines a peer discovery mechanism able to search the addresses of a peer in the network
+type PeerFinder interface {
+	FindPeer(ctx context.Context, pid peer.ID) (peer.AddrInfo, error)
+}
+
+// SeedersStatusProvider defines a peer discovery mechanism able to report the health state of the seeders
+type SeedersStatusProvider interface {
+	SeedersStatus() []p2p.SeederStatus
+}
+
+// EvictionExplainer defines a sharder able to explain its eviction decisions
+type EvictionExplainer interface {
+	ExplainEviction(pidList []peer.ID) p2p.EvictionDecision
+	LastEvictionDecision() p2p.EvictionDecision
+}
+
+// ShardingConfigUpdater defines a sharder able to apply a new sharding config at runtime
+type ShardingConfigUpdater interface {
+	UpdateShardingConfig(cfg config.ShardingConfig) error
+}
+
 type p2pSigner interface {
 	Sign(payload []byte) ([]byte, error)
 	Verify(payload []byte, pid core.PeerID, signature []byte) error
 	SignUsingPrivateKey(skBytes []byte, payload []byte) ([]byte, error)
 }
 
-// SendableData represents the struct used in data throttler implementation
+// SendableData represents the struct used in data throttler implementation. Buff holds the packed topic message
 type SendableData struct {
 	Buff  []byte
 	Topic string
@@ -40,10 +83,37 @@ type SendableData struct {
 
 // ChannelLoadBalancer defines what a load balancer that uses chans should do
 type ChannelLoadBalancer interface {
-	AddChannel(channel string) error
+	AddChannel(channel string, options p2p.OutgoingChannelOptions) error
 	RemoveChannel(channel string) error
-	GetChannelOrDefault(channel string) chan *SendableData
+	Enqueue(channel string, data *SendableData) error
+	EnqueueWithContext(ctx context.Context, channel string, data *SendableData) error
 	CollectOneElementFromChannels() *SendableData
+	ChannelsStatistics() []OutgoingChannelStatistics
+	Close() error
+	IsInterfaceNil() bool
+}
+
+// MetricsRegistry defines a registry that holds the messenger metrics and renders them in the Prometheus text format
+type MetricsRegistry interface {
+	http.Handler
+	AddCounter(name string, help string, value uint64, labels ...metrics.Label)
+	AddGauge(name string, help string, delta float64, labels ...metrics.Label)
+	SetGauge(name string, help string, value float64, labels ...metrics.Label)
+	RegisterCollector(name string, help string, metricType string, collector func() []metrics.Sample) error
+	WritePrometheusText(w io.Writer) error
+	IsInterfaceNil() bool
+}
+
+// AntifloodHandler defines a component able to decide if a received message is within the flooding limits
+type AntifloodHandler interface {
+	CanProcessMessage(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error
 	Close() error
 	IsInterfaceNil() bool
 }
+
+// ConnectionGater defines a libp2p connection gater able to deny the connections using the peer denial evaluator
+type ConnectionGater interface {
+	connmgr.ConnectionGater
+	SetPeerDenialEvaluator(handler p2p.PeerDenialEvaluator) error
+	IsInterfaceNil() bool
+}
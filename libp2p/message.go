@@ -9,10 +9,20 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	p2p "github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/data"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+	compressionFactory "github.com/multiversx/mx-chain-p2p-go/libp2p/compression/factory"
 	"github.com/multiversx/mx-chain-p2p-go/message"
 )
 
-const currentTopicMessageVersion = uint32(1)
+const (
+	// currentTopicMessageVersion is used for the uncompressed messages so that nodes not aware of the compression
+	// can still process them
+	currentTopicMessageVersion = uint32(1)
+	// compressedTopicMessageVersion is used for the messages that carry a compression codec ID
+	compressedTopicMessageVersion = uint32(2)
+	// maxDecompressedPayloadSize is the maximum size a received payload can have after decompression
+	maxDecompressedPayloadSize = compression.MaxDecompressedPayloadSize
+)
 
 // NewMessage returns a new instance of a Message object
 func NewMessage(msg *pubsub.Message, marshalizer p2p.Marshalizer) (*message.Message, error) {
@@ -41,18 +51,17 @@ func NewMessage(msg *pubsub.Message, marshalizer p2p.Marshalizer) (*message.Mess
 		return nil, fmt.Errorf("%w error: %s", p2p.ErrMessageUnmarshalError, err.Error())
 	}
 
-	// TODO change this area when new versions of the message will need to be implemented
-	if topicMessage.Version != currentTopicMessageVersion {
-		return nil, fmt.Errorf("%w, supported %d, got %d",
-			p2p.ErrUnsupportedMessageVersion, currentTopicMessageVersion, topicMessage.Version)
-	}
-
 	if len(topicMessage.SignatureOnPid)+len(topicMessage.Pk) > 0 {
 		return nil, fmt.Errorf("%w for topicMessage.SignatureOnPid and topicMessage.Pk",
 			p2p.ErrUnsupportedFields)
 	}
 
-	newMsg.DataField = topicMessage.Payload
+	payload, err := extractPayload(topicMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	newMsg.DataField = payload
 	newMsg.TimestampField = topicMessage.Timestamp
 
 	id, err := peer.IDFromBytes(newMsg.From())
@@ -63,3 +72,35 @@ func NewMessage(msg *pubsub.Message, marshalizer p2p.Marshalizer) (*message.Mess
 	newMsg.PeerField = core.PeerID(id)
 	return newMsg, nil
 }
+
+func extractPayload(topicMessage *data.TopicMessage) ([]byte, error) {
+	switch topicMessage.Version {
+	case currentTopicMessageVersion:
+		if topicMessage.Compression != compression.NoCompressionID {
+			return nil, fmt.Errorf("%w for topicMessage.Compression on version %d",
+				p2p.ErrUnsupportedFields, topicMessage.Version)
+		}
+
+		return topicMessage.Payload, nil
+	case compressedTopicMessageVersion:
+		compressor, err := compressionFactory.NewCompressorByID(topicMessage.Compression)
+		if err != nil {
+			return nil, fmt.Errorf("%w error: %s", p2p.ErrMessageDecompressionError, err.Error())
+		}
+
+		payload, err := compressor.Decompress(topicMessage.Payload, maxDecompressedPayloadSize)
+		if err != nil {
+			return nil, fmt.Errorf("%w error: %s", p2p.ErrMessageDecompressionError, err.Error())
+		}
+
+		return payload, nil
+	default:
+		if topicMessage.Version > compressedTopicMessageVersion {
+			return nil, fmt.Errorf("%w, supported up to %d, got %d",
+				p2p.ErrNewerMessageVersion, compressedTopicMessageVersion, topicMessage.Version)
+		}
+
+		return nil, fmt.Errorf("%w, supported %d and %d, got %d",
+			p2p.ErrUnsupportedMessageVersion, currentTopicMessageVersion, compressedTopicMessageVersion, topicMessage.Version)
+	}
+}
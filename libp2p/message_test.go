@@ -1,6 +1,7 @@
 package libp2p_test
 
 import (
+	"bytes"
 	"crypto/rand"
 	"errors"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	p2p "github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/data"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
 	"github.com/multiversx/mx-chain-p2p-go/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -141,7 +143,7 @@ func TestMessage_WrongVersionShouldErr(t *testing.T) {
 	marshalizer := &mock.ProtoMarshallerMock{}
 
 	topicMessage := &data.TopicMessage{
-		Version:   libp2p.CurrentTopicMessageVersion + 1,
+		Version:   0,
 		Timestamp: time.Now().Unix(),
 		Payload:   []byte("data"),
 	}
@@ -160,6 +162,31 @@ func TestMessage_WrongVersionShouldErr(t *testing.T) {
 	assert.True(t, errors.Is(err, p2p.ErrUnsupportedMessageVersion))
 }
 
+func TestMessage_NewerVersionShouldErr(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.ProtoMarshallerMock{}
+
+	topicMessage := &data.TopicMessage{
+		Version:   libp2p.CompressedTopicMessageVersion + 1,
+		Timestamp: time.Now().Unix(),
+		Payload:   []byte("data"),
+	}
+	buff, _ := marshalizer.Marshal(topicMessage)
+	topic := "topic"
+	mes := &pb.Message{
+		From:  getRandomID(),
+		Data:  buff,
+		Topic: &topic,
+	}
+
+	pMes := &pubsub.Message{Message: mes}
+	m, err := libp2p.NewMessage(pMes, marshalizer)
+
+	assert.True(t, check.IfNil(m))
+	assert.True(t, errors.Is(err, p2p.ErrNewerMessageVersion))
+}
+
 func TestMessage_PopulatedPkFieldShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -246,3 +273,95 @@ func TestMessage_NilMessage(t *testing.T) {
 	assert.Equal(t, p2p.ErrNilMessage, err)
 	assert.True(t, check.IfNil(m))
 }
+
+func TestMessage_CompressedPayload(t *testing.T) {
+	t.Parallel()
+
+	createPubsubMessage := func(topicMessage *data.TopicMessage) *pubsub.Message {
+		buff, _ := (&mock.ProtoMarshallerMock{}).Marshal(topicMessage)
+		topic := "topic"
+		mes := &pb.Message{
+			From:  getRandomID(),
+			Data:  buff,
+			Topic: &topic,
+		}
+
+		return &pubsub.Message{Message: mes}
+	}
+	payload := bytes.Repeat([]byte("data"), 1000)
+
+	t.Run("compression field on the uncompressed version should error", func(t *testing.T) {
+		t.Parallel()
+
+		topicMessage := &data.TopicMessage{
+			Version:     libp2p.CurrentTopicMessageVersion,
+			Timestamp:   time.Now().Unix(),
+			Payload:     payload,
+			Compression: compression.SnappyCompressionID,
+		}
+
+		m, err := libp2p.NewMessage(createPubsubMessage(topicMessage), &mock.ProtoMarshallerMock{})
+		assert.True(t, check.IfNil(m))
+		assert.True(t, errors.Is(err, p2p.ErrUnsupportedFields))
+	})
+	t.Run("unknown compression should error", func(t *testing.T) {
+		t.Parallel()
+
+		topicMessage := &data.TopicMessage{
+			Version:     libp2p.CompressedTopicMessageVersion,
+			Timestamp:   time.Now().Unix(),
+			Payload:     payload,
+			Compression: 100,
+		}
+
+		m, err := libp2p.NewMessage(createPubsubMessage(topicMessage), &mock.ProtoMarshallerMock{})
+		assert.True(t, check.IfNil(m))
+		assert.True(t, errors.Is(err, p2p.ErrMessageDecompressionError))
+	})
+	t.Run("corrupted compressed payload should error", func(t *testing.T) {
+		t.Parallel()
+
+		topicMessage := &data.TopicMessage{
+			Version:     libp2p.CompressedTopicMessageVersion,
+			Timestamp:   time.Now().Unix(),
+			Payload:     payload,
+			Compression: compression.ZstdCompressionID,
+		}
+
+		m, err := libp2p.NewMessage(createPubsubMessage(topicMessage), &mock.ProtoMarshallerMock{})
+		assert.True(t, check.IfNil(m))
+		assert.True(t, errors.Is(err, p2p.ErrMessageDecompressionError))
+	})
+	t.Run("decompressed payload too large should error", func(t *testing.T) {
+		t.Parallel()
+
+		bomb, _ := compression.NewSnappyCompressor().Compress(make([]byte, libp2p.MaxDecompressedPayloadSize+1))
+		topicMessage := &data.TopicMessage{
+			Version:     libp2p.CompressedTopicMessageVersion,
+			Timestamp:   time.Now().Unix(),
+			Payload:     bomb,
+			Compression: compression.SnappyCompressionID,
+		}
+
+		m, err := libp2p.NewMessage(createPubsubMessage(topicMessage), &mock.ProtoMarshallerMock{})
+		assert.True(t, check.IfNil(m))
+		assert.True(t, errors.Is(err, p2p.ErrMessageDecompressionError))
+		assert.Contains(t, err.Error(), compression.ErrDecompressedSizeTooLarge.Error())
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		zc, _ := compression.NewZstdCompressor()
+		compressed, _ := zc.Compress(payload)
+		topicMessage := &data.TopicMessage{
+			Version:     libp2p.CompressedTopicMessageVersion,
+			Timestamp:   time.Now().Unix(),
+			Payload:     compressed,
+			Compression: compression.ZstdCompressionID,
+		}
+
+		m, err := libp2p.NewMessage(createPubsubMessage(topicMessage), &mock.ProtoMarshallerMock{})
+		require.Nil(t, err)
+		assert.Equal(t, payload, m.Data())
+	})
+}
@@ -9,8 +9,10 @@ import (
 
 // Connections is a metric that counts connections and disconnections done by the host implementation
 type Connections struct {
-	numConnections    uint32
-	numDisconnections uint32
+	numConnections         uint32
+	numDisconnections      uint32
+	numTotalConnections    uint64
+	numTotalDisconnections uint64
 }
 
 // NewConnections returns a new connsDisconnsMetric instance
@@ -30,11 +32,13 @@ func (conns *Connections) ListenClose(network.Network, multiaddr.Multiaddr) {}
 // Connected is called when a connection opened. It increments the numConnections counter
 func (conns *Connections) Connected(network.Network, network.Conn) {
 	atomic.AddUint32(&conns.numConnections, 1)
+	atomic.AddUint64(&conns.numTotalConnections, 1)
 }
 
 // Disconnected is called when a connection closed it increments the numDisconnections counter
 func (conns *Connections) Disconnected(network.Network, network.Conn) {
 	atomic.AddUint32(&conns.numDisconnections, 1)
+	atomic.AddUint64(&conns.numTotalDisconnections, 1)
 }
 
 // ResetNumConnections resets the numConnections counter returning the previous value
@@ -46,3 +50,15 @@ func (conns *Connections) ResetNumConnections() uint32 {
 func (conns *Connections) ResetNumDisconnections() uint32 {
 	return atomic.SwapUint32(&conns.numDisconnections, 0)
 }
+
+// NumTotalConnections returns the number of connections since the instance was created. The value is not affected
+// by ResetNumConnections
+func (conns *Connections) NumTotalConnections() uint64 {
+	return atomic.LoadUint64(&conns.numTotalConnections)
+}
+
+// NumTotalDisconnections returns the number of disconnections since the instance was created. The value is not
+// affected by ResetNumDisconnections
+func (conns *Connections) NumTotalDisconnections() uint64 {
+	return atomic.LoadUint64(&conns.numTotalDisconnections)
+}
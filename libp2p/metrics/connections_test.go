@@ -52,3 +52,19 @@ func TestConnsDisconnsMetric_ResetNumDisconnectionsShouldWork(t *testing.T) {
 	existing = cdm.ResetNumDisconnections()
 	assert.Equal(t, uint32(0), existing)
 }
+
+func TestConnections_TotalCountersShouldNotBeReset(t *testing.T) {
+	t.Parallel()
+
+	cdm := metrics.NewConnections()
+
+	cdm.Connected(nil, nil)
+	cdm.Connected(nil, nil)
+	cdm.Disconnected(nil, nil)
+	_ = cdm.ResetNumConnections()
+	_ = cdm.ResetNumDisconnections()
+	cdm.Connected(nil, nil)
+
+	assert.Equal(t, uint64(3), cdm.NumTotalConnections())
+	assert.Equal(t, uint64(1), cdm.NumTotalDisconnections())
+}
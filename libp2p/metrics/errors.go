@@ -4,3 +4,21 @@ import "errors"
 
 // ErrInvalidValueForTimeToLiveParam signals that an invalid value for the time-to-live parameter was provided
 var ErrInvalidValueForTimeToLiveParam = errors.New("invalid value for the time-to-live parameter")
+
+// ErrNilMetricsCollector signals that a nil metrics collector was provided
+var ErrNilMetricsCollector = errors.New("nil metrics collector")
+
+// ErrInvalidMetricType signals that an invalid metric type was provided
+var ErrInvalidMetricType = errors.New("invalid metric type")
+
+// ErrInvalidMetricName signals that an invalid metric name was provided
+var ErrInvalidMetricName = errors.New("invalid metric name")
+
+// ErrInvalidLabelName signals that an invalid label name was provided
+var ErrInvalidLabelName = errors.New("invalid label name")
+
+// ErrMetricAlreadyRegistered signals that a metric with the same name was already registered
+var ErrMetricAlreadyRegistered = errors.New("metric already registered")
+
+// ErrMetricTypeMismatch signals that a metric was updated using a different type than the one it was created with
+var ErrMetricTypeMismatch = errors.New("metric type mismatch")
@@ -0,0 +1,273 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// CounterType is the metric type for the values that can only increase
+	CounterType = "counter"
+	// GaugeType is the metric type for the values that can increase and decrease
+	GaugeType = "gauge"
+
+	contentTypePrometheusText = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+var metricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Label is a name-value pair that identifies a sample of a metric
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample holds one value of a metric, identified by its labels
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+type family struct {
+	help       string
+	metricType string
+	samples    map[string]*Sample
+	collector  func() []Sample
+}
+
+type registry struct {
+	mut      sync.RWMutex
+	families map[string]*family
+}
+
+// NewRegistry creates a new metrics registry able to render its content in the Prometheus text format
+func NewRegistry() *registry {
+	return &registry{
+		families: make(map[string]*family),
+	}
+}
+
+// AddCounter adds the provided value to the counter identified by the name and the labels
+func (reg *registry) AddCounter(name string, help string, value uint64, labels ...Label) {
+	reg.updateSample(name, help, CounterType, labels, func(sample *Sample) {
+		sample.Value += float64(value)
+	})
+}
+
+// AddGauge adds the provided delta (that can also be negative) to the gauge identified by the name and the labels
+func (reg *registry) AddGauge(name string, help string, delta float64, labels ...Label) {
+	reg.updateSample(name, help, GaugeType, labels, func(sample *Sample) {
+		sample.Value += delta
+	})
+}
+
+// SetGauge sets the value of the gauge identified by the name and the labels
+func (reg *registry) SetGauge(name string, help string, value float64, labels ...Label) {
+	reg.updateSample(name, help, GaugeType, labels, func(sample *Sample) {
+		sample.Value = value
+	})
+}
+
+func (reg *registry) updateSample(name string, help string, metricType string, labels []Label, update func(sample *Sample)) {
+	err := checkMetric(name, labels)
+	if err != nil {
+		log.Trace("registry.updateSample", "metric", name, "error", err)
+		return
+	}
+
+	reg.mut.Lock()
+	defer reg.mut.Unlock()
+
+	fam, found := reg.families[name]
+	if !found {
+		fam = &family{
+			help:       help,
+			metricType: metricType,
+			samples:    make(map[string]*Sample),
+		}
+		reg.families[name] = fam
+	}
+	if fam.metricType != metricType || fam.collector != nil {
+		log.Trace("registry.updateSample", "metric", name, "error", ErrMetricTypeMismatch)
+		return
+	}
+
+	key := labelsToString(labels)
+	sample, found := fam.samples[key]
+	if !found {
+		sample = &Sample{
+			Labels: copyLabels(labels),
+		}
+		fam.samples[key] = sample
+	}
+
+	update(sample)
+}
+
+// RegisterCollector registers a function that will be called each time the metrics are rendered. It should be used
+// for the metrics that are computed from the state of other components
+func (reg *registry) RegisterCollector(name string, help string, metricType string, collector func() []Sample) error {
+	if collector == nil {
+		return ErrNilMetricsCollector
+	}
+	if metricType != CounterType && metricType != GaugeType {
+		return fmt.Errorf("%w: %s", ErrInvalidMetricType, metricType)
+	}
+	err := checkMetric(name, nil)
+	if err != nil {
+		return err
+	}
+
+	reg.mut.Lock()
+	defer reg.mut.Unlock()
+
+	_, found := reg.families[name]
+	if found {
+		return fmt.Errorf("%w: %s", ErrMetricAlreadyRegistered, name)
+	}
+
+	reg.families[name] = &family{
+		help:       help,
+		metricType: metricType,
+		collector:  collector,
+	}
+
+	return nil
+}
+
+// WritePrometheusText writes all the metrics in the Prometheus text exposition format
+func (reg *registry) WritePrometheusText(w io.Writer) error {
+	reg.mut.RLock()
+	names := make([]string, 0, len(reg.families))
+	families := make(map[string]*family, len(reg.families))
+	samples := make(map[string][]Sample, len(reg.families))
+	for name, fam := range reg.families {
+		names = append(names, name)
+		families[name] = fam
+		if fam.collector == nil {
+			samples[name] = copySamples(fam.samples)
+		}
+	}
+	reg.mut.RUnlock()
+
+	sort.Strings(names)
+
+	bufw := bufio.NewWriter(w)
+	for _, name := range names {
+		fam := families[name]
+		famSamples, found := samples[name]
+		if !found {
+			// collectors are called outside the registry's mutex as they might call other components
+			famSamples = fam.collector()
+		}
+
+		writeFamily(bufw, name, fam, famSamples)
+	}
+
+	return bufw.Flush()
+}
+
+func writeFamily(w *bufio.Writer, name string, fam *family, samples []Sample) {
+	sort.Slice(samples, func(i, j int) bool {
+		return labelsToString(samples[i].Labels) < labelsToString(samples[j].Labels)
+	})
+
+	if len(fam.help) > 0 {
+		_, _ = fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(fam.help))
+	}
+	_, _ = fmt.Fprintf(w, "# TYPE %s %s\n", name, fam.metricType)
+	for _, sample := range samples {
+		err := checkMetric(name, sample.Labels)
+		if err != nil {
+			log.Trace("registry.writeFamily", "metric", name, "error", err)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "%s%s %s\n", name, labelsToString(sample.Labels), formatValue(sample.Value))
+	}
+}
+
+// ServeHTTP renders the metrics in the Prometheus text format
+func (reg *registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", contentTypePrometheusText)
+
+	err := reg.WritePrometheusText(w)
+	if err != nil {
+		log.Debug("registry.ServeHTTP", "error", err)
+	}
+}
+
+func checkMetric(name string, labels []Label) error {
+	if !metricNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %s", ErrInvalidMetricName, name)
+	}
+	for _, label := range labels {
+		if !labelNameRegex.MatchString(label.Name) {
+			return fmt.Errorf("%w: %s", ErrInvalidLabelName, label.Name)
+		}
+	}
+
+	return nil
+}
+
+func labelsToString(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	sorted := copyLabels(labels)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	parts := make([]string, 0, len(sorted))
+	for _, label := range sorted {
+		parts = append(parts, fmt.Sprintf("%s=\"%s\"", label.Name, escapeLabelValue(label.Value)))
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func copyLabels(labels []Label) []Label {
+	result := make([]Label, len(labels))
+	copy(result, labels)
+
+	return result
+}
+
+func copySamples(samples map[string]*Sample) []Sample {
+	result := make([]Sample, 0, len(samples))
+	for _, sample := range samples {
+		result = append(result, *sample)
+	}
+
+	return result
+}
+
+func escapeHelp(help string) string {
+	help = strings.ReplaceAll(help, `\`, `\\`)
+	return strings.ReplaceAll(help, "\n", `\n`)
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (reg *registry) IsInterfaceNil() bool {
+	return reg == nil
+}
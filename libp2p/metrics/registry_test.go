@@ -0,0 +1,176 @@
+package metrics_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRegistry(t *testing.T) {
+	t.Parallel()
+
+	reg := metrics.NewRegistry()
+	assert.False(t, check.IfNil(reg))
+
+	buff := &bytes.Buffer{}
+	err := reg.WritePrometheusText(buff)
+	assert.Nil(t, err)
+	assert.Empty(t, buff.String())
+}
+
+func TestRegistry_AddCounter(t *testing.T) {
+	t.Parallel()
+
+	reg := metrics.NewRegistry()
+	reg.AddCounter("p2p_messages_total", "number of messages", 2, metrics.Label{Name: "topic", Value: "b"})
+	reg.AddCounter("p2p_messages_total", "number of messages", 3, metrics.Label{Name: "topic", Value: "a"})
+	reg.AddCounter("p2p_messages_total", "number of messages", 1, metrics.Label{Name: "topic", Value: "b"})
+
+	buff := &bytes.Buffer{}
+	err := reg.WritePrometheusText(buff)
+	assert.Nil(t, err)
+
+	expected := "# HELP p2p_messages_total number of messages\n" +
+		"# TYPE p2p_messages_total counter\n" +
+		"p2p_messages_total{topic=\"a\"} 3\n" +
+		"p2p_messages_total{topic=\"b\"} 3\n"
+	assert.Equal(t, expected, buff.String())
+}
+
+func TestRegistry_Gauges(t *testing.T) {
+	t.Parallel()
+
+	reg := metrics.NewRegistry()
+	reg.AddGauge("queue_depth", "", 3)
+	reg.AddGauge("queue_depth", "", -1)
+	reg.SetGauge("peers", "connected peers", 7, metrics.Label{Name: "b", Value: "2"}, metrics.Label{Name: "a", Value: "1"})
+
+	buff := &bytes.Buffer{}
+	_ = reg.WritePrometheusText(buff)
+
+	expected := "# HELP peers connected peers\n" +
+		"# TYPE peers gauge\n" +
+		"peers{a=\"1\",b=\"2\"} 7\n" +
+		"# TYPE queue_depth gauge\n" +
+		"queue_depth 2\n"
+	assert.Equal(t, expected, buff.String())
+}
+
+func TestRegistry_InvalidUpdatesShouldBeIgnored(t *testing.T) {
+	t.Parallel()
+
+	reg := metrics.NewRegistry()
+	reg.AddCounter("invalid name", "", 1)
+	reg.AddCounter("metric", "", 1, metrics.Label{Name: "invalid-label", Value: "value"})
+	reg.AddCounter("metric", "", 1)
+	reg.SetGauge("metric", "", 10)
+
+	buff := &bytes.Buffer{}
+	_ = reg.WritePrometheusText(buff)
+
+	expected := "# TYPE metric counter\n" +
+		"metric 1\n"
+	assert.Equal(t, expected, buff.String())
+}
+
+func TestRegistry_LabelValuesShouldBeEscaped(t *testing.T) {
+	t.Parallel()
+
+	reg := metrics.NewRegistry()
+	reg.AddCounter("metric", "help with \\ and\nnew line", 1, metrics.Label{Name: "topic", Value: "a\"b\\c\nd"})
+
+	buff := &bytes.Buffer{}
+	_ = reg.WritePrometheusText(buff)
+
+	expected := "# HELP metric help with \\\\ and\\nnew line\n" +
+		"# TYPE metric counter\n" +
+		"metric{topic=\"a\\\"b\\\\c\\nd\"} 1\n"
+	assert.Equal(t, expected, buff.String())
+}
+
+func TestRegistry_RegisterCollector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil collector should error", func(t *testing.T) {
+		t.Parallel()
+
+		reg := metrics.NewRegistry()
+		err := reg.RegisterCollector("metric", "", metrics.GaugeType, nil)
+		assert.Equal(t, metrics.ErrNilMetricsCollector, err)
+	})
+	t.Run("invalid metric type should error", func(t *testing.T) {
+		t.Parallel()
+
+		reg := metrics.NewRegistry()
+		err := reg.RegisterCollector("metric", "", "histogram", func() []metrics.Sample {
+			return nil
+		})
+		assert.True(t, errors.Is(err, metrics.ErrInvalidMetricType))
+	})
+	t.Run("invalid metric name should error", func(t *testing.T) {
+		t.Parallel()
+
+		reg := metrics.NewRegistry()
+		err := reg.RegisterCollector("0metric", "", metrics.GaugeType, func() []metrics.Sample {
+			return nil
+		})
+		assert.True(t, errors.Is(err, metrics.ErrInvalidMetricName))
+	})
+	t.Run("already registered should error", func(t *testing.T) {
+		t.Parallel()
+
+		reg := metrics.NewRegistry()
+		reg.AddCounter("metric", "", 1)
+		err := reg.RegisterCollector("metric", "", metrics.CounterType, func() []metrics.Sample {
+			return nil
+		})
+		assert.True(t, errors.Is(err, metrics.ErrMetricAlreadyRegistered))
+	})
+	t.Run("should call the collector on each render", func(t *testing.T) {
+		t.Parallel()
+
+		reg := metrics.NewRegistry()
+		numCalls := 0
+		err := reg.RegisterCollector("connected_peers", "number of peers", metrics.GaugeType, func() []metrics.Sample {
+			numCalls++
+			return []metrics.Sample{
+				{
+					Labels: []metrics.Label{{Name: "category", Value: "validators"}},
+					Value:  float64(numCalls),
+				},
+			}
+		})
+		assert.Nil(t, err)
+
+		buff := &bytes.Buffer{}
+		_ = reg.WritePrometheusText(buff)
+		buff.Reset()
+		_ = reg.WritePrometheusText(buff)
+
+		expected := "# HELP connected_peers number of peers\n" +
+			"# TYPE connected_peers gauge\n" +
+			"connected_peers{category=\"validators\"} 2\n"
+		assert.Equal(t, expected, buff.String())
+		assert.Equal(t, 2, numCalls)
+	})
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	reg := metrics.NewRegistry()
+	reg.AddCounter("metric", "", 5)
+
+	recorder := httptest.NewRecorder()
+	reg.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+	assert.Equal(t, "# TYPE metric counter\nmetric 5\n", recorder.Body.String())
+}
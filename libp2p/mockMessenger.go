@@ -5,6 +5,7 @@ import (
 
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/addressDenial"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/crypto"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/metrics/factory"
 )
@@ -36,12 +37,25 @@ func NewMockMessenger(
 		return nil, err
 	}
 
+	// the mocknet hosts can not be configured with a connection gater, the instance only stores the denial settings
+	addressDenialEvaluator, err := addressDenial.NewAddressDenialEvaluator(args.P2pConfig.AddressDenial, args.P2pConfig.ConnectionGater)
+	if err != nil {
+		return nil, err
+	}
+
+	connGater, err := newConnectionGater(addressDenialEvaluator)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	p2pNode := &networkMessenger{
-		p2pSigner:  signer,
-		p2pHost:    NewConnectableHost(h),
-		ctx:        ctx,
-		cancelFunc: cancelFunc,
+		p2pSigner:     signer,
+		p2pHost:       NewConnectableHost(h),
+		ctx:           ctx,
+		cancelFunc:    cancelFunc,
+		connGater:     connGater,
+		addressDenial: addressDenialEvaluator,
 	}
 	p2pNode.printConnectionsWatcher, err = factory.NewConnectionsWatcher(args.ConnectionWatcherType, ttlConnectionsWatcher)
 	if err != nil {
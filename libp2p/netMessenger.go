@@ -3,7 +3,9 @@ package libp2p
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync"
@@ -30,9 +32,14 @@ import (
 	"github.com/multiversx/mx-chain-p2p-go/config"
 	"github.com/multiversx/mx-chain-p2p-go/data"
 	"github.com/multiversx/mx-chain-p2p-go/debug"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/addressDenial"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/antiflood"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/compression"
+	compressionFactory "github.com/multiversx/mx-chain-p2p-go/libp2p/compression/factory"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/connectionMonitor"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/crypto"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/disabled"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/discovery"
 	discoveryFactory "github.com/multiversx/mx-chain-p2p-go/libp2p/discovery/factory"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/metrics"
 	metricsFactory "github.com/multiversx/mx-chain-p2p-go/libp2p/metrics/factory"
@@ -42,8 +49,19 @@ import (
 const (
 	// DirectSendID represents the protocol ID for sending and receiving direct P2P messages
 	DirectSendID = protocol.ID("/erd/directsend/1.0.0")
+	// CompressedDirectSendID represents the protocol ID for sending and receiving compressed direct P2P messages
+	CompressedDirectSendID = protocol.ID("/erd/directsend/2.0.0")
+	// RequestResponseID represents the protocol ID for sending requests and receiving their responses
+	RequestResponseID = protocol.ID("/erd/requestresponse/1.0.0")
+	// ChunkedSendID represents the protocol ID for sending and receiving large direct P2P messages split in chunks
+	ChunkedSendID = protocol.ID("/erd/chunkedsend/1.0.0")
+	// AcknowledgedDirectSendID represents the protocol ID for sending direct P2P messages and receiving the
+	// delivery status from the remote peer
+	AcknowledgedDirectSendID = protocol.ID("/erd/ackdirectsend/1.0.0")
 
 	durationBetweenSends            = time.Microsecond * 10
+	temporaryConnectionTTL          = time.Minute
+	minTemporaryConnectionTTL       = time.Second * 10
 	durationCheckConnections        = time.Second
 	refreshPeersOnTopic             = time.Second * 3
 	ttlPeersOnTopic                 = time.Second * 10
@@ -52,6 +70,7 @@ const (
 	acceptMessagesInAdvanceDuration = 20 * time.Second // we are accepting the messages with timestamp in the future only for this delta
 	pollWaitForConnectionsInterval  = time.Second
 	broadcastGoRoutines             = 1000
+	streamHandlerGoRoutines         = 1000
 	timeBetweenPeerPrints           = time.Second * 20
 	timeBetweenExternalLoggersCheck = time.Second * 20
 	minRangePortValue               = 1025
@@ -61,6 +80,12 @@ const (
 
 	baseErrorSuffix      = "when creating a new network messenger"
 	pubSubMaxMessageSize = 1 << 21 // 2 MB
+
+	chunkSize                     = 1 << 20 // 1 MB
+	maxChunkedPayloadSize         = 1 << 26 // 64 MB
+	maxChunkedBufferedSize        = 1 << 28 // 256 MB
+	maxChunkedBufferedSizePerPeer = 1 << 27 // 128 MB
+	chunkedReassemblyTimeout      = time.Minute
 )
 
 type messageSigningConfig bool
@@ -96,21 +121,32 @@ type networkMessenger struct {
 	port       int
 	pb         *pubsub.PubSub
 	ds         p2p.DirectSender
+	rs         p2p.RequestSender
+	cs         p2p.ChunkedSender
 	// TODO refactor this (connMonitor & connMonitorWrapper)
 	connMonitor             ConnectionMonitor
 	connMonitorWrapper      p2p.ConnectionMonitorWrapper
+	connGater               ConnectionGater
+	addressDenial           p2p.AddressDenialEvaluator
+	shardingType            string
 	peerDiscoverer          p2p.PeerDiscoverer
 	sharder                 p2p.Sharder
+	peerConnector           PeerConnector
+	temporaryConns          *temporaryConnections
 	peerShardResolver       p2p.PeerShardResolver
 	mutPeerResolver         sync.RWMutex
 	mutTopics               sync.RWMutex
 	processors              map[string]*topicProcessors
 	topics                  map[string]*pubsub.Topic
 	subscriptions           map[string]*pubsub.Subscription
+	topicCompressors        map[string]p2p.Compressor
+	topicCompressionEnabled bool
 	outgoingPLB             ChannelLoadBalancer
 	poc                     *peersOnChannel
 	goRoutinesThrottler     *throttler.NumGoRoutinesThrottler
 	connectionsMetric       *metrics.Connections
+	metricsRegistry         MetricsRegistry
+	antiflood               AntifloodHandler
 	debugger                p2p.Debugger
 	marshalizer             p2p.Marshalizer
 	syncTimer               p2p.SyncTimer
@@ -119,6 +155,8 @@ type networkMessenger struct {
 	peersRatingHandler      p2p.PeersRatingHandler
 	mutPeerTopicNotifiers   sync.RWMutex
 	peerTopicNotifiers      []p2p.PeerTopicNotifier
+	mutRequestHandlers      sync.RWMutex
+	requestHandlers         map[string]p2p.RequestHandler
 }
 
 // ArgsNetworkMessenger defines the options used to create a p2p wrapper
@@ -204,6 +242,16 @@ func constructNode(
 		return nil, err
 	}
 
+	addressDenialEvaluator, err := addressDenial.NewAddressDenialEvaluator(args.P2pConfig.AddressDenial, args.P2pConfig.ConnectionGater)
+	if err != nil {
+		return nil, err
+	}
+
+	connGater, err := newConnectionGater(addressDenialEvaluator)
+	if err != nil {
+		return nil, err
+	}
+
 	options := []libp2p.Option{
 		libp2p.ListenAddrStrings(addresses...),
 		libp2p.Identity(p2pPrivateKey),
@@ -212,6 +260,7 @@ func constructNode(
 		// we need to disable relay option in order to save the node's bandwidth as much as possible
 		libp2p.DisableRelay(),
 		libp2p.NATPortMap(),
+		libp2p.ConnectionGater(connGater),
 	}
 	options = append(options, transportOptions...)
 
@@ -241,6 +290,8 @@ func constructNode(
 		printConnectionsWatcher: connWatcher,
 		peersRatingHandler:      args.PeersRatingHandler,
 		peerTopicNotifiers:      make([]p2p.PeerTopicNotifier, 0),
+		connGater:               connGater,
+		addressDenial:           addressDenialEvaluator,
 	}
 
 	return p2pNode, nil
@@ -352,6 +403,9 @@ func addComponentsToNode(
 	p2pNode.processors = make(map[string]*topicProcessors)
 	p2pNode.topics = make(map[string]*pubsub.Topic)
 	p2pNode.subscriptions = make(map[string]*pubsub.Subscription)
+	p2pNode.topicCompressors = make(map[string]p2p.Compressor)
+	p2pNode.topicCompressionEnabled = args.P2pConfig.TopicCompression.Enabled
+	p2pNode.requestHandlers = make(map[string]p2p.RequestHandler)
 	p2pNode.outgoingPLB = NewOutgoingChannelLoadBalancer()
 	p2pNode.peerShardResolver = &unknownPeerShardResolver{}
 	p2pNode.marshalizer = args.Marshalizer
@@ -375,14 +429,50 @@ func addComponentsToNode(
 		return err
 	}
 
+	err = p2pNode.createPeerConnector()
+	if err != nil {
+		return err
+	}
+
 	err = p2pNode.createConnectionMonitor(args.P2pConfig)
 	if err != nil {
 		return err
 	}
 
+	err = p2pNode.createAntiflood(args.P2pConfig)
+	if err != nil {
+		return err
+	}
+
 	p2pNode.createConnectionsMetric()
 
-	p2pNode.ds, err = NewDirectSender(p2pNode.ctx, p2pNode.p2pHost, p2pNode.directMessageHandler, p2pNode)
+	err = p2pNode.createMetricsRegistry()
+	if err != nil {
+		return err
+	}
+
+	err = p2pNode.createDirectSender(args.P2pConfig)
+	if err != nil {
+		return err
+	}
+
+	p2pNode.rs, err = NewRequestSender(p2pNode.ctx, p2pNode.p2pHost, p2pNode.requestMessageHandler, p2pNode)
+	if err != nil {
+		return err
+	}
+
+	argsChunkedSender := ArgsChunkedSender{
+		Ctx:                    p2pNode.ctx,
+		Host:                   p2pNode.p2pHost,
+		MessageHandler:         p2pNode.directMessageHandler,
+		Signer:                 p2pNode,
+		ChunkSize:              chunkSize,
+		MaxPayloadSize:         maxChunkedPayloadSize + messageHeader,
+		MaxBufferedSize:        maxChunkedBufferedSize,
+		MaxBufferedSizePerPeer: maxChunkedBufferedSizePerPeer,
+		ReassemblyTimeout:      chunkedReassemblyTimeout,
+	}
+	p2pNode.cs, err = NewChunkedSender(argsChunkedSender)
 	if err != nil {
 		return err
 	}
@@ -450,12 +540,7 @@ func (netMes *networkMessenger) createPubSub(messageSigning messageSigningConfig
 				continue
 			}
 
-			packedSendableDataBuff := netMes.createMessageBytes(sendableData.Buff)
-			if len(packedSendableDataBuff) == 0 {
-				continue
-			}
-
-			errPublish := netMes.publish(topic, sendableData, packedSendableDataBuff)
+			errPublish := netMes.publish(topic, sendableData)
 			if errPublish != nil {
 				log.Trace("error sending data", "error", errPublish)
 			}
@@ -475,43 +560,65 @@ func (netMes *networkMessenger) newPeerFound(pid peer.ID, topic string) bool {
 	return true
 }
 
-func (netMes *networkMessenger) publish(topic *pubsub.Topic, data *SendableData, packedSendableDataBuff []byte) error {
+func (netMes *networkMessenger) publish(topic *pubsub.Topic, data *SendableData) error {
 	options := make([]pubsub.PubOpt, 0, 1)
 
 	if data.Sk != nil {
 		options = append(options, pubsub.WithSecretKeyAndPeerId(data.Sk, data.ID))
 	}
 
-	return topic.Publish(netMes.ctx, packedSendableDataBuff, options...)
+	return topic.Publish(netMes.ctx, data.Buff, options...)
 }
 
-func (netMes *networkMessenger) createMessageBytes(buff []byte) []byte {
+// createMessageBytes packs the buffer in a topic message. The payload is compressed if the topic uses compression,
+// otherwise the uncompressed version is used
+func (netMes *networkMessenger) createMessageBytes(topic string, buff []byte, maxPayloadSize int) ([]byte, error) {
 	message := &data.TopicMessage{
 		Version:   currentTopicMessageVersion,
 		Payload:   buff,
 		Timestamp: netMes.syncTimer.CurrentTime().Unix(),
 	}
 
-	buffToSend, errMarshal := netMes.marshalizer.Marshal(message)
-	if errMarshal != nil {
-		log.Warn("error sending data", "error", errMarshal)
-		return nil
+	compressor := netMes.getTopicCompressor(topic)
+	if !check.IfNil(compressor) {
+		compressedBuff, err := compressor.Compress(buff)
+		if err != nil {
+			return nil, fmt.Errorf("%w while compressing data with %s", err, compressor.Name())
+		}
+
+		message.Version = compressedTopicMessageVersion
+		message.Compression = compressor.ID()
+		message.Payload = compressedBuff
+	}
+
+	if len(message.Payload) > maxPayloadSize {
+		return nil, fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(message.Payload), maxPayloadSize)
 	}
 
-	return buffToSend
+	return netMes.marshalizer.Marshal(message)
+}
+
+func (netMes *networkMessenger) getTopicCompressor(topic string) p2p.Compressor {
+	netMes.mutTopics.RLock()
+	defer netMes.mutTopics.RUnlock()
+
+	return netMes.topicCompressors[topic]
 }
 
 func (netMes *networkMessenger) createSharder(argsNetMes ArgsNetworkMessenger) error {
 	args := factory.ArgsSharderFactory{
-		PeerShardResolver:    &unknownPeerShardResolver{},
-		Pid:                  netMes.p2pHost.ID(),
-		P2pConfig:            argsNetMes.P2pConfig,
-		PreferredPeersHolder: netMes.preferredPeersHolder,
-		NodeOperationMode:    argsNetMes.NodeOperationMode,
+		PeerShardResolver:     &unknownPeerShardResolver{},
+		Pid:                   netMes.p2pHost.ID(),
+		P2pConfig:             argsNetMes.P2pConfig,
+		PreferredPeersHolder:  netMes.preferredPeersHolder,
+		NodeOperationMode:     argsNetMes.NodeOperationMode,
+		PeersRatingHandler:    netMes.peersRatingHandler,
+		ConnectionAgeProvider: newConnectionAgeProvider(netMes.p2pHost.Network()),
 	}
 
 	var err error
 	netMes.sharder, err = factory.NewSharder(args)
+	netMes.shardingType = argsNetMes.P2pConfig.Sharding.Type
 
 	return err
 }
@@ -520,11 +627,13 @@ func (netMes *networkMessenger) createDiscoverer(p2pConfig config.P2PConfig) err
 	var err error
 
 	args := discoveryFactory.ArgsPeerDiscoverer{
-		Context:            netMes.ctx,
-		Host:               netMes.p2pHost,
-		Sharder:            netMes.sharder,
-		P2pConfig:          p2pConfig,
-		ConnectionsWatcher: netMes.printConnectionsWatcher,
+		Context:                netMes.ctx,
+		Host:                   netMes.p2pHost,
+		Sharder:                netMes.sharder,
+		P2pConfig:              p2pConfig,
+		ConnectionsWatcher:     netMes.printConnectionsWatcher,
+		AddressDenialEvaluator: netMes.addressDenial,
+		PreferredPeersHolder:   netMes.preferredPeersHolder,
 	}
 
 	netMes.peerDiscoverer, err = discoveryFactory.NewPeerDiscoverer(args)
@@ -532,6 +641,26 @@ func (netMes *networkMessenger) createDiscoverer(p2pConfig config.P2PConfig) err
 	return err
 }
 
+func (netMes *networkMessenger) createPeerConnector() error {
+	sharder, ok := netMes.sharder.(discovery.Sharder)
+	if !ok {
+		return fmt.Errorf("%w in networkMessenger.createPeerConnector", p2p.ErrWrongTypeAssertions)
+	}
+
+	args := discovery.ArgsHostWithConnectionManagement{
+		ConnectableHost:        netMes.p2pHost,
+		Sharder:                sharder,
+		ConnectionsWatcher:     netMes.printConnectionsWatcher,
+		AddressDenialEvaluator: netMes.addressDenial,
+	}
+
+	var err error
+	netMes.peerConnector, err = discovery.NewHostWithConnectionManagement(args)
+	netMes.temporaryConns = newTemporaryConnections()
+
+	return err
+}
+
 func (netMes *networkMessenger) createConnectionMonitor(p2pConfig config.P2PConfig) error {
 	reconnecter, ok := netMes.peerDiscoverer.(p2p.Reconnecter)
 	if !ok {
@@ -560,6 +689,7 @@ func (netMes *networkMessenger) createConnectionMonitor(p2pConfig config.P2PConf
 		netMes.p2pHost.Network(),
 		netMes.connMonitor,
 		&disabled.PeerDenialEvaluator{},
+		netMes.addressDenial,
 	)
 	netMes.p2pHost.Network().Notify(cmw)
 	netMes.connMonitorWrapper = cmw
@@ -567,6 +697,7 @@ func (netMes *networkMessenger) createConnectionMonitor(p2pConfig config.P2PConf
 	go func() {
 		for {
 			cmw.CheckConnectionsBlocking()
+			netMes.trimTemporaryConnections()
 			select {
 			case <-time.After(durationCheckConnections):
 			case <-netMes.ctx.Done():
@@ -579,6 +710,52 @@ func (netMes *networkMessenger) createConnectionMonitor(p2pConfig config.P2PConf
 	return nil
 }
 
+func (netMes *networkMessenger) createAntiflood(p2pConfig config.P2PConfig) error {
+	if !p2pConfig.Antiflood.Enabled {
+		netMes.antiflood = &disabled.Antiflood{}
+		return nil
+	}
+
+	args := antiflood.ArgsAntiflood{
+		Config:                    p2pConfig.Antiflood,
+		PeersRatingHandler:        netMes.peersRatingHandler,
+		PeerDenialEvaluatorHolder: netMes.connMonitorWrapper,
+	}
+
+	var err error
+	netMes.antiflood, err = antiflood.NewAntiflood(args)
+
+	return err
+}
+
+func (netMes *networkMessenger) createDirectSender(p2pConfig config.P2PConfig) error {
+	ds, err := NewDirectSender(netMes.ctx, netMes.p2pHost, netMes.directMessageHandler, netMes)
+	if err != nil {
+		return err
+	}
+
+	if p2pConfig.DirectSend.CompressedProtocolEnabled {
+		compressor, errCreate := compressionFactory.NewCompressor(p2pConfig.DirectSend.Compression)
+		if errCreate != nil {
+			return fmt.Errorf("%w for the direct send protocol", errCreate)
+		}
+
+		codec, errCreate := NewCompressedDirectSendCodec(compressor)
+		if errCreate != nil {
+			return errCreate
+		}
+
+		err = ds.RegisterProtocol(CompressedDirectSendID, codec)
+		if err != nil {
+			return err
+		}
+	}
+
+	netMes.ds = ds
+
+	return nil
+}
+
 func (netMes *networkMessenger) createConnectionsMetric() {
 	netMes.connectionsMetric = metrics.NewConnections()
 	netMes.p2pHost.Network().Notify(netMes.connectionsMetric)
@@ -677,9 +854,20 @@ func (netMes *networkMessenger) checkExternalLoggers() {
 
 // Close closes the host, connections and streams
 func (netMes *networkMessenger) Close() error {
-	log.Debug("closing network messenger's host...")
-
 	var err error
+	log.Debug("closing network messenger's peer discoverer...")
+	closer, ok := netMes.peerDiscoverer.(io.Closer)
+	if ok {
+		errDiscoverer := closer.Close()
+		if errDiscoverer != nil {
+			err = errDiscoverer
+			log.Warn("networkMessenger.Close",
+				"component", "peerDiscoverer",
+				"error", err)
+		}
+	}
+
+	log.Debug("closing network messenger's host...")
 	errHost := netMes.p2pHost.Close()
 	if errHost != nil {
 		err = errHost
@@ -722,6 +910,14 @@ func (netMes *networkMessenger) Close() error {
 			"error", errConnMonitor)
 	}
 
+	log.Debug("closing network messenger's antiflood...")
+	errAntiflood := netMes.antiflood.Close()
+	if errAntiflood != nil {
+		log.Warn("networkMessenger.Close",
+			"component", "antiflood",
+			"error", errAntiflood)
+	}
+
 	log.Debug("closing network messenger's components through the context...")
 	netMes.cancelFunc()
 
@@ -920,7 +1116,19 @@ func (netMes *networkMessenger) ConnectedFullHistoryPeersOnTopic(topic string) [
 }
 
 // CreateTopic opens a new topic using pubsub infrastructure
-func (netMes *networkMessenger) CreateTopic(name string, createChannelForTopic bool) error {
+func (netMes *networkMessenger) CreateTopic(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+	topicOptions := p2p.NewTopicOptions(options...)
+	compressor, err := compressionFactory.NewCompressor(topicOptions.Compression)
+	if err != nil {
+		return fmt.Errorf("%w for topic %s", err, name)
+	}
+	if createChannelForTopic {
+		err = checkOutgoingChannelOptions(topicOptions.OutgoingChannel)
+		if err != nil {
+			return fmt.Errorf("%w for topic %s", err, name)
+		}
+	}
+
 	netMes.mutTopics.Lock()
 	defer netMes.mutTopics.Unlock()
 	_, found := netMes.topics[name]
@@ -934,6 +1142,9 @@ func (netMes *networkMessenger) CreateTopic(name string, createChannelForTopic b
 	}
 
 	netMes.topics[name] = topic
+	if netMes.topicCompressionEnabled && compressor.ID() != compression.NoCompressionID {
+		netMes.topicCompressors[name] = compressor
+	}
 	subscrRequest, err := topic.Subscribe()
 	if err != nil {
 		return fmt.Errorf("%w for topic %s", err, name)
@@ -941,7 +1152,7 @@ func (netMes *networkMessenger) CreateTopic(name string, createChannelForTopic b
 
 	netMes.subscriptions[name] = subscrRequest
 	if createChannelForTopic {
-		err = netMes.outgoingPLB.AddChannel(name)
+		err = netMes.outgoingPLB.AddChannel(name, topicOptions.OutgoingChannel)
 	}
 
 	// just a dummy func to consume messages received by the newly created topic
@@ -972,9 +1183,10 @@ func (netMes *networkMessenger) HasTopic(name string) bool {
 }
 
 // BroadcastOnChannelBlocking tries to send a byte buffer onto a topic using provided channel
-// It is a blocking method. It needs to be launched on a go routine
+// It is a blocking method: if the queue of the channel is full, it waits until there is room in the queue or until
+// the messenger is closed. It needs to be launched on a go routine
 func (netMes *networkMessenger) BroadcastOnChannelBlocking(channel string, topic string, buff []byte) error {
-	err := netMes.checkSendableData(buff)
+	err := netMes.checkSendableData(topic, buff)
 	if err != nil {
 		return err
 	}
@@ -984,20 +1196,26 @@ func (netMes *networkMessenger) BroadcastOnChannelBlocking(channel string, topic
 	}
 
 	netMes.goRoutinesThrottler.StartProcessing()
+	defer netMes.goRoutinesThrottler.EndProcessing()
 
-	sendable := &SendableData{
-		Buff:  buff,
-		Topic: topic,
-		ID:    netMes.p2pHost.ID(),
+	sendable, err := netMes.createSendableData(topic, buff, nil, netMes.p2pHost.ID())
+	if err != nil {
+		return err
 	}
-	netMes.outgoingPLB.GetChannelOrDefault(channel) <- sendable
-	netMes.goRoutinesThrottler.EndProcessing()
-	return nil
+
+	return netMes.outgoingPLB.EnqueueWithContext(netMes.ctx, channel, sendable)
 }
 
-func (netMes *networkMessenger) checkSendableData(buff []byte) error {
-	if len(buff) > maxSendBuffSize {
-		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxSendBuffSize)
+// checkSendableData checks the size of the buffer before packing. The buffers sent on topics that use compression
+// are allowed to be larger as the maximum size is checked again after the compression
+func (netMes *networkMessenger) checkSendableData(topic string, buff []byte) error {
+	maxSize := maxSendBuffSize
+	if !check.IfNil(netMes.getTopicCompressor(topic)) {
+		maxSize = maxDecompressedPayloadSize
+	}
+
+	if len(buff) > maxSize {
+		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxSize)
 	}
 	if len(buff) == 0 {
 		return p2p.ErrEmptyBufferToSend
@@ -1007,13 +1225,81 @@ func (netMes *networkMessenger) checkSendableData(buff []byte) error {
 }
 
 // BroadcastOnChannel tries to send a byte buffer onto a topic using provided channel
+// The message is queued without blocking, the dropped messages being logged
 func (netMes *networkMessenger) BroadcastOnChannel(channel string, topic string, buff []byte) {
-	go func() {
-		err := netMes.BroadcastOnChannelBlocking(channel, topic, buff)
-		if err != nil {
-			log.Warn("p2p broadcast", "error", err.Error())
-		}
-	}()
+	result := netMes.TryBroadcastOnChannel(channel, topic, buff)
+	if !result.IsQueued() {
+		log.Warn("p2p broadcast", "topic", topic, "channel", channel, "result", string(result))
+	}
+}
+
+// TryBroadcastOnChannel tries to queue a byte buffer to be sent onto a topic using provided channel
+// It returns immediately, the result telling if the message was queued or the reason it was dropped
+func (netMes *networkMessenger) TryBroadcastOnChannel(channel string, topic string, buff []byte) p2p.BroadcastResult {
+	result, err := netMes.checkBroadcastData(topic, buff)
+	if err != nil {
+		log.Trace("p2p try broadcast", "topic", topic, "error", err.Error())
+		return result
+	}
+
+	sendable, err := netMes.createSendableData(topic, buff, nil, netMes.p2pHost.ID())
+	if err != nil {
+		log.Trace("p2p try broadcast", "topic", topic, "error", err.Error())
+		return p2p.BroadcastDroppedInvalidData
+	}
+
+	err = netMes.outgoingPLB.Enqueue(channel, sendable)
+	if err != nil {
+		log.Trace("p2p try broadcast", "topic", topic, "error", err.Error())
+		return p2p.BroadcastDroppedQueueFull
+	}
+
+	return p2p.BroadcastQueued
+}
+
+// BroadcastOnChannelWithContext queues a byte buffer to be sent onto a topic using provided channel
+// If the queue of the channel is full, it blocks until there is room in the queue or until the context is done
+func (netMes *networkMessenger) BroadcastOnChannelWithContext(ctx context.Context, channel string, topic string, buff []byte) error {
+	_, err := netMes.checkBroadcastData(topic, buff)
+	if err != nil {
+		return err
+	}
+
+	sendable, err := netMes.createSendableData(topic, buff, nil, netMes.p2pHost.ID())
+	if err != nil {
+		return err
+	}
+
+	return netMes.outgoingPLB.EnqueueWithContext(ctx, channel, sendable)
+}
+
+func (netMes *networkMessenger) checkBroadcastData(topic string, buff []byte) (p2p.BroadcastResult, error) {
+	if !netMes.HasTopic(topic) {
+		return p2p.BroadcastDroppedUnknownTopic, fmt.Errorf("%w %s", p2p.ErrUnknownTopic, topic)
+	}
+
+	err := netMes.checkSendableData(topic, buff)
+	if err != nil {
+		return p2p.BroadcastDroppedInvalidData, err
+	}
+
+	return p2p.BroadcastQueued, nil
+}
+
+// createSendableData packs the buffer when the broadcast is queued so that a payload too large for the transport,
+// even after compression, is reported to the caller
+func (netMes *networkMessenger) createSendableData(topic string, buff []byte, sk libp2pCrypto.PrivKey, id peer.ID) (*SendableData, error) {
+	packedBuff, err := netMes.createMessageBytes(topic, buff, maxSendBuffSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendableData{
+		Buff:  packedBuff,
+		Topic: topic,
+		Sk:    sk,
+		ID:    id,
+	}, nil
 }
 
 // Broadcast tries to send a byte buffer onto a topic using the topic name as channel
@@ -1022,7 +1308,8 @@ func (netMes *networkMessenger) Broadcast(topic string, buff []byte) {
 }
 
 // BroadcastOnChannelBlockingUsingPrivateKey tries to send a byte buffer onto a topic using provided channel
-// It is a blocking method. It needs to be launched on a go routine
+// It is a blocking method: if the queue of the channel is full, it waits until there is room in the queue or until
+// the messenger is closed. It needs to be launched on a go routine
 func (netMes *networkMessenger) BroadcastOnChannelBlockingUsingPrivateKey(
 	channel string,
 	topic string,
@@ -1036,7 +1323,7 @@ func (netMes *networkMessenger) BroadcastOnChannelBlockingUsingPrivateKey(
 		return err
 	}
 
-	err = netMes.checkSendableData(buff)
+	err = netMes.checkSendableData(topic, buff)
 	if err != nil {
 		return err
 	}
@@ -1046,16 +1333,14 @@ func (netMes *networkMessenger) BroadcastOnChannelBlockingUsingPrivateKey(
 	}
 
 	netMes.goRoutinesThrottler.StartProcessing()
+	defer netMes.goRoutinesThrottler.EndProcessing()
 
-	sendable := &SendableData{
-		Buff:  buff,
-		Topic: topic,
-		Sk:    sk,
-		ID:    id,
+	sendable, err := netMes.createSendableData(topic, buff, sk, id)
+	if err != nil {
+		return err
 	}
-	netMes.outgoingPLB.GetChannelOrDefault(channel) <- sendable
-	netMes.goRoutinesThrottler.EndProcessing()
-	return nil
+
+	return netMes.outgoingPLB.EnqueueWithContext(netMes.ctx, channel, sendable)
 }
 
 // BroadcastOnChannelUsingPrivateKey tries to send a byte buffer onto a topic using provided channel
@@ -1123,6 +1408,12 @@ func (netMes *networkMessenger) pubsubCallback(topicProcs *topicProcessors, topi
 			return false
 		}
 
+		err = netMes.checkAntiflood(msg, fromConnectedPeer)
+		if err != nil {
+			log.Trace("p2p antiflood - new message", "error", err.Error(), "topic", topic)
+			return false
+		}
+
 		identifiers, handlers := topicProcs.getList()
 		messageOk := true
 		for index, handler := range handlers {
@@ -1142,7 +1433,7 @@ func (netMes *networkMessenger) pubsubCallback(topicProcs *topicProcessors, topi
 		netMes.processDebugMessage(topic, fromConnectedPeer, uint64(len(message.Data)), !messageOk)
 
 		if messageOk {
-			netMes.peersRatingHandler.IncreaseRating(fromConnectedPeer)
+			netMes.peersRatingHandler.IncreaseRatingWithReason(fromConnectedPeer, p2p.ValidGossipRatingReason)
 		}
 
 		return messageOk
@@ -1151,10 +1442,23 @@ func (netMes *networkMessenger) pubsubCallback(topicProcs *topicProcessors, topi
 
 func (netMes *networkMessenger) transformAndCheckMessage(pbMsg *pubsub.Message, pid core.PeerID, topic string) (p2p.MessageP2P, error) {
 	msg, errUnmarshal := NewMessage(pbMsg, netMes.marshalizer)
+	if errors.Is(errUnmarshal, p2p.ErrMessageDecompressionError) || errors.Is(errUnmarshal, p2p.ErrNewerMessageVersion) {
+		// the sender might use a codec or a message version this node does not know yet, so the message is only dropped
+		log.Trace("received a message that could not be decoded",
+			"originator pid", p2p.PeerIdToShortString(core.PeerID(pbMsg.From)),
+			"from connected pid", p2p.PeerIdToShortString(pid),
+			"topic", topic,
+			"error", errUnmarshal,
+		)
+		netMes.processDebugMessage(topic, pid, uint64(len(pbMsg.Data)), true)
+
+		return nil, errUnmarshal
+	}
 	if errUnmarshal != nil {
 		// this error is so severe that will need to blacklist both the originator and the connected peer as there is
 		// no way this node can communicate with them
 		pidFrom := core.PeerID(pbMsg.From)
+		netMes.peersRatingHandler.DecreaseRatingWithReason(pid, p2p.InvalidDataRatingReason)
 		netMes.blacklistPid(pid, p2p.WrongP2PMessageBlacklistDuration)
 		netMes.blacklistPid(pidFrom, p2p.WrongP2PMessageBlacklistDuration)
 
@@ -1179,6 +1483,22 @@ func (netMes *networkMessenger) transformAndCheckMessage(pbMsg *pubsub.Message,
 	return msg, nil
 }
 
+// checkAntiflood rejects the messages that exceed the antiflood limits before calling the handlers. The messages
+// sent by self are not limited
+func (netMes *networkMessenger) checkAntiflood(msg p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+	if fromConnectedPeer == netMes.ID() {
+		return nil
+	}
+
+	err := netMes.antiflood.CanProcessMessage(msg, fromConnectedPeer)
+	if err != nil {
+		netMes.processDebugMessage(msg.Topic(), fromConnectedPeer, uint64(len(msg.Data())), true)
+		netMes.addAntifloodRejection(msg.Topic())
+	}
+
+	return err
+}
+
 func (netMes *networkMessenger) blacklistPid(pid core.PeerID, banDuration time.Duration) {
 	if netMes.connMonitorWrapper.PeerDenialEvaluator().IsDenied(pid) {
 		return
@@ -1198,7 +1518,10 @@ func (netMes *networkMessenger) blacklistPid(pid core.PeerID, banDuration time.D
 			"pid", pid.Pretty(),
 			"error", err.Error(),
 		)
+		return
 	}
+
+	netMes.addBlacklistEvent()
 }
 
 // invalidMessageByTimestamp will check that the message time stamp should be in the interval
@@ -1222,9 +1545,9 @@ func (netMes *networkMessenger) validMessageByTimestamp(msg p2p.MessageP2P) erro
 
 func (netMes *networkMessenger) processDebugMessage(topic string, fromConnectedPeer core.PeerID, size uint64, isRejected bool) {
 	if fromConnectedPeer == netMes.ID() {
-		netMes.debugger.AddOutgoingMessage(topic, size, isRejected)
+		netMes.addOutgoingMessage(topic, size, isRejected)
 	} else {
-		netMes.debugger.AddIncomingMessage(topic, size, isRejected)
+		netMes.addIncomingMessage(topic, size, isRejected)
 	}
 }
 
@@ -1266,11 +1589,78 @@ func (netMes *networkMessenger) UnjoinAllTopics() error {
 		}
 
 		delete(netMes.topics, topicName)
+		delete(netMes.topicCompressors, topicName)
 	}
 
 	return errFound
 }
 
+// LeaveTopic cancels the subscription and closes the provided topic, also removing its outgoing channel and all its
+// message processors. The peer topic notifiers are informed after the topic was left. Leaving a topic that was not
+// created is a no-op
+func (netMes *networkMessenger) LeaveTopic(name string) error {
+	netMes.mutTopics.Lock()
+	topic, found := netMes.topics[name]
+	if !found {
+		netMes.mutTopics.Unlock()
+		return nil
+	}
+
+	err := netMes.leaveTopic(name, topic)
+	netMes.mutTopics.Unlock()
+	if err != nil {
+		return err
+	}
+
+	netMes.poc.removeTopic(name)
+	netMes.topicLeft(name)
+
+	log.Debug("networkMessenger.LeaveTopic", "topic", name)
+
+	return nil
+}
+
+func (netMes *networkMessenger) leaveTopic(name string, topic *pubsub.Topic) error {
+	subscr := netMes.subscriptions[name]
+	if subscr != nil {
+		subscr.Cancel()
+	}
+	delete(netMes.subscriptions, name)
+
+	if netMes.processors[name] != nil {
+		err := netMes.pb.UnregisterTopicValidator(name)
+		if err != nil {
+			return fmt.Errorf("%w while unregistering the message processors of topic %s", err, name)
+		}
+	}
+	delete(netMes.processors, name)
+
+	err := topic.Close()
+	if err != nil {
+		return fmt.Errorf("%w while closing topic %s", err, name)
+	}
+	delete(netMes.topics, name)
+	delete(netMes.topicCompressors, name)
+
+	err = netMes.outgoingPLB.RemoveChannel(name)
+	if err != nil && !errors.Is(err, p2p.ErrChannelDoesNotExist) {
+		log.Warn("error removing the outgoing channel",
+			"topic", name,
+			"error", err,
+		)
+	}
+
+	return nil
+}
+
+func (netMes *networkMessenger) topicLeft(topic string) {
+	netMes.mutPeerTopicNotifiers.RLock()
+	defer netMes.mutPeerTopicNotifiers.RUnlock()
+	for _, notifier := range netMes.peerTopicNotifiers {
+		notifier.TopicLeft(topic)
+	}
+}
+
 // UnregisterMessageProcessor unregisters a message processes on a topic
 func (netMes *networkMessenger) UnregisterMessageProcessor(topic string, identifier string) error {
 	netMes.mutTopics.Lock()
@@ -1298,14 +1688,14 @@ func (netMes *networkMessenger) UnregisterMessageProcessor(topic string, identif
 
 // SendToConnectedPeer sends a direct message to a connected peer
 func (netMes *networkMessenger) SendToConnectedPeer(topic string, buff []byte, peerID core.PeerID) error {
-	err := netMes.checkSendableData(buff)
+	err := netMes.checkSendableData(topic, buff)
 	if err != nil {
 		return err
 	}
 
-	buffToSend := netMes.createMessageBytes(buff)
-	if len(buffToSend) == 0 {
-		return nil
+	buffToSend, err := netMes.createMessageBytes(topic, buff, maxSendBuffSize)
+	if err != nil {
+		return err
 	}
 
 	if peerID == netMes.ID() {
@@ -1313,11 +1703,169 @@ func (netMes *networkMessenger) SendToConnectedPeer(topic string, buff []byte, p
 	}
 
 	err = netMes.ds.Send(topic, buffToSend, peerID)
-	netMes.debugger.AddOutgoingMessage(topic, uint64(len(buffToSend)), err != nil)
+	netMes.addOutgoingMessage(topic, uint64(len(buffToSend)), err != nil)
+
+	return err
+}
+
+// SendToConnectedPeerWithAck sends a direct message to a connected peer and waits for the peer to confirm its delivery
+func (netMes *networkMessenger) SendToConnectedPeerWithAck(ctx context.Context, topic string, buff []byte, peerID core.PeerID) error {
+	err := netMes.checkSendableData(topic, buff)
+	if err != nil {
+		return err
+	}
+
+	buffToSend, err := netMes.createMessageBytes(topic, buff, maxSendBuffSize)
+	if err != nil {
+		return err
+	}
+
+	if peerID == netMes.ID() {
+		err = netMes.sendDirectToSelf(topic, buffToSend)
+		if err != nil {
+			return fmt.Errorf("%w, reason: %s", p2p.ErrDirectMessageRejected, err.Error())
+		}
+
+		return nil
+	}
+
+	err = netMes.ds.SendWithAck(ctx, topic, buffToSend, peerID)
+	netMes.addOutgoingMessage(topic, uint64(len(buffToSend)), err != nil)
 
 	return err
 }
 
+// SendToPeer sends a direct message to a peer, opening a new connection if the peer is not already connected. The
+// peer addresses are searched in the peerstore and, if not found there, through the peer discovery mechanism. The
+// new connection is dialed only if the sharder and the denial evaluators allow it and is a temporary one: once the
+// minimum temporary connection TTL or, if keepConnection is set, the temporary connection TTL expires, it is closed
+// unless the peer became a preferred one. Meanwhile, it is subject to the usual sharder eviction
+func (netMes *networkMessenger) SendToPeer(ctx context.Context, topic string, buff []byte, peerID core.PeerID, keepConnection bool) error {
+	if ctx == nil {
+		return p2p.ErrNilContext
+	}
+
+	pid := peer.ID(peerID)
+	isConnected := peerID == netMes.ID() || netMes.p2pHost.Network().Connectedness(pid) == network.Connected
+	if isConnected {
+		if keepConnection && netMes.temporaryConns.has(pid) {
+			netMes.temporaryConns.upsert(pid, temporaryConnectionTTL)
+		}
+
+		return netMes.SendToConnectedPeer(topic, buff, peerID)
+	}
+
+	err := netMes.checkSendableData(topic, buff)
+	if err != nil {
+		return err
+	}
+
+	err = netMes.connectToPeerID(ctx, pid)
+	if err != nil {
+		return err
+	}
+
+	ttl := minTemporaryConnectionTTL
+	if keepConnection {
+		ttl = temporaryConnectionTTL
+	}
+	netMes.temporaryConns.upsert(pid, ttl)
+
+	return netMes.SendToConnectedPeer(topic, buff, peerID)
+}
+
+func (netMes *networkMessenger) connectToPeerID(ctx context.Context, pid peer.ID) error {
+	peerDenialEvaluator := netMes.connMonitorWrapper.PeerDenialEvaluator()
+	if peerDenialEvaluator.IsDenied(core.PeerID(pid)) {
+		return fmt.Errorf("%w, pid: %s", p2p.ErrDeniedPeer, pid.String())
+	}
+
+	addrInfo, err := netMes.findPeerAddresses(ctx, pid)
+	if err != nil {
+		return err
+	}
+
+	return netMes.peerConnector.Connect(ctx, addrInfo)
+}
+
+func (netMes *networkMessenger) findPeerAddresses(ctx context.Context, pid peer.ID) (peer.AddrInfo, error) {
+	addresses := netMes.p2pHost.Peerstore().Addrs(pid)
+	if len(addresses) > 0 {
+		return peer.AddrInfo{
+			ID:    pid,
+			Addrs: addresses,
+		}, nil
+	}
+
+	finder, ok := netMes.peerDiscoverer.(PeerFinder)
+	if !ok {
+		return peer.AddrInfo{}, fmt.Errorf("%w for pid %s, peer discovery can not search for peers",
+			p2p.ErrPeerAddressesNotFound, pid.String())
+	}
+
+	addrInfo, err := finder.FindPeer(ctx, pid)
+	if err != nil {
+		return peer.AddrInfo{}, fmt.Errorf("%w for pid %s: %s", p2p.ErrPeerAddressesNotFound, pid.String(), err.Error())
+	}
+	if len(addrInfo.Addrs) == 0 {
+		return peer.AddrInfo{}, fmt.Errorf("%w for pid %s", p2p.ErrPeerAddressesNotFound, pid.String())
+	}
+
+	return addrInfo, nil
+}
+
+// trimTemporaryConnections closes the expired temporary connections, unless the peers became preferred meanwhile
+func (netMes *networkMessenger) trimTemporaryConnections() {
+	for _, pid := range netMes.temporaryConns.popExpired() {
+		if netMes.preferredPeersHolder.Contains(core.PeerID(pid)) {
+			continue
+		}
+
+		log.Trace("closing expired temporary connection", "pid", pid.String())
+		_ = netMes.p2pHost.Network().ClosePeer(pid)
+	}
+}
+
+// SendChunkedToConnectedPeer sends a large direct message to a connected peer, split in chunks
+func (netMes *networkMessenger) SendChunkedToConnectedPeer(topic string, buff []byte, peerID core.PeerID) error {
+	err := netMes.checkChunkedSendableData(topic, buff)
+	if err != nil {
+		return err
+	}
+
+	buffToSend, err := netMes.createMessageBytes(topic, buff, maxChunkedPayloadSize)
+	if err != nil {
+		return err
+	}
+
+	if peerID == netMes.ID() {
+		return netMes.sendDirectToSelf(topic, buffToSend)
+	}
+
+	err = netMes.cs.Send(topic, buffToSend, peerID)
+	netMes.addOutgoingMessage(topic, uint64(len(buffToSend)), err != nil)
+
+	return err
+}
+
+// checkChunkedSendableData checks the size of the buffer before packing. The buffers sent on topics that use
+// compression are limited to the maximum size accepted by the receiver after the decompression
+func (netMes *networkMessenger) checkChunkedSendableData(topic string, buff []byte) error {
+	maxSize := maxChunkedPayloadSize
+	if !check.IfNil(netMes.getTopicCompressor(topic)) {
+		maxSize = maxDecompressedPayloadSize
+	}
+
+	if len(buff) > maxSize {
+		return fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxSize)
+	}
+	if len(buff) == 0 {
+		return p2p.ErrEmptyBufferToSend
+	}
+
+	return nil
+}
+
 func (netMes *networkMessenger) sendDirectToSelf(topic string, buff []byte) error {
 	msg := &pubsub.Message{
 		Message: &pubsubPb.Message{
@@ -1339,6 +1887,11 @@ func (netMes *networkMessenger) directMessageHandler(message *pubsub.Message, fr
 		return err
 	}
 
+	err = netMes.checkAntiflood(msg, fromConnectedPeer)
+	if err != nil {
+		return err
+	}
+
 	netMes.mutTopics.RLock()
 	topicProcs := netMes.processors[topic]
 	netMes.mutTopics.RUnlock()
@@ -1371,16 +1924,135 @@ func (netMes *networkMessenger) directMessageHandler(message *pubsub.Message, fr
 			}
 		}
 
-		netMes.debugger.AddIncomingMessage(msg.Topic(), uint64(len(msg.Data())), !messageOk)
+		netMes.addIncomingMessage(msg.Topic(), uint64(len(msg.Data())), !messageOk)
 
 		if messageOk {
-			netMes.peersRatingHandler.IncreaseRating(fromConnectedPeer)
+			netMes.peersRatingHandler.IncreaseRatingWithReason(fromConnectedPeer, p2p.ValidDirectResponseRatingReason)
 		}
 	}(msg)
 
 	return nil
 }
 
+// Request sends a request to a connected peer and waits for its response
+func (netMes *networkMessenger) Request(ctx context.Context, topic string, buff []byte, peerID core.PeerID) ([]byte, error) {
+	err := netMes.checkSendableData(topic, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	buffToSend, err := netMes.createMessageBytes(topic, buff, maxSendBuffSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if peerID == netMes.ID() {
+		return netMes.requestToSelf(topic, buffToSend)
+	}
+
+	response, err := netMes.rs.Request(ctx, topic, buffToSend, peerID)
+	netMes.addOutgoingMessage(topic, uint64(len(buffToSend)), err != nil)
+	netMes.updateRatingAfterRequest(peerID, err)
+
+	return response, err
+}
+
+func (netMes *networkMessenger) updateRatingAfterRequest(peerID core.PeerID, err error) {
+	if err == nil {
+		netMes.peersRatingHandler.IncreaseRatingWithReason(peerID, p2p.ValidDirectResponseRatingReason)
+		return
+	}
+	if errors.Is(err, p2p.ErrRequestTimeout) {
+		netMes.peersRatingHandler.DecreaseRatingWithReason(peerID, p2p.TimeoutRatingReason)
+	}
+}
+
+func (netMes *networkMessenger) requestToSelf(topic string, buff []byte) ([]byte, error) {
+	msg := &pubsub.Message{
+		Message: &pubsubPb.Message{
+			From:      netMes.ID().Bytes(),
+			Data:      buff,
+			Seqno:     netMes.ds.NextSequenceNumber(),
+			Topic:     &topic,
+			Signature: netMes.ID().Bytes(),
+		},
+	}
+
+	response, err := netMes.requestMessageHandler(msg, netMes.ID())
+	if err != nil {
+		return nil, fmt.Errorf("%w, reason: %s", p2p.ErrRequestRejected, err.Error())
+	}
+
+	return response, nil
+}
+
+func (netMes *networkMessenger) requestMessageHandler(message *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error) {
+	topic := *message.Topic
+	msg, err := netMes.transformAndCheckMessage(message, fromConnectedPeer, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	err = netMes.checkAntiflood(msg, fromConnectedPeer)
+	if err != nil {
+		return nil, err
+	}
+
+	netMes.mutRequestHandlers.RLock()
+	handler := netMes.requestHandlers[topic]
+	netMes.mutRequestHandlers.RUnlock()
+
+	if check.IfNil(handler) {
+		return nil, fmt.Errorf("%w %s", p2p.ErrNoRequestHandlerForTopic, topic)
+	}
+
+	response, err := handler.ProcessRequest(msg, fromConnectedPeer)
+	netMes.addIncomingMessage(topic, uint64(len(msg.Data())), err != nil)
+	if err != nil {
+		log.Trace("p2p request handler",
+			"error", err.Error(),
+			"topic", topic,
+			"originator", p2p.MessageOriginatorPid(msg),
+			"from connected peer", p2p.PeerIdToShortString(fromConnectedPeer),
+			"seq no", p2p.MessageOriginatorSeq(msg),
+		)
+		return nil, err
+	}
+
+	netMes.peersRatingHandler.IncreaseRatingWithReason(fromConnectedPeer, p2p.ValidRequestRatingReason)
+
+	return response, nil
+}
+
+// RegisterRequestHandler registers the handler that will respond to the requests received on the provided topic
+func (netMes *networkMessenger) RegisterRequestHandler(topic string, handler p2p.RequestHandler) error {
+	if check.IfNil(handler) {
+		return fmt.Errorf("%w when calling networkMessenger.RegisterRequestHandler for topic %s",
+			p2p.ErrNilRequestHandler, topic)
+	}
+
+	netMes.mutRequestHandlers.Lock()
+	defer netMes.mutRequestHandlers.Unlock()
+
+	_, found := netMes.requestHandlers[topic]
+	if found {
+		return fmt.Errorf("%w, topic %s", p2p.ErrRequestHandlerAlreadyDefined, topic)
+	}
+
+	netMes.requestHandlers[topic] = handler
+
+	return nil
+}
+
+// UnregisterRequestHandler unregisters the request handler set on the provided topic
+func (netMes *networkMessenger) UnregisterRequestHandler(topic string) error {
+	netMes.mutRequestHandlers.Lock()
+	delete(netMes.requestHandlers, topic)
+	netMes.mutRequestHandlers.Unlock()
+
+	return nil
+}
+
 // IsConnectedToTheNetwork returns true if the current node is connected to the network
 func (netMes *networkMessenger) IsConnectedToTheNetwork() bool {
 	netw := netMes.p2pHost.Network()
@@ -1426,7 +2098,71 @@ func (netMes *networkMessenger) SetPeerShardResolver(peerShardResolver p2p.PeerS
 // SetPeerDenialEvaluator sets the peer black list handler
 // TODO decide if we continue on using setters or switch to options. Refactor if necessary
 func (netMes *networkMessenger) SetPeerDenialEvaluator(handler p2p.PeerDenialEvaluator) error {
-	return netMes.connMonitorWrapper.SetPeerDenialEvaluator(handler)
+	err := netMes.connMonitorWrapper.SetPeerDenialEvaluator(handler)
+	if err != nil {
+		return err
+	}
+
+	return netMes.connGater.SetPeerDenialEvaluator(handler)
+}
+
+// UpdateShardingConfig applies the new connection quotas on the sharder and triggers an eviction pass through the
+// connection monitor. The sharder type can not be changed at runtime
+func (netMes *networkMessenger) UpdateShardingConfig(cfg config.ShardingConfig) error {
+	if cfg.Type != netMes.shardingType {
+		return fmt.Errorf("%w, the sharder type can not be changed at runtime from %s to %s",
+			p2p.ErrInvalidValue, netMes.shardingType, cfg.Type)
+	}
+
+	updater, ok := netMes.sharder.(ShardingConfigUpdater)
+	if !ok {
+		return fmt.Errorf("%w when converting sharder to ShardingConfigUpdater interface", p2p.ErrWrongTypeAssertion)
+	}
+
+	err := updater.UpdateShardingConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	netMes.connMonitor.EvictUnwantedPeers(netMes.p2pHost.Network())
+
+	return nil
+}
+
+// ExplainEviction dry-runs the sharder on the currently connected peers and explains the outcome
+func (netMes *networkMessenger) ExplainEviction() p2p.EvictionDecision {
+	explainer, ok := netMes.sharder.(EvictionExplainer)
+	if !ok {
+		return p2p.EvictionDecision{}
+	}
+
+	return explainer.ExplainEviction(netMes.p2pHost.Network().Peers())
+}
+
+// LastEvictionDecision returns the last sharder decision that evicted at least one peer
+func (netMes *networkMessenger) LastEvictionDecision() p2p.EvictionDecision {
+	explainer, ok := netMes.sharder.(EvictionExplainer)
+	if !ok {
+		return p2p.EvictionDecision{}
+	}
+
+	return explainer.LastEvictionDecision()
+}
+
+// SeedersStatus returns the health state of the seeders, as tracked by the peer discovery mechanism. Returns an empty
+// slice if the peer discovery mechanism does not track the seeders
+func (netMes *networkMessenger) SeedersStatus() []p2p.SeederStatus {
+	provider, ok := netMes.peerDiscoverer.(SeedersStatusProvider)
+	if !ok {
+		return make([]p2p.SeederStatus, 0)
+	}
+
+	return provider.SeedersStatus()
+}
+
+// AddressDenialEvaluator returns the address denial evaluator consulted on each dialed or accepted connection
+func (netMes *networkMessenger) AddressDenialEvaluator() p2p.AddressDenialEvaluator {
+	return netMes.addressDenial
 }
 
 // GetConnectedPeersInfo gets the current connected peers information
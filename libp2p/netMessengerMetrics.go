@@ -0,0 +1,222 @@
+package libp2p
+
+import (
+	"net/http"
+
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/metrics"
+)
+
+const (
+	metricMessages           = "p2p_messages_total"
+	metricMessagesBytes      = "p2p_messages_bytes_total"
+	metricConnections        = "p2p_connections_total"
+	metricDisconnections     = "p2p_disconnections_total"
+	metricConnectedPeers     = "p2p_connected_peers"
+	metricBlacklistEvents    = "p2p_blacklist_events_total"
+	metricRatingTierPeers    = "p2p_rating_tier_peers"
+	metricOutgoingQueueDepth = "p2p_outgoing_queue_depth"
+	metricOutgoingQueued     = "p2p_outgoing_queued_messages_total"
+	metricOutgoingDropped    = "p2p_outgoing_dropped_messages_total"
+	metricAntifloodRejected  = "p2p_antiflood_rejected_messages_total"
+
+	labelTopic     = "topic"
+	labelDirection = "direction"
+	labelStatus    = "status"
+	labelCategory  = "category"
+	labelTier      = "tier"
+	labelChannel   = "channel"
+
+	directionIncoming = "incoming"
+	directionOutgoing = "outgoing"
+	statusAccepted    = "accepted"
+	statusRejected    = "rejected"
+	unknownTopicLabel = "unknown"
+)
+
+func (netMes *networkMessenger) createMetricsRegistry() error {
+	netMes.metricsRegistry = metrics.NewRegistry()
+
+	err := netMes.metricsRegistry.RegisterCollector(
+		metricConnections,
+		"number of connections opened by the host",
+		metrics.CounterType,
+		func() []metrics.Sample {
+			return []metrics.Sample{{Value: float64(netMes.connectionsMetric.NumTotalConnections())}}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = netMes.metricsRegistry.RegisterCollector(
+		metricDisconnections,
+		"number of connections closed by the host",
+		metrics.CounterType,
+		func() []metrics.Sample {
+			return []metrics.Sample{{Value: float64(netMes.connectionsMetric.NumTotalDisconnections())}}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = netMes.metricsRegistry.RegisterCollector(
+		metricConnectedPeers,
+		"number of connected peers for each category",
+		metrics.GaugeType,
+		netMes.collectConnectedPeersSamples,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = netMes.metricsRegistry.RegisterCollector(
+		metricRatingTierPeers,
+		"number of peers in each rating tier",
+		metrics.GaugeType,
+		netMes.collectRatingTierSamples,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = netMes.metricsRegistry.RegisterCollector(
+		metricOutgoingQueueDepth,
+		"number of messages waiting to be sent on each channel",
+		metrics.GaugeType,
+		func() []metrics.Sample {
+			return netMes.collectOutgoingChannelsSamples(func(statistics OutgoingChannelStatistics) float64 {
+				return float64(statistics.QueueLen)
+			})
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = netMes.metricsRegistry.RegisterCollector(
+		metricOutgoingQueued,
+		"number of messages queued on each channel",
+		metrics.CounterType,
+		func() []metrics.Sample {
+			return netMes.collectOutgoingChannelsSamples(func(statistics OutgoingChannelStatistics) float64 {
+				return float64(statistics.NumQueued)
+			})
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return netMes.metricsRegistry.RegisterCollector(
+		metricOutgoingDropped,
+		"number of messages dropped because the queue of the channel was full",
+		metrics.CounterType,
+		func() []metrics.Sample {
+			return netMes.collectOutgoingChannelsSamples(func(statistics OutgoingChannelStatistics) float64 {
+				return float64(statistics.NumDropped)
+			})
+		},
+	)
+}
+
+func (netMes *networkMessenger) collectConnectedPeersSamples() []metrics.Sample {
+	peersInfo := netMes.GetConnectedPeersInfo()
+
+	return []metrics.Sample{
+		createCategorySample("intra_shard_validators", peersInfo.NumIntraShardValidators),
+		createCategorySample("intra_shard_observers", peersInfo.NumIntraShardObservers),
+		createCategorySample("cross_shard_validators", peersInfo.NumCrossShardValidators),
+		createCategorySample("cross_shard_observers", peersInfo.NumCrossShardObservers),
+		createCategorySample("full_history_observers", peersInfo.NumFullHistoryObservers),
+		createCategorySample("seeders", len(peersInfo.Seeders)),
+		createCategorySample("unknown", len(peersInfo.UnknownPeers)),
+	}
+}
+
+func createCategorySample(category string, value int) metrics.Sample {
+	return metrics.Sample{
+		Labels: []metrics.Label{{Name: labelCategory, Value: category}},
+		Value:  float64(value),
+	}
+}
+
+func (netMes *networkMessenger) collectRatingTierSamples() []metrics.Sample {
+	tierSizes := netMes.peersRatingHandler.GetTierSizes()
+
+	samples := make([]metrics.Sample, 0, len(tierSizes))
+	for tier, size := range tierSizes {
+		samples = append(samples, metrics.Sample{
+			Labels: []metrics.Label{{Name: labelTier, Value: tier}},
+			Value:  float64(size),
+		})
+	}
+
+	return samples
+}
+
+func (netMes *networkMessenger) collectOutgoingChannelsSamples(getValue func(statistics OutgoingChannelStatistics) float64) []metrics.Sample {
+	channelsStatistics := netMes.outgoingPLB.ChannelsStatistics()
+
+	samples := make([]metrics.Sample, 0, len(channelsStatistics))
+	for _, statistics := range channelsStatistics {
+		samples = append(samples, metrics.Sample{
+			Labels: []metrics.Label{{Name: labelChannel, Value: statistics.Name}},
+			Value:  getValue(statistics),
+		})
+	}
+
+	return samples
+}
+
+func (netMes *networkMessenger) addIncomingMessage(topic string, size uint64, isRejected bool) {
+	netMes.debugger.AddIncomingMessage(topic, size, isRejected)
+	netMes.addMessageMetrics(topic, directionIncoming, size, isRejected)
+}
+
+func (netMes *networkMessenger) addOutgoingMessage(topic string, size uint64, isRejected bool) {
+	netMes.debugger.AddOutgoingMessage(topic, size, isRejected)
+	netMes.addMessageMetrics(topic, directionOutgoing, size, isRejected)
+}
+
+func (netMes *networkMessenger) addMessageMetrics(topic string, direction string, size uint64, isRejected bool) {
+	// the topic of a received direct message is chosen by the sender, so only the known topics are used as labels
+	if !netMes.HasTopic(topic) {
+		topic = unknownTopicLabel
+	}
+
+	status := statusAccepted
+	if isRejected {
+		status = statusRejected
+	}
+
+	labels := []metrics.Label{
+		{Name: labelTopic, Value: topic},
+		{Name: labelDirection, Value: direction},
+		{Name: labelStatus, Value: status},
+	}
+	netMes.metricsRegistry.AddCounter(metricMessages, "number of messages", 1, labels...)
+	netMes.metricsRegistry.AddCounter(metricMessagesBytes, "size of the messages in bytes", size, labels...)
+}
+
+func (netMes *networkMessenger) addBlacklistEvent() {
+	netMes.metricsRegistry.AddCounter(metricBlacklistEvents, "number of blacklisted peers due to incompatible messages", 1)
+}
+
+func (netMes *networkMessenger) addAntifloodRejection(topic string) {
+	if !netMes.HasTopic(topic) {
+		topic = unknownTopicLabel
+	}
+
+	netMes.metricsRegistry.AddCounter(
+		metricAntifloodRejected,
+		"number of messages rejected by the antiflood component",
+		1,
+		metrics.Label{Name: labelTopic, Value: topic},
+	)
+}
+
+// MetricsHandler returns the http.Handler that renders the messenger metrics in the Prometheus text format
+func (netMes *networkMessenger) MetricsHandler() http.Handler {
+	return netMes.metricsRegistry
+}
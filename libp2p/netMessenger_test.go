@@ -3,8 +3,11 @@ package libp2p_test
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"runtime"
 	"strings"
 	"sync"
@@ -30,6 +33,7 @@ import (
 	"github.com/multiversx/mx-chain-p2p-go/config"
 	"github.com/multiversx/mx-chain-p2p-go/data"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	compressionFactory "github.com/multiversx/mx-chain-p2p-go/libp2p/compression/factory"
 	p2pCrypto "github.com/multiversx/mx-chain-p2p-go/libp2p/crypto"
 	"github.com/multiversx/mx-chain-p2p-go/message"
 	"github.com/multiversx/mx-chain-p2p-go/mock"
@@ -306,6 +310,20 @@ func TestNewNetworkMessenger_NilChecksShouldErr(t *testing.T) {
 		assert.Nil(t, messenger)
 		assert.True(t, errors.Is(err, p2p.ErrNoTransportsDefined))
 	})
+
+	t.Run("invalid direct send compression should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockNetworkArgs()
+		arg.P2pConfig.DirectSend = config.DirectSendConfig{
+			CompressedProtocolEnabled: true,
+			Compression:               "unknown",
+		}
+		messenger, err := libp2p.NewNetworkMessenger(arg)
+
+		assert.Nil(t, messenger)
+		assert.True(t, errors.Is(err, compressionFactory.ErrUnknownCompressionType))
+	})
 }
 
 func TestNewNetworkMessenger_WithDeactivatedKadDiscovererShouldWork(t *testing.T) {
@@ -335,6 +353,40 @@ func TestNewNetworkMessenger_WithKadDiscovererListsSharderInvalidTargetConnShoul
 	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
 }
 
+func TestNewNetworkMessenger_WithInvalidAntifloodConfigShouldErr(t *testing.T) {
+	arg := createMockNetworkArgs()
+	arg.P2pConfig.Antiflood = config.AntifloodConfig{
+		Enabled:                   true,
+		NumViolationsBeforeDenial: 3,
+	}
+	messenger, err := libp2p.NewNetworkMessenger(arg)
+
+	assert.True(t, check.IfNil(messenger))
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+}
+
+func TestNewNetworkMessenger_WithInvalidConnectionGaterConfigShouldErr(t *testing.T) {
+	arg := createMockNetworkArgs()
+	arg.P2pConfig.ConnectionGater.DeniedSubnets = []string{"invalid subnet"}
+	messenger, err := libp2p.NewNetworkMessenger(arg)
+
+	assert.True(t, check.IfNil(messenger))
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+}
+
+func TestNewNetworkMessenger_WithInvalidAddressDenialConfigShouldErr(t *testing.T) {
+	arg := createMockNetworkArgs()
+	arg.P2pConfig.AddressDenial = config.AddressDenialConfig{
+		MaxPeersPerSubnet:  1,
+		IPv4SubnetMaskBits: 33,
+		IPv6SubnetMaskBits: 64,
+	}
+	messenger, err := libp2p.NewNetworkMessenger(arg)
+
+	assert.True(t, check.IfNil(messenger))
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+}
+
 func TestNewNetworkMessenger_WithKadDiscovererListSharderShouldWork(t *testing.T) {
 	arg := createMockNetworkArgs()
 	arg.P2pConfig.KadDhtPeerDiscovery = config.KadDhtPeerDiscoveryConfig{
@@ -590,10 +642,10 @@ func TestLibp2pMessenger_BroadcastDataLargeMessageShouldNotCallSend(t *testing.T
 	defer closeMessengers(messenger)
 
 	messenger.SetLoadBalancer(&mock.ChannelLoadBalancerStub{
-		GetChannelOrDefaultCalled: func(pipe string) chan *libp2p.SendableData {
+		EnqueueCalled: func(pipe string, data *libp2p.SendableData) error {
 			assert.Fail(t, "should have not got to this line")
 
-			return make(chan *libp2p.SendableData, 1)
+			return nil
 		},
 		CollectOneElementFromChannelsCalled: func() *libp2p.SendableData {
 			return nil
@@ -645,7 +697,7 @@ func TestLibp2pMessenger_BroadcastOnChannelBlockingShouldLimitNumberOfGoRoutines
 	msg := []byte("test message")
 	numBroadcasts := libp2p.BroadcastGoRoutines + 5
 
-	ch := make(chan *libp2p.SendableData)
+	chanRelease := make(chan struct{})
 
 	wg := sync.WaitGroup{}
 	wg.Add(numBroadcasts)
@@ -657,9 +709,10 @@ func TestLibp2pMessenger_BroadcastOnChannelBlockingShouldLimitNumberOfGoRoutines
 		CollectOneElementFromChannelsCalled: func() *libp2p.SendableData {
 			return nil
 		},
-		GetChannelOrDefaultCalled: func(pipe string) chan *libp2p.SendableData {
+		EnqueueWithContextCalled: func(ctx context.Context, pipe string, data *libp2p.SendableData) error {
 			wg.Done()
-			return ch
+			<-chanRelease
+			return nil
 		},
 	})
 
@@ -677,17 +730,185 @@ func TestLibp2pMessenger_BroadcastOnChannelBlockingShouldLimitNumberOfGoRoutines
 
 	wg.Wait()
 
-	// cleanup stuck go routines that are trying to write on the ch channel
-	for i := 0; i < libp2p.BroadcastGoRoutines; i++ {
-		select {
-		case <-ch:
-		default:
-		}
-	}
+	// cleanup stuck go routines that are trying to enqueue
+	close(chanRelease)
 
 	assert.True(t, atomic.LoadUint32(&numErrors) > 0)
 }
 
+func TestLibp2pMessenger_BroadcastOnChannelBlockingShouldWaitForRoomInTheQueue(t *testing.T) {
+	t.Parallel()
+
+	messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger)
+
+	// the messenger's sending go routine collects from the initial load balancer, so the new queues are not emptied
+	loadBalancer := libp2p.NewOutgoingChannelLoadBalancer()
+	messenger.SetLoadBalancer(loadBalancer)
+	_ = messenger.CreateTopic("topic", true, p2p.WithOutgoingQueue(1, p2p.DropNewestPolicy))
+
+	err := messenger.BroadcastOnChannelBlocking("topic", "topic", []byte("buff"))
+	require.Nil(t, err)
+
+	chanDone := make(chan error, 1)
+	go func() {
+		chanDone <- messenger.BroadcastOnChannelBlocking("topic", "topic", []byte("buff"))
+	}()
+
+	select {
+	case <-chanDone:
+		assert.Fail(t, "should have blocked while the queue is full")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	require.NotNil(t, loadBalancer.CollectOneElementFromChannels())
+	select {
+	case err = <-chanDone:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "should have been queued after the queue was emptied")
+	}
+}
+
+func TestLibp2pMessenger_TryBroadcastOnChannel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown topic should drop", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		result := messenger.TryBroadcastOnChannel("topic", "topic", []byte("buff"))
+		assert.Equal(t, p2p.BroadcastDroppedUnknownTopic, result)
+	})
+	t.Run("invalid data should drop", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic("topic", true)
+		result := messenger.TryBroadcastOnChannel("topic", "topic", make([]byte, libp2p.MaxSendBuffSize+1))
+		assert.Equal(t, p2p.BroadcastDroppedInvalidData, result)
+
+		result = messenger.TryBroadcastOnChannel("topic", "topic", nil)
+		assert.Equal(t, p2p.BroadcastDroppedInvalidData, result)
+	})
+	t.Run("full queue should drop", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic("topic", true, p2p.WithOutgoingQueue(1, p2p.DropNewestPolicy))
+		messenger.SetLoadBalancer(&mock.ChannelLoadBalancerStub{
+			EnqueueCalled: func(pipe string, data *libp2p.SendableData) error {
+				return p2p.ErrOutgoingQueueFull
+			},
+		})
+
+		result := messenger.TryBroadcastOnChannel("topic", "topic", []byte("buff"))
+		assert.Equal(t, p2p.BroadcastDroppedQueueFull, result)
+	})
+	t.Run("should queue", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic("topic", true)
+		var queuedData *libp2p.SendableData
+		messenger.SetLoadBalancer(&mock.ChannelLoadBalancerStub{
+			EnqueueCalled: func(pipe string, data *libp2p.SendableData) error {
+				assert.Equal(t, "channel", pipe)
+				queuedData = data
+				return nil
+			},
+		})
+
+		result := messenger.TryBroadcastOnChannel("channel", "topic", []byte("buff"))
+		assert.Equal(t, p2p.BroadcastQueued, result)
+		require.NotNil(t, queuedData)
+		assert.Equal(t, "topic", queuedData.Topic)
+		topicMessage := &data.TopicMessage{}
+		err := (&mock.ProtoMarshallerMock{}).Unmarshal(topicMessage, queuedData.Buff)
+		require.Nil(t, err)
+		assert.Equal(t, []byte("buff"), topicMessage.Payload)
+	})
+}
+
+func TestLibp2pMessenger_BroadcastOnChannelWithContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown topic should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		err := messenger.BroadcastOnChannelWithContext(context.Background(), "topic", "topic", []byte("buff"))
+		assert.True(t, errors.Is(err, p2p.ErrUnknownTopic))
+	})
+	t.Run("invalid data should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic("topic", true)
+		err := messenger.BroadcastOnChannelWithContext(context.Background(), "topic", "topic", nil)
+		assert.Equal(t, p2p.ErrEmptyBufferToSend, err)
+	})
+	t.Run("compressed data still too large should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.TopicCompression.Enabled = true
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic("topic", true, p2p.WithCompression(p2p.SnappyCompression))
+		incompressible := make([]byte, libp2p.MaxSendBuffSize*2)
+		_, _ = rand.Read(incompressible)
+
+		err := messenger.BroadcastOnChannelWithContext(context.Background(), "topic", "topic", incompressible)
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+		assert.Equal(t, p2p.BroadcastDroppedInvalidData, messenger.TryBroadcastOnChannel("topic", "topic", incompressible))
+	})
+	t.Run("compression not enabled should use the uncompressed size limit", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic("topic", true, p2p.WithCompression(p2p.SnappyCompression))
+		compressible := bytes.Repeat([]byte{'A'}, libp2p.MaxSendBuffSize*2)
+
+		err := messenger.BroadcastOnChannelWithContext(context.Background(), "topic", "topic", compressible)
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+	})
+	t.Run("full queue should wait until the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		// the messenger's sending go routine collects from the initial load balancer, so the new queues are not emptied
+		messenger.SetLoadBalancer(libp2p.NewOutgoingChannelLoadBalancer())
+		_ = messenger.CreateTopic("topic", true, p2p.WithOutgoingQueue(1, p2p.DropNewestPolicy))
+
+		err := messenger.BroadcastOnChannelWithContext(context.Background(), "topic", "topic", []byte("buff"))
+		require.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+		err = messenger.BroadcastOnChannelWithContext(ctx, "topic", "topic", []byte("buff"))
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, p2p.BroadcastDroppedQueueFull, messenger.TryBroadcastOnChannel("topic", "topic", []byte("buff")))
+	})
+}
+
 func TestLibp2pMessenger_BroadcastDataBetween2PeersWithLargeMsgShouldWork(t *testing.T) {
 	msg := bytes.Repeat([]byte{'A'}, libp2p.MaxSendBuffSize)
 
@@ -722,6 +943,98 @@ func TestLibp2pMessenger_BroadcastDataBetween2PeersWithLargeMsgShouldWork(t *tes
 	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
 }
 
+func TestLibp2pMessenger_BroadcastDataBetween2PeersWithCompressionShouldWork(t *testing.T) {
+	msg := bytes.Repeat([]byte{'A'}, libp2p.MaxSendBuffSize*2)
+
+	args := createMockNetworkArgs()
+	args.P2pConfig.TopicCompression.Enabled = true
+	netw := mocknet.New()
+	messenger1, _ := libp2p.NewMockMessenger(args, netw)
+	messenger2, _ := libp2p.NewMockMessenger(createMockNetworkArgs(), netw)
+	_ = netw.LinkAll()
+	defer closeMessengers(messenger1, messenger2)
+
+	adr2 := messenger2.Addresses()[0]
+
+	fmt.Printf("Connecting to %s...\n", adr2)
+
+	_ = messenger1.ConnectToPeer(adr2)
+
+	wg := &sync.WaitGroup{}
+	chanDone := make(chan bool)
+	wg.Add(2)
+
+	go func() {
+		wg.Wait()
+		chanDone <- true
+	}()
+
+	err := messenger1.CreateTopic(testTopic, false, p2p.WithCompression(p2p.ZstdCompression))
+	require.Nil(t, err)
+	prepareMessengerForMatchDataReceive(messenger1, msg, wg, noSigCheckHandler)
+	prepareMessengerForMatchDataReceive(messenger2, msg, wg, noSigCheckHandler)
+
+	fmt.Println("Delaying as to allow peers to announce themselves on the opened topic...")
+	time.Sleep(time.Second)
+
+	fmt.Printf("sending message from %s...\n", messenger1.ID().Pretty())
+
+	messenger1.Broadcast(testTopic, msg)
+
+	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
+}
+
+func TestLibp2pMessenger_CreateTopicWithUnknownCompressionShouldErr(t *testing.T) {
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	err := messenger.CreateTopic("test", false, p2p.WithCompression("unknown"))
+	assert.NotNil(t, err)
+	assert.False(t, messenger.HasTopic("test"))
+}
+
+func TestLibp2pMessenger_CreateTopicWithInvalidOutgoingChannelOptionsShouldErr(t *testing.T) {
+	messenger := createMockMessenger()
+	defer closeMessengers(messenger)
+
+	err := messenger.CreateTopic("test", true, p2p.WithOutgoingQueue(10, "unknown"))
+	assert.True(t, errors.Is(err, p2p.ErrInvalidDropPolicy))
+	assert.False(t, messenger.HasTopic("test"))
+
+	err = messenger.CreateTopic("test", true, p2p.WithPriority(1, 0))
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.False(t, messenger.HasTopic("test"))
+
+	// the outgoing channel options are not used if the channel is not created
+	err = messenger.CreateTopic("test", false, p2p.WithPriority(1, 0))
+	assert.Nil(t, err)
+	assert.True(t, messenger.HasTopic("test"))
+}
+
+func TestLibp2pMessenger_CreateTopicWithOutgoingChannelOptionsShouldWork(t *testing.T) {
+	messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger)
+
+	var providedOptions p2p.OutgoingChannelOptions
+	messenger.SetLoadBalancer(&mock.ChannelLoadBalancerStub{
+		AddChannelCalled: func(pipe string, options p2p.OutgoingChannelOptions) error {
+			providedOptions = options
+			return nil
+		},
+	})
+
+	err := messenger.CreateTopic("test", true, p2p.WithPriority(10, 2), p2p.WithOutgoingQueue(5, p2p.DropOldestPolicy))
+	assert.Nil(t, err)
+
+	expectedOptions := p2p.OutgoingChannelOptions{
+		Priority:   10,
+		Weight:     2,
+		QueueSize:  5,
+		DropPolicy: p2p.DropOldestPolicy,
+	}
+	assert.Equal(t, expectedOptions, providedOptions)
+}
+
 func TestLibp2pMessenger_Peers(t *testing.T) {
 	_, messenger1, messenger2 := createMockNetworkOf2()
 	defer closeMessengers(messenger1, messenger2)
@@ -1217,6 +1530,63 @@ func TestLibp2pMessenger_SendDirectWithRealMessengersShouldWork(t *testing.T) {
 	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
 }
 
+func TestLibp2pMessenger_SendDirectWithAntifloodShouldRejectFlooding(t *testing.T) {
+	numMessages := 10
+	maxMessagesPerSec := 2
+
+	args := createMockNetworkArgs()
+	args.P2pConfig.Antiflood = config.AntifloodConfig{
+		Enabled:               true,
+		PeerMaxMessagesPerSec: uint32(maxMessagesPerSec),
+	}
+	messenger1, _ := libp2p.NewNetworkMessenger(args)
+
+	numDecreases := uint32(0)
+	args = createMockNetworkArgs()
+	args.P2pConfig.Antiflood = config.AntifloodConfig{
+		Enabled:               true,
+		PeerMaxMessagesPerSec: uint32(maxMessagesPerSec),
+	}
+	args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+		DecreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+			assert.Equal(t, messenger1.ID(), pid)
+			assert.Equal(t, p2p.FloodingRatingReason, reason)
+			atomic.AddUint32(&numDecreases, 1)
+		},
+	}
+	messenger2, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger1, messenger2)
+
+	err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+	require.Nil(t, err)
+
+	numReceived := uint32(0)
+	_ = messenger1.CreateTopic(testTopic, false)
+	_ = messenger2.CreateTopic(testTopic, false)
+	_ = messenger2.RegisterMessageProcessor(testTopic, "identifier",
+		&mock.MessageProcessorStub{
+			ProcessMessageCalled: func(message p2p.MessageP2P, _ core.PeerID) error {
+				atomic.AddUint32(&numReceived, 1)
+				return nil
+			},
+		})
+
+	time.Sleep(time.Second)
+
+	for i := 0; i < numMessages; i++ {
+		err = messenger1.SendToConnectedPeer(testTopic, []byte(fmt.Sprintf("message %d", i)), messenger2.ID())
+		assert.Nil(t, err)
+	}
+
+	time.Sleep(time.Second)
+
+	// the messages were sent in a burst, so only a few of them can be accepted until the token bucket refills
+	received := atomic.LoadUint32(&numReceived)
+	assert.True(t, received >= uint32(maxMessagesPerSec))
+	assert.True(t, received < uint32(numMessages))
+	assert.Equal(t, uint32(numMessages)-received, atomic.LoadUint32(&numDecreases))
+}
+
 func TestLibp2pMessenger_SendDirectWithRealMessengersWithoutSignatureShouldWork(t *testing.T) {
 	msg := []byte("test message")
 
@@ -1290,6 +1660,50 @@ func TestLibp2pMessenger_SendDirectWithRealMessengersWithoutSignatureShouldWork(
 	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
 }
 
+func TestLibp2pMessenger_SendChunkedWithRealMessengersShouldWork(t *testing.T) {
+	msg := bytes.Repeat([]byte{'A'}, libp2p.MaxSendBuffSize*3)
+
+	messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger1, messenger2)
+
+	err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+	require.Nil(t, err)
+
+	wg := &sync.WaitGroup{}
+	chanDone := make(chan bool)
+	wg.Add(1)
+
+	go func() {
+		wg.Wait()
+		chanDone <- true
+	}()
+
+	_ = messenger1.CreateTopic(testTopic, false)
+	prepareMessengerForMatchDataReceive(messenger2, msg, wg, noSigCheckHandler)
+
+	err = messenger1.SendToConnectedPeer(testTopic, msg, messenger2.ID())
+	assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+
+	err = messenger1.SendChunkedToConnectedPeer(testTopic, msg, messenger2.ID())
+	assert.Nil(t, err)
+
+	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
+}
+
+func TestLibp2pMessenger_SendChunkedToConnectedPeerWithInvalidDataShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, messenger1, _ := createMockNetworkOf2()
+	defer closeMessengers(messenger1)
+
+	err := messenger1.SendChunkedToConnectedPeer(testTopic, nil, "pid")
+	assert.Equal(t, p2p.ErrEmptyBufferToSend, err)
+
+	err = messenger1.SendChunkedToConnectedPeer(testTopic, make([]byte, libp2p.MaxChunkedPayloadSize+1), "pid")
+	assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+}
+
 func TestLibp2pMessenger_SendDirectWithRealNetToConnectedPeerShouldWork(t *testing.T) {
 	msg := []byte("test message")
 
@@ -1330,7 +1744,63 @@ func TestLibp2pMessenger_SendDirectWithRealNetToConnectedPeerShouldWork(t *testi
 	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
 }
 
-func TestLibp2pMessenger_SendDirectWithRealNetToSelfShouldWork(t *testing.T) {
+func createCompressedDirectSendNetworkArgs() libp2p.ArgsNetworkMessenger {
+	args := createMockNetworkArgs()
+	args.P2pConfig.DirectSend = config.DirectSendConfig{
+		CompressedProtocolEnabled: true,
+		Compression:               p2p.SnappyCompression,
+	}
+
+	return args
+}
+
+func TestLibp2pMessenger_SendDirectWithMixedProtocolVersionsShouldWork(t *testing.T) {
+	msg := []byte("test message")
+
+	fmt.Println("Messenger compressed 1:")
+	messengerCompressed1, _ := libp2p.NewNetworkMessenger(createCompressedDirectSendNetworkArgs())
+
+	fmt.Println("Messenger compressed 2:")
+	messengerCompressed2, _ := libp2p.NewNetworkMessenger(createCompressedDirectSendNetworkArgs())
+
+	fmt.Println("Messenger legacy:")
+	messengerLegacy, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messengerCompressed1, messengerCompressed2, messengerLegacy)
+
+	err := messengerCompressed1.ConnectToPeer(getConnectableAddress(messengerCompressed2))
+	require.Nil(t, err)
+	err = messengerCompressed1.ConnectToPeer(getConnectableAddress(messengerLegacy))
+	require.Nil(t, err)
+	err = messengerLegacy.ConnectToPeer(getConnectableAddress(messengerCompressed2))
+	require.Nil(t, err)
+
+	wg := &sync.WaitGroup{}
+	chanDone := make(chan bool)
+	wg.Add(3)
+
+	go func() {
+		wg.Wait()
+		chanDone <- true
+	}()
+
+	prepareMessengerForMatchDataReceive(messengerCompressed2, msg, wg, noSigCheckHandler)
+	prepareMessengerForMatchDataReceive(messengerLegacy, msg, wg, noSigCheckHandler)
+
+	fmt.Println("Delaying as to allow peers to announce themselves on the opened topic...")
+	time.Sleep(time.Second)
+
+	// compressed -> compressed uses the 2.0.0 protocol, the other two pairs fall back to 1.0.0
+	err = messengerCompressed1.SendToConnectedPeer(testTopic, msg, messengerCompressed2.ID())
+	assert.Nil(t, err)
+	err = messengerCompressed1.SendToConnectedPeer(testTopic, msg, messengerLegacy.ID())
+	assert.Nil(t, err)
+	err = messengerLegacy.SendToConnectedPeer(testTopic, msg, messengerCompressed2.ID())
+	assert.Nil(t, err)
+
+	waitDoneWithTimeout(t, chanDone, timeoutWaitResponses)
+}
+
+func TestLibp2pMessenger_SendDirectWithRealNetToSelfShouldWork(t *testing.T) {
 	msg := []byte("test message")
 
 	fmt.Println("Messenger 1:")
@@ -1602,6 +2072,143 @@ func TestNetworkMessenger_PubsubCallbackNotMessageNotValidShouldNotCallHandler(t
 	assert.Equal(t, int32(2), atomic.LoadInt32(&numUpserts))
 }
 
+func TestNetworkMessenger_PubsubCallbackNotDecompressableMessageShouldNotBlacklist(t *testing.T) {
+	args := createMockNetworkArgs()
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	numUpserts := int32(0)
+	_ = messenger.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{
+		UpsertPeerIDCalled: func(pid core.PeerID, duration time.Duration) error {
+			atomic.AddInt32(&numUpserts, 1)
+			return nil
+		},
+		IsDeniedCalled: func(pid core.PeerID) bool {
+			return false
+		},
+	})
+
+	numCalled := uint32(0)
+	handler := &mock.MessageProcessorStub{
+		ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+			atomic.AddUint32(&numCalled, 1)
+			return nil
+		},
+	}
+
+	callBackFunc := messenger.PubsubCallback(handler, testTopic)
+	innerMessage := &data.TopicMessage{
+		Version:     libp2p.CompressedTopicMessageVersion,
+		Payload:     []byte("data"),
+		Timestamp:   time.Now().Unix(),
+		Compression: 100,
+	}
+	buff, _ := args.Marshalizer.Marshal(innerMessage)
+	topic := testTopic
+	msg := &pubsub.Message{
+		Message: &pb.Message{
+			From:  messenger.ID().Bytes(),
+			Data:  buff,
+			Seqno: []byte{0, 0, 0, 1},
+			Topic: &topic,
+		},
+	}
+
+	assert.False(t, callBackFunc(context.Background(), "connected peer", msg))
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&numCalled))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&numUpserts))
+}
+
+func TestNetworkMessenger_PubsubCallbackNewerMessageVersionShouldNotBlacklist(t *testing.T) {
+	args := createMockNetworkArgs()
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	numUpserts := int32(0)
+	_ = messenger.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{
+		UpsertPeerIDCalled: func(pid core.PeerID, duration time.Duration) error {
+			atomic.AddInt32(&numUpserts, 1)
+			return nil
+		},
+		IsDeniedCalled: func(pid core.PeerID) bool {
+			return false
+		},
+	})
+
+	numCalled := uint32(0)
+	handler := &mock.MessageProcessorStub{
+		ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+			atomic.AddUint32(&numCalled, 1)
+			return nil
+		},
+	}
+
+	callBackFunc := messenger.PubsubCallback(handler, testTopic)
+	innerMessage := &data.TopicMessage{
+		Version:   libp2p.CompressedTopicMessageVersion + 1,
+		Payload:   []byte("data"),
+		Timestamp: time.Now().Unix(),
+	}
+	buff, _ := args.Marshalizer.Marshal(innerMessage)
+	topic := testTopic
+	msg := &pubsub.Message{
+		Message: &pb.Message{
+			From:  messenger.ID().Bytes(),
+			Data:  buff,
+			Seqno: []byte{0, 0, 0, 1},
+			Topic: &topic,
+		},
+	}
+
+	assert.False(t, callBackFunc(context.Background(), "connected peer", msg))
+	assert.Equal(t, uint32(0), atomic.LoadUint32(&numCalled))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&numUpserts))
+}
+
+func TestLibp2pMessenger_CompressionIsUsedOnlyIfEnabled(t *testing.T) {
+	type compressionMessenger interface {
+		p2p.Messenger
+		CreateMessageBytes(topic string, buff []byte) ([]byte, error)
+	}
+
+	getVersion := func(netMes compressionMessenger) uint32 {
+		buff, err := netMes.CreateMessageBytes(testTopic, []byte("data"))
+		require.Nil(t, err)
+
+		topicMessage := &data.TopicMessage{}
+		_ = (&mock.ProtoMarshallerMock{}).Unmarshal(topicMessage, buff)
+
+		return topicMessage.Version
+	}
+
+	t.Run("not enabled should send uncompressed", func(t *testing.T) {
+		messenger, _ := libp2p.NewMockMessenger(createMockNetworkArgs(), mocknet.New())
+		defer closeMessengers(messenger)
+
+		err := messenger.CreateTopic(testTopic, false, p2p.WithCompression(p2p.SnappyCompression))
+		require.Nil(t, err)
+
+		assert.Equal(t, libp2p.CurrentTopicMessageVersion, getVersion(messenger))
+	})
+	t.Run("enabled should always compress, regardless of the connected peers", func(t *testing.T) {
+		args := createMockNetworkArgs()
+		args.P2pConfig.TopicCompression.Enabled = true
+		netw := mocknet.New()
+		messenger1, _ := libp2p.NewMockMessenger(args, netw)
+		messenger2, _ := libp2p.NewMockMessenger(createMockNetworkArgs(), netw)
+		_ = netw.LinkAll()
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.CreateTopic(testTopic, false, p2p.WithCompression(p2p.SnappyCompression))
+		require.Nil(t, err)
+		assert.Equal(t, libp2p.CompressedTopicMessageVersion, getVersion(messenger1))
+
+		err = messenger1.ConnectToPeer(messenger2.Addresses()[0])
+		require.Nil(t, err)
+		assert.Equal(t, libp2p.CompressedTopicMessageVersion, getVersion(messenger1))
+	})
+}
+
 func TestNetworkMessenger_PubsubCallbackReturnsFalseIfHandlerErrors(t *testing.T) {
 	args := libp2p.ArgsNetworkMessenger{
 		Marshalizer: &mock.ProtoMarshallerMock{},
@@ -1705,6 +2312,74 @@ func TestNetworkMessenger_UnjoinAllTopicsShouldWork(t *testing.T) {
 	assert.False(t, messenger.HasTopic(topic))
 }
 
+func TestNetworkMessenger_LeaveTopic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not created topic should not notify", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		_ = messenger.AddPeerTopicNotifier(&mock.PeerTopicNotifierStub{
+			TopicLeftCalled: func(topic string) {
+				assert.Fail(t, "should have not notified")
+			},
+		})
+
+		err := messenger.LeaveTopic("topic")
+		assert.Nil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		topic := "topic"
+		otherTopic := "other topic"
+		_ = messenger.CreateTopic(topic, true)
+		_ = messenger.CreateTopic(otherTopic, true)
+		_ = messenger.RegisterMessageProcessor(topic, "identifier", &mock.MessageProcessorStub{})
+		_ = messenger.RegisterMessageProcessor(otherTopic, "identifier", &mock.MessageProcessorStub{})
+
+		removedChannels := make([]string, 0)
+		messenger.SetLoadBalancer(&mock.ChannelLoadBalancerStub{
+			AddChannelCalled: func(pipe string, options p2p.OutgoingChannelOptions) error {
+				return nil
+			},
+			RemoveChannelCalled: func(pipe string) error {
+				removedChannels = append(removedChannels, pipe)
+				return nil
+			},
+		})
+		topicsLeft := make([]string, 0)
+		_ = messenger.AddPeerTopicNotifier(&mock.PeerTopicNotifierStub{
+			TopicLeftCalled: func(topic string) {
+				topicsLeft = append(topicsLeft, topic)
+			},
+		})
+
+		err := messenger.LeaveTopic(topic)
+		assert.Nil(t, err)
+
+		assert.False(t, messenger.HasTopic(topic))
+		assert.False(t, messenger.PubsubHasTopic(topic))
+		assert.False(t, messenger.HasProcessorForTopic(topic))
+		assert.Equal(t, []string{topic}, removedChannels)
+		assert.Equal(t, []string{topic}, topicsLeft)
+
+		assert.True(t, messenger.HasTopic(otherTopic))
+		assert.True(t, messenger.PubsubHasTopic(otherTopic))
+		assert.True(t, messenger.HasProcessorForTopic(otherTopic))
+
+		// the topic can be joined again
+		err = messenger.CreateTopic(topic, true)
+		assert.Nil(t, err)
+		assert.True(t, messenger.HasTopic(topic))
+	})
+}
+
 func TestNetworkMessenger_ValidMessageByTimestampMessageTooOld(t *testing.T) {
 	args := createMockNetworkArgs()
 	now := time.Now()
@@ -2335,3 +3010,797 @@ func TestParseTransportOptions(t *testing.T) {
 		})
 	})
 }
+
+func TestNetworkMessenger_RegisterRequestHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		err := messenger.RegisterRequestHandler(testTopic, nil)
+		assert.True(t, errors.Is(err, p2p.ErrNilRequestHandler))
+	})
+	t.Run("reregistration should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		err := messenger.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{})
+		assert.Nil(t, err)
+
+		err = messenger.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{})
+		assert.True(t, errors.Is(err, p2p.ErrRequestHandlerAlreadyDefined))
+	})
+	t.Run("register after unregister should work", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		err := messenger.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{})
+		assert.Nil(t, err)
+
+		err = messenger.UnregisterRequestHandler(testTopic)
+		assert.Nil(t, err)
+
+		err = messenger.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{})
+		assert.Nil(t, err)
+	})
+}
+
+func TestNetworkMessenger_Request(t *testing.T) {
+	t.Parallel()
+
+	requestData := []byte("request")
+	responseData := []byte("response")
+
+	t.Run("empty buffer should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		response, err := messenger1.Request(context.Background(), testTopic, nil, messenger2.ID())
+		assert.Nil(t, response)
+		assert.Equal(t, p2p.ErrEmptyBufferToSend, err)
+	})
+	t.Run("not connected peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		response, err := messenger1.Request(context.Background(), testTopic, requestData, messenger2.ID())
+		assert.Nil(t, response)
+		assert.Equal(t, p2p.ErrPeerNotDirectlyConnected, err)
+	})
+	t.Run("request to a connected peer should work", func(t *testing.T) {
+		t.Parallel()
+
+		increasedReasons := make(map[core.PeerID]p2p.RatingReason)
+		mutIncreasedReasons := sync.Mutex{}
+		args := createMockNetworkArgs()
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			IncreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				mutIncreasedReasons.Lock()
+				increasedReasons[pid] = reason
+				mutIncreasedReasons.Unlock()
+			},
+		}
+		messenger1, _ := libp2p.NewNetworkMessenger(args)
+		args = createMockNetworkArgs()
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			IncreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				mutIncreasedReasons.Lock()
+				increasedReasons[pid] = reason
+				mutIncreasedReasons.Unlock()
+			},
+		}
+		messenger2, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		err = messenger2.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{
+			ProcessRequestCalled: func(request p2p.MessageP2P, fromConnectedPeer core.PeerID) ([]byte, error) {
+				assert.Equal(t, requestData, request.Data())
+				assert.Equal(t, testTopic, request.Topic())
+				assert.Equal(t, messenger1.ID(), fromConnectedPeer)
+
+				return responseData, nil
+			},
+		})
+		require.Nil(t, err)
+
+		response, err := messenger1.Request(context.Background(), testTopic, requestData, messenger2.ID())
+		assert.Nil(t, err)
+		assert.Equal(t, responseData, response)
+
+		mutIncreasedReasons.Lock()
+		// the requester rates the responder and the responder rates the requester
+		assert.Equal(t, p2p.ValidDirectResponseRatingReason, increasedReasons[messenger2.ID()])
+		assert.Equal(t, p2p.ValidRequestRatingReason, increasedReasons[messenger1.ID()])
+		mutIncreasedReasons.Unlock()
+	})
+	t.Run("request timeout should decrease the rating", func(t *testing.T) {
+		t.Parallel()
+
+		var decreasedReason p2p.RatingReason
+		args := createMockNetworkArgs()
+		args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+			DecreaseRatingWithReasonCalled: func(pid core.PeerID, reason p2p.RatingReason) {
+				decreasedReason = reason
+			},
+		}
+		messenger1, _ := libp2p.NewNetworkMessenger(args)
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		err = messenger2.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{
+			ProcessRequestCalled: func(request p2p.MessageP2P, fromConnectedPeer core.PeerID) ([]byte, error) {
+				time.Sleep(time.Second)
+				return responseData, nil
+			},
+		})
+		require.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+		defer cancel()
+		response, err := messenger1.Request(ctx, testTopic, requestData, messenger2.ID())
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, p2p.ErrRequestTimeout))
+		assert.Equal(t, p2p.TimeoutRatingReason, decreasedReason)
+	})
+	t.Run("request on a topic without handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		response, err := messenger1.Request(context.Background(), testTopic, requestData, messenger2.ID())
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, p2p.ErrRequestRejected))
+		assert.Contains(t, err.Error(), p2p.ErrNoRequestHandlerForTopic.Error())
+	})
+	t.Run("request to self should work", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		err := messenger.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{
+			ProcessRequestCalled: func(request p2p.MessageP2P, fromConnectedPeer core.PeerID) ([]byte, error) {
+				return responseData, nil
+			},
+		})
+		require.Nil(t, err)
+
+		response, err := messenger.Request(context.Background(), testTopic, requestData, messenger.ID())
+		assert.Nil(t, err)
+		assert.Equal(t, responseData, response)
+	})
+	t.Run("handler error on self request should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		expectedErr := errors.New("expected error")
+		err := messenger.RegisterRequestHandler(testTopic, &mock.RequestHandlerStub{
+			ProcessRequestCalled: func(request p2p.MessageP2P, fromConnectedPeer core.PeerID) ([]byte, error) {
+				return nil, expectedErr
+			},
+		})
+		require.Nil(t, err)
+
+		response, err := messenger.Request(context.Background(), testTopic, requestData, messenger.ID())
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, p2p.ErrRequestRejected))
+		assert.Contains(t, err.Error(), expectedErr.Error())
+	})
+}
+
+func TestNetworkMessenger_SendToConnectedPeerWithAck(t *testing.T) {
+	t.Parallel()
+
+	sentData := []byte("data")
+
+	t.Run("empty buffer should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.SendToConnectedPeerWithAck(context.Background(), testTopic, nil, messenger2.ID())
+		assert.Equal(t, p2p.ErrEmptyBufferToSend, err)
+	})
+	t.Run("not connected peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.SendToConnectedPeerWithAck(context.Background(), testTopic, sentData, messenger2.ID())
+		assert.Equal(t, p2p.ErrPeerNotDirectlyConnected, err)
+	})
+	t.Run("send to a connected peer should work", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		chanReceived := make(chan p2p.MessageP2P, 1)
+		_ = messenger2.CreateTopic(testTopic, false)
+		err = messenger2.RegisterMessageProcessor(testTopic, "identifier", &mock.MessageProcessorStub{
+			ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+				chanReceived <- message
+				return nil
+			},
+		})
+		require.Nil(t, err)
+
+		err = messenger1.SendToConnectedPeerWithAck(context.Background(), testTopic, sentData, messenger2.ID())
+		assert.Nil(t, err)
+
+		select {
+		case msg := <-chanReceived:
+			assert.Equal(t, sentData, msg.Data())
+			assert.Equal(t, messenger1.ID(), msg.Peer())
+		case <-time.After(timeout):
+			assert.Fail(t, "timeout while waiting for the message")
+		}
+	})
+	t.Run("send on a topic without processors should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		err = messenger1.SendToConnectedPeerWithAck(context.Background(), testTopic, sentData, messenger2.ID())
+		assert.True(t, errors.Is(err, p2p.ErrDirectMessageRejected))
+		assert.Contains(t, err.Error(), p2p.ErrNilValidator.Error())
+	})
+	t.Run("send to self should work", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		_ = messenger.CreateTopic(testTopic, false)
+		err := messenger.RegisterMessageProcessor(testTopic, "identifier", &mock.MessageProcessorStub{})
+		require.Nil(t, err)
+
+		err = messenger.SendToConnectedPeerWithAck(context.Background(), testTopic, sentData, messenger.ID())
+		assert.Nil(t, err)
+	})
+	t.Run("send to self on a topic without processors should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger := createMockMessenger()
+		defer closeMessengers(messenger)
+
+		err := messenger.SendToConnectedPeerWithAck(context.Background(), testTopic, sentData, messenger.ID())
+		assert.True(t, errors.Is(err, p2p.ErrDirectMessageRejected))
+	})
+}
+
+func TestNetworkMessenger_SendToPeer(t *testing.T) {
+	t.Parallel()
+
+	type temporaryConnectionsMessenger interface {
+		p2p.Messenger
+		Disconnect(pid core.PeerID) error
+		IsTemporaryConnection(pid core.PeerID) bool
+		SetTemporaryConnectionsTimeHandler(handler func() time.Time)
+	}
+
+	sentData := []byte("data")
+
+	createConnectedThenDisconnectedMessengers := func(t *testing.T, args1 libp2p.ArgsNetworkMessenger) (temporaryConnectionsMessenger, p2p.Messenger) {
+		messenger1, _ := libp2p.NewNetworkMessenger(args1)
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+
+		// the peerstore will keep the addresses of messenger2 after the disconnection
+		_ = messenger1.Disconnect(messenger2.ID())
+		require.Eventually(t, func() bool {
+			return !messenger1.IsConnected(messenger2.ID())
+		}, timeout, time.Millisecond*10)
+
+		return messenger1, messenger2
+	}
+	registerReceiver := func(t *testing.T, messenger p2p.Messenger) chan p2p.MessageP2P {
+		chanReceived := make(chan p2p.MessageP2P, 1)
+		_ = messenger.CreateTopic(testTopic, false)
+		err := messenger.RegisterMessageProcessor(testTopic, "identifier", &mock.MessageProcessorStub{
+			ProcessMessageCalled: func(message p2p.MessageP2P, fromConnectedPeer core.PeerID) error {
+				chanReceived <- message
+				return nil
+			},
+		})
+		require.Nil(t, err)
+
+		return chanReceived
+	}
+	waitMessage := func(t *testing.T, chanReceived chan p2p.MessageP2P) {
+		select {
+		case msg := <-chanReceived:
+			assert.Equal(t, sentData, msg.Data())
+		case <-time.After(timeout):
+			assert.Fail(t, "timeout while waiting for the message")
+		}
+	}
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		var ctx context.Context = nil
+		err := messenger1.SendToPeer(ctx, testTopic, sentData, messenger2.ID(), false)
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("empty buffer should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, nil, messenger2.ID(), false)
+		assert.Equal(t, p2p.ErrEmptyBufferToSend, err)
+	})
+	t.Run("unknown peer addresses should error", func(t *testing.T) {
+		t.Parallel()
+
+		_, messenger1, messenger2 := createMockNetworkOf2()
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), false)
+		assert.True(t, errors.Is(err, p2p.ErrPeerAddressesNotFound))
+	})
+	t.Run("peer discovery error should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		expectedErr := errors.New("expected error")
+		messenger1.SetPeerDiscoverer(&mock.PeerDiscovererStub{
+			FindPeerCalled: func(ctx context.Context, pid peer.ID) (peer.AddrInfo, error) {
+				return peer.AddrInfo{}, expectedErr
+			},
+		})
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), false)
+		assert.True(t, errors.Is(err, p2p.ErrPeerAddressesNotFound))
+		assert.Contains(t, err.Error(), expectedErr.Error())
+	})
+	t.Run("denied peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, messenger2 := createConnectedThenDisconnectedMessengers(t, createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		_ = messenger1.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{
+			IsDeniedCalled: func(pid core.PeerID) bool {
+				return pid == messenger2.ID()
+			},
+		})
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), false)
+		assert.True(t, errors.Is(err, p2p.ErrDeniedPeer))
+		assert.False(t, messenger1.IsConnected(messenger2.ID()))
+	})
+	t.Run("connected peer should send without a temporary connection", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		err := messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+		chanReceived := registerReceiver(t, messenger2)
+
+		err = messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), true)
+		assert.Nil(t, err)
+		waitMessage(t, chanReceived)
+		assert.False(t, messenger1.IsTemporaryConnection(messenger2.ID()))
+	})
+	t.Run("peer found through the peer discovery should work", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		addrInfo, err := peer.AddrInfoFromString(getConnectableAddress(messenger2))
+		require.Nil(t, err)
+		messenger1.SetPeerDiscoverer(&mock.PeerDiscovererStub{
+			FindPeerCalled: func(ctx context.Context, pid peer.ID) (peer.AddrInfo, error) {
+				return *addrInfo, nil
+			},
+		})
+		chanReceived := registerReceiver(t, messenger2)
+
+		err = messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), true)
+		assert.Nil(t, err)
+		waitMessage(t, chanReceived)
+		assert.True(t, messenger1.IsTemporaryConnection(messenger2.ID()))
+	})
+	t.Run("not kept connection should stay open for the minimum time to live", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, messenger2 := createConnectedThenDisconnectedMessengers(t, createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		chanReceived := registerReceiver(t, messenger2)
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), false)
+		assert.Nil(t, err)
+		waitMessage(t, chanReceived)
+
+		// the temporary connections are checked every second
+		time.Sleep(time.Second * 2)
+		assert.True(t, messenger1.IsConnected(messenger2.ID()))
+		assert.True(t, messenger1.IsTemporaryConnection(messenger2.ID()))
+	})
+	t.Run("expired connection should be closed", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, messenger2 := createConnectedThenDisconnectedMessengers(t, createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		chanReceived := registerReceiver(t, messenger2)
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), true)
+		assert.Nil(t, err)
+		waitMessage(t, chanReceived)
+
+		// the pubsub streams are opened with all the connected peers, yet they should not keep the connection alive
+		messenger1.SetTemporaryConnectionsTimeHandler(func() time.Time {
+			return time.Now().Add(time.Hour)
+		})
+		assert.Eventually(t, func() bool {
+			return !messenger1.IsConnected(messenger2.ID())
+		}, timeout, time.Millisecond*100)
+		assert.False(t, messenger1.IsTemporaryConnection(messenger2.ID()))
+	})
+	t.Run("expired connection of a preferred peer should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.PreferredPeersHolder = &mock.PeersHolderStub{
+			ContainsCalled: func(peerID core.PeerID) bool {
+				return true
+			},
+		}
+		messenger1, messenger2 := createConnectedThenDisconnectedMessengers(t, args)
+		defer closeMessengers(messenger1, messenger2)
+
+		chanReceived := registerReceiver(t, messenger2)
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), false)
+		assert.Nil(t, err)
+		waitMessage(t, chanReceived)
+
+		messenger1.SetTemporaryConnectionsTimeHandler(func() time.Time {
+			return time.Now().Add(time.Hour)
+		})
+		assert.Eventually(t, func() bool {
+			return !messenger1.IsTemporaryConnection(messenger2.ID())
+		}, timeout, time.Millisecond*100)
+		assert.True(t, messenger1.IsConnected(messenger2.ID()))
+	})
+	t.Run("kept connection should stay open until it expires", func(t *testing.T) {
+		t.Parallel()
+
+		messenger1, messenger2 := createConnectedThenDisconnectedMessengers(t, createMockNetworkArgs())
+		defer closeMessengers(messenger1, messenger2)
+
+		chanReceived := registerReceiver(t, messenger2)
+
+		err := messenger1.SendToPeer(context.Background(), testTopic, sentData, messenger2.ID(), true)
+		assert.Nil(t, err)
+		waitMessage(t, chanReceived)
+
+		// the temporary connections are checked every second
+		time.Sleep(time.Second * 2)
+		assert.True(t, messenger1.IsConnected(messenger2.ID()))
+		assert.True(t, messenger1.IsTemporaryConnection(messenger2.ID()))
+	})
+}
+
+func TestNetworkMessenger_MetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	args := createMockNetworkArgs()
+	args.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+		GetTierSizesCalled: func() map[string]int {
+			return map[string]int{
+				"top rated tier": 3,
+			}
+		},
+	}
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	msg := []byte("test message")
+	chanDone := make(chan struct{})
+	_ = messenger.CreateTopic(testTopic, false)
+	_ = messenger.RegisterMessageProcessor(testTopic, "identifier", &mock.MessageProcessorStub{
+		ProcessMessageCalled: func(message p2p.MessageP2P, _ core.PeerID) error {
+			close(chanDone)
+			return nil
+		},
+	})
+
+	err := messenger.SendToConnectedPeer(testTopic, msg, messenger.ID())
+	require.Nil(t, err)
+	select {
+	case <-chanDone:
+	case <-time.After(timeoutWaitResponses):
+		require.Fail(t, "timeout waiting for the message")
+	}
+	time.Sleep(time.Millisecond * 100)
+
+	recorder := httptest.NewRecorder()
+	messenger.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	content := recorder.Body.String()
+
+	assert.Contains(t, content, `p2p_messages_total{direction="incoming",status="accepted",topic="test"} 1`)
+	assert.Contains(t, content, `p2p_connected_peers{category="intra_shard_validators"} 0`)
+	assert.Contains(t, content, `p2p_rating_tier_peers{tier="top rated tier"} 3`)
+	assert.Contains(t, content, "# TYPE p2p_connections_total counter")
+	assert.Contains(t, content, "# TYPE p2p_disconnections_total counter")
+	assert.Contains(t, content, `p2p_outgoing_queue_depth{channel="default send channel"} 0`)
+	assert.Contains(t, content, "# TYPE p2p_outgoing_queued_messages_total counter")
+	assert.Contains(t, content, "# TYPE p2p_outgoing_dropped_messages_total counter")
+}
+
+func TestNetworkMessenger_ConnectionGaterShouldRejectDeniedPeers(t *testing.T) {
+	t.Parallel()
+
+	messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	messenger3, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger1, messenger2, messenger3)
+
+	err := messenger2.SetPeerDenialEvaluator(&mock.PeerDenialEvaluatorStub{
+		IsDeniedCalled: func(pid core.PeerID) bool {
+			return pid == messenger1.ID()
+		},
+	})
+	require.Nil(t, err)
+
+	// inbound connection from a denied peer: the dialer might see the handshake completed before the connection
+	// is dropped by the gater, so only the final state is checked
+	_ = messenger1.ConnectToPeer(getConnectableAddress(messenger2))
+	time.Sleep(time.Millisecond * 200)
+	assert.False(t, messenger2.IsConnected(messenger1.ID()))
+	assert.False(t, messenger1.IsConnected(messenger2.ID()))
+
+	// outbound connection to a denied peer
+	err = messenger2.ConnectToPeer(getConnectableAddress(messenger1))
+	assert.NotNil(t, err)
+	assert.False(t, messenger2.IsConnected(messenger1.ID()))
+
+	err = messenger3.ConnectToPeer(getConnectableAddress(messenger2))
+	assert.Nil(t, err)
+	assert.True(t, messenger2.IsConnected(messenger3.ID()))
+}
+
+func TestNetworkMessenger_ConnectionGaterShouldRejectDeniedAddresses(t *testing.T) {
+	t.Parallel()
+
+	args := createMockNetworkArgs()
+	args.P2pConfig.ConnectionGater.DeniedSubnets = []string{"127.0.0.0/8"}
+	messenger1, _ := libp2p.NewNetworkMessenger(args)
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger1, messenger2)
+
+	err := messenger2.ConnectToPeer(getConnectableAddress(messenger1))
+	assert.NotNil(t, err)
+	assert.False(t, messenger1.IsConnected(messenger2.ID()))
+}
+
+func TestNetworkMessenger_AddressDenialEvaluatorShouldRejectBannedIPs(t *testing.T) {
+	t.Parallel()
+
+	messenger1, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger1, messenger2)
+
+	require.False(t, check.IfNil(messenger1.AddressDenialEvaluator()))
+	err := messenger1.AddressDenialEvaluator().UpsertSubnet("127.0.0.0/8", time.Hour)
+	require.Nil(t, err)
+
+	_ = messenger2.ConnectToPeer(getConnectableAddress(messenger1))
+	time.Sleep(time.Millisecond * 200)
+
+	assert.False(t, messenger1.IsConnected(messenger2.ID()))
+	assert.False(t, messenger2.IsConnected(messenger1.ID()))
+}
+
+func TestNetworkMessenger_AddressDenialEvaluatorShouldLimitPeersPerIP(t *testing.T) {
+	t.Parallel()
+
+	args := createMockNetworkArgs()
+	args.P2pConfig.AddressDenial.MaxPeersPerIP = 1
+	messenger1, _ := libp2p.NewNetworkMessenger(args)
+	messenger2, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	messenger3, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+	defer closeMessengers(messenger1, messenger2, messenger3)
+
+	err := messenger2.ConnectToPeer(getConnectableAddress(messenger1))
+	require.Nil(t, err)
+	_ = messenger3.ConnectToPeer(getConnectableAddress(messenger1))
+	time.Sleep(time.Millisecond * 200)
+
+	assert.True(t, messenger1.IsConnected(messenger2.ID()))
+	assert.False(t, messenger1.IsConnected(messenger3.ID()))
+}
+
+func TestNetworkMessenger_EvictionDecisions(t *testing.T) {
+	t.Parallel()
+
+	args := createMockNetworkArgs()
+	args.P2pConfig.Sharding = config.ShardingConfig{
+		Type:            p2p.OneListSharder,
+		TargetPeerCount: 3,
+	}
+	messenger, _ := libp2p.NewNetworkMessenger(args)
+	defer closeMessengers(messenger)
+
+	numPeers := 4
+	peers := make([]p2p.Messenger, 0, numPeers)
+	for i := 0; i < numPeers; i++ {
+		p, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(p)
+
+		peers = append(peers, p)
+		_ = p.ConnectToPeer(getConnectableAddress(messenger))
+	}
+	time.Sleep(time.Millisecond * 500)
+
+	decision := messenger.LastEvictionDecision()
+	assert.True(t, decision.NumEvicted > 0)
+	assert.Equal(t, numPeers, len(decision.Peers))
+	for _, explanation := range decision.Peers {
+		if explanation.IsEvicted {
+			assert.False(t, messenger.IsConnected(explanation.Pid))
+		}
+	}
+
+	decision = messenger.ExplainEviction()
+	assert.Equal(t, len(messenger.ConnectedPeers()), len(decision.Peers))
+	assert.Equal(t, 0, decision.NumEvicted)
+}
+
+func TestNetworkMessenger_SeedersStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("peer discovery not tracking the seeders should return empty", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		status := messenger.SeedersStatus()
+		assert.NotNil(t, status)
+		assert.Empty(t, status)
+	})
+	t.Run("optimized kad dht should return the seeders status", func(t *testing.T) {
+		t.Parallel()
+
+		seeder := "/ip4/127.0.0.1/tcp/9999/p2p/16Uiu2HAkw5SNNtSvH1zJiQ6Gc3WoGNSxiyNueRKe6fuAuh57G3Bk"
+		args := createMockNetworkArgs()
+		args.P2pConfig.KadDhtPeerDiscovery = config.KadDhtPeerDiscoveryConfig{
+			Enabled:                          true,
+			Type:                             "optimized",
+			RefreshIntervalInSec:             10,
+			ProtocolID:                       "/erd/kad/1.0.0",
+			InitialPeerList:                  []string{seeder},
+			BucketSize:                       100,
+			RoutingTableRefreshIntervalInSec: 10,
+		}
+		messenger, err := libp2p.NewNetworkMessenger(args)
+		require.Nil(t, err)
+		defer closeMessengers(messenger)
+
+		status := messenger.SeedersStatus()
+		require.Equal(t, 1, len(status))
+		assert.Equal(t, seeder, status[0].Address)
+		assert.True(t, status[0].IsHealthy)
+		assert.True(t, status[0].LastAttempt.IsZero())
+	})
+}
+
+func TestNetworkMessenger_UpdateShardingConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("different sharder type should error", func(t *testing.T) {
+		t.Parallel()
+
+		messenger, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+		defer closeMessengers(messenger)
+
+		err := messenger.UpdateShardingConfig(config.ShardingConfig{
+			Type:            p2p.OneListSharder,
+			TargetPeerCount: 3,
+		})
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid config should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.Sharding = config.ShardingConfig{
+			Type:            p2p.OneListSharder,
+			TargetPeerCount: 5,
+		}
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		err := messenger.UpdateShardingConfig(config.ShardingConfig{
+			Type:            p2p.OneListSharder,
+			TargetPeerCount: 1,
+		})
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("should apply the new quotas and evict", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockNetworkArgs()
+		args.P2pConfig.Sharding = config.ShardingConfig{
+			Type:            p2p.OneListSharder,
+			TargetPeerCount: 5,
+		}
+		messenger, _ := libp2p.NewNetworkMessenger(args)
+		defer closeMessengers(messenger)
+
+		numPeers := 4
+		for i := 0; i < numPeers; i++ {
+			p, _ := libp2p.NewNetworkMessenger(createMockNetworkArgs())
+			defer closeMessengers(p)
+
+			_ = p.ConnectToPeer(getConnectableAddress(messenger))
+		}
+		time.Sleep(time.Millisecond * 500)
+		require.Equal(t, numPeers, len(messenger.ConnectedPeers()))
+
+		err := messenger.UpdateShardingConfig(config.ShardingConfig{
+			Type:            p2p.OneListSharder,
+			TargetPeerCount: 3,
+		})
+		require.Nil(t, err)
+		time.Sleep(time.Millisecond * 200)
+
+		assert.Equal(t, 3, len(messenger.ConnectedPeers()))
+		assert.Equal(t, 1, messenger.LastEvictionDecision().NumEvicted)
+	})
+}
@@ -0,0 +1,111 @@
+package networksharding
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/networksharding/sorting"
+)
+
+const distanceCriteria = "the closest peers are kept"
+const scoreCriteria = "the best scored peers are kept"
+
+// categoryEviction holds the outcome of the eviction computed for the peers of one category
+type categoryEviction struct {
+	category  p2p.EvictionCategory
+	distances sorting.PeerDistances
+	numKeep   int
+	evicted   []peer.ID
+}
+
+// preferredPeer holds a preferred peer together with the category it would have been placed in
+type preferredPeer struct {
+	distance *sorting.PeerDistance
+	category p2p.EvictionCategory
+}
+
+func collectEvicted(categories []*categoryEviction) []peer.ID {
+	evicted := make([]peer.ID, 0)
+	for _, ce := range categories {
+		evicted = append(evicted, ce.evicted...)
+	}
+
+	return evicted
+}
+
+func createEvictionDecision(
+	categories []*categoryEviction,
+	preferred []*preferredPeer,
+	criteria string,
+) p2p.EvictionDecision {
+	decision := p2p.EvictionDecision{
+		Timestamp: time.Now(),
+		Peers:     make([]p2p.PeerEvictionExplanation, 0),
+	}
+
+	for _, ce := range categories {
+		evictedMap := make(map[peer.ID]struct{}, len(ce.evicted))
+		for _, pid := range ce.evicted {
+			evictedMap[pid] = struct{}{}
+		}
+		numKept := len(ce.distances) - len(ce.evicted)
+
+		for _, pd := range ce.distances {
+			_, isEvicted := evictedMap[pd.ID]
+			reason := fmt.Sprintf("kept, %d out of %d %s peers are kept", numKept, len(ce.distances), ce.category)
+			if isEvicted {
+				reason = fmt.Sprintf("evicted, only %d out of %d %s peers are kept and %s",
+					numKept, len(ce.distances), ce.category, criteria)
+				decision.NumEvicted++
+			}
+
+			decision.Peers = append(decision.Peers, p2p.PeerEvictionExplanation{
+				Pid:       core.PeerID(pd.ID),
+				Category:  ce.category,
+				Distance:  pd.Distance,
+				IsEvicted: isEvicted,
+				Reason:    reason,
+			})
+		}
+	}
+
+	for _, pp := range preferred {
+		decision.Peers = append(decision.Peers, p2p.PeerEvictionExplanation{
+			Pid:         core.PeerID(pp.distance.ID),
+			Category:    pp.category,
+			Distance:    pp.distance.Distance,
+			IsPreferred: true,
+			Reason:      "kept, preferred peers are never evicted",
+		})
+	}
+
+	return decision
+}
+
+// lastEvictionDecisionHolder keeps the last decision that evicted at least one peer
+type lastEvictionDecisionHolder struct {
+	mutLastEvictionDecision sync.RWMutex
+	lastEvictionDecision    p2p.EvictionDecision
+}
+
+func (holder *lastEvictionDecisionHolder) setLastEvictionDecision(decision p2p.EvictionDecision) {
+	holder.mutLastEvictionDecision.Lock()
+	holder.lastEvictionDecision = decision
+	holder.mutLastEvictionDecision.Unlock()
+}
+
+// LastEvictionDecision returns the last eviction decision that evicted at least one peer
+func (holder *lastEvictionDecisionHolder) LastEvictionDecision() p2p.EvictionDecision {
+	holder.mutLastEvictionDecision.RLock()
+	defer holder.mutLastEvictionDecision.RUnlock()
+
+	decision := holder.lastEvictionDecision
+	decision.Peers = make([]p2p.PeerEvictionExplanation, len(holder.lastEvictionDecision.Peers))
+	copy(decision.Peers, holder.lastEvictionDecision.Peers)
+
+	return decision
+}
@@ -0,0 +1,158 @@
+package networksharding
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p/networksharding/sorting"
+)
+
+// evictionScorer orders the peers of a category by a weighted score built from the kad distance, the peer's rating
+// and the connection age. Each component is normalized in the [0, 1] interval against the category's peers so the
+// weights are comparable; a higher score means the peer is evicted sooner
+type evictionScorer struct {
+	distanceWeight        float64
+	ratingWeight          float64
+	connectionAgeWeight   float64
+	peersRatingHandler    p2p.PeersRatingHandler
+	connectionAgeProvider ConnectionAgeProvider
+}
+
+type scoredPeer struct {
+	*sorting.PeerDistance
+	rating        int32
+	connectionAge time.Duration
+	score         float64
+}
+
+func newEvictionScorer(
+	cfg config.EvictionScoringConfig,
+	peersRatingHandler p2p.PeersRatingHandler,
+	connectionAgeProvider ConnectionAgeProvider,
+) (*evictionScorer, error) {
+	if check.IfNil(peersRatingHandler) {
+		return nil, fmt.Errorf("%w for the eviction scoring", p2p.ErrNilPeersRatingHandler)
+	}
+	if check.IfNil(connectionAgeProvider) {
+		return nil, fmt.Errorf("%w for the eviction scoring", p2p.ErrNilConnectionAgeProvider)
+	}
+	if cfg.DistanceWeight+cfg.RatingWeight+cfg.ConnectionAgeWeight == 0 {
+		return nil, fmt.Errorf("%w, at least one of the eviction scoring weights should be positive", p2p.ErrInvalidValue)
+	}
+
+	return &evictionScorer{
+		distanceWeight:        float64(cfg.DistanceWeight),
+		ratingWeight:          float64(cfg.RatingWeight),
+		connectionAgeWeight:   float64(cfg.ConnectionAgeWeight),
+		peersRatingHandler:    peersRatingHandler,
+		connectionAgeProvider: connectionAgeProvider,
+	}, nil
+}
+
+func (es *evictionScorer) evict(distances sorting.PeerDistances, numKeep int) []peer.ID {
+	if numKeep < 0 {
+		numKeep = 0
+	}
+	if numKeep >= len(distances) {
+		return make([]peer.ID, 0)
+	}
+
+	scoredPeers := es.computeScores(distances)
+	sort.SliceStable(scoredPeers, func(i, j int) bool {
+		if scoredPeers[i].score != scoredPeers[j].score {
+			return scoredPeers[i].score < scoredPeers[j].score
+		}
+
+		return scoredPeers[i].Distance.Cmp(scoredPeers[j].Distance) < 0
+	})
+
+	evicted := scoredPeers[numKeep:]
+	evictedPids := make([]peer.ID, len(evicted))
+	for i, sp := range evicted {
+		evictedPids[i] = sp.ID
+	}
+
+	return evictedPids
+}
+
+// computeScores looks up the ratings of the scored peers only, as the rating handler can know many more peers
+func (es *evictionScorer) computeScores(distances sorting.PeerDistances) []*scoredPeer {
+	scoredPeers := make([]*scoredPeer, 0, len(distances))
+	minDistance, maxDistance := math.MaxFloat64, -math.MaxFloat64
+	minRating, maxRating := int32(math.MaxInt32), int32(math.MinInt32)
+	minAge, maxAge := time.Duration(math.MaxInt64), time.Duration(0)
+	for _, pd := range distances {
+		rating, _ := es.peersRatingHandler.GetRating(core.PeerID(pd.ID))
+		sp := &scoredPeer{
+			PeerDistance:  pd,
+			rating:        rating,
+			connectionAge: es.connectionAgeProvider.ConnectionAge(pd.ID),
+		}
+		scoredPeers = append(scoredPeers, sp)
+
+		distance, _ := new(big.Float).SetInt(pd.Distance).Float64()
+		minDistance, maxDistance = math.Min(minDistance, distance), math.Max(maxDistance, distance)
+		minRating, maxRating = minInt32(minRating, sp.rating), maxInt32(maxRating, sp.rating)
+		minAge, maxAge = minDuration(minAge, sp.connectionAge), maxDuration(maxAge, sp.connectionAge)
+	}
+
+	for _, sp := range scoredPeers {
+		distance, _ := new(big.Float).SetInt(sp.Distance).Float64()
+		// the farther, the worse
+		distanceComponent := normalize(distance-minDistance, maxDistance-minDistance)
+		// the lower the rating, the worse
+		ratingComponent := normalize(float64(maxRating-sp.rating), float64(maxRating-minRating))
+		// the younger the connection, the worse
+		ageComponent := normalize(float64(maxAge-sp.connectionAge), float64(maxAge-minAge))
+
+		sp.score = es.distanceWeight*distanceComponent +
+			es.ratingWeight*ratingComponent +
+			es.connectionAgeWeight*ageComponent
+	}
+
+	return scoredPeers
+}
+
+func normalize(value float64, interval float64) float64 {
+	if interval <= 0 {
+		return 0
+	}
+
+	return value / interval
+}
+
+func minInt32(a int32, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt32(a int32, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minDuration(a time.Duration, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxDuration(a time.Duration, b time.Duration) time.Duration {
+	if a > b {
+		return a
+	}
+	return b
+}
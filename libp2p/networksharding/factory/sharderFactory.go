@@ -14,11 +14,13 @@ var log = logger.GetOrCreate("p2p/networksharding/factory")
 
 // ArgsSharderFactory represents the argument for the sharder factory
 type ArgsSharderFactory struct {
-	PeerShardResolver    p2p.PeerShardResolver
-	Pid                  peer.ID
-	P2pConfig            config.P2PConfig
-	PreferredPeersHolder p2p.PreferredPeersHolderHandler
-	NodeOperationMode    p2p.NodeOperation
+	PeerShardResolver     p2p.PeerShardResolver
+	Pid                   peer.ID
+	P2pConfig             config.P2PConfig
+	PreferredPeersHolder  p2p.PreferredPeersHolderHandler
+	NodeOperationMode     p2p.NodeOperation
+	PeersRatingHandler    p2p.PeersRatingHandler
+	ConnectionAgeProvider networksharding.ConnectionAgeProvider
 }
 
 // NewSharder creates new Sharder instances
@@ -52,13 +54,16 @@ func listSharder(arg ArgsSharderFactory) (p2p.Sharder, error) {
 		"MaxFullHistoryObservers", arg.P2pConfig.Sharding.AdditionalConnections.MaxFullHistoryObservers,
 		"MaxSeeders", arg.P2pConfig.Sharding.MaxSeeders,
 		"node operation", arg.NodeOperationMode,
+		"eviction scoring", arg.P2pConfig.Sharding.EvictionScoring.Enabled,
 	)
 	argListsSharder := networksharding.ArgListsSharder{
-		PeerResolver:         arg.PeerShardResolver,
-		SelfPeerId:           arg.Pid,
-		P2pConfig:            arg.P2pConfig,
-		PreferredPeersHolder: arg.PreferredPeersHolder,
-		NodeOperationMode:    arg.NodeOperationMode,
+		PeerResolver:          arg.PeerShardResolver,
+		SelfPeerId:            arg.Pid,
+		P2pConfig:             arg.P2pConfig,
+		PreferredPeersHolder:  arg.PreferredPeersHolder,
+		NodeOperationMode:     arg.NodeOperationMode,
+		PeersRatingHandler:    arg.PeersRatingHandler,
+		ConnectionAgeProvider: arg.ConnectionAgeProvider,
 	}
 	return networksharding.NewListsSharder(argListsSharder)
 }
@@ -0,0 +1,13 @@
+package networksharding
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ConnectionAgeProvider is able to tell for how long the current host has been connected to a peer
+type ConnectionAgeProvider interface {
+	ConnectionAge(pid peer.ID) time.Duration
+	IsInterfaceNil() bool
+}
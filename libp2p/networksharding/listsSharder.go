@@ -35,6 +35,16 @@ const fullHistoryObservers = 60
 
 var log = logger.GetOrCreate("p2p/libp2p/networksharding")
 
+var categoriesNames = map[int]p2p.EvictionCategory{
+	intraShardValidators: p2p.IntraShardValidatorsCategory,
+	intraShardObservers:  p2p.IntraShardObserversCategory,
+	crossShardValidators: p2p.CrossShardValidatorsCategory,
+	crossShardObservers:  p2p.CrossShardObserversCategory,
+	seeders:              p2p.SeedersCategory,
+	unknown:              p2p.UnknownCategory,
+	fullHistoryObservers: p2p.FullHistoryObserversCategory,
+}
+
 var leadingZerosCount = []int{
 	8, 7, 6, 6, 5, 5, 5, 5,
 	4, 4, 4, 4, 4, 4, 4, 4,
@@ -75,11 +85,13 @@ var _ = leadingZerosCount[255]
 
 // ArgListsSharder represents the argument structure used in the initialization of a listsSharder implementation
 type ArgListsSharder struct {
-	PeerResolver         p2p.PeerShardResolver
-	SelfPeerId           peer.ID
-	P2pConfig            config.P2PConfig
-	PreferredPeersHolder p2p.PreferredPeersHolderHandler
-	NodeOperationMode    p2p.NodeOperation
+	PeerResolver          p2p.PeerShardResolver
+	SelfPeerId            peer.ID
+	P2pConfig             config.P2PConfig
+	PreferredPeersHolder  p2p.PreferredPeersHolderHandler
+	NodeOperationMode     p2p.NodeOperation
+	PeersRatingHandler    p2p.PeersRatingHandler
+	ConnectionAgeProvider ConnectionAgeProvider
 }
 
 // listsSharder is the struct able to compute an eviction list of connected peers id according to the
@@ -90,6 +102,7 @@ type listsSharder struct {
 	mutResolver             sync.RWMutex
 	peerShardResolver       p2p.PeerShardResolver
 	selfPeerId              peer.ID
+	mutConfig               sync.RWMutex
 	maxPeerCount            int
 	maxIntraShardValidators int
 	maxCrossShardValidators int
@@ -102,6 +115,11 @@ type listsSharder struct {
 	seeders                 []string
 	computeDistance         func(src peer.ID, dest peer.ID) *big.Int
 	preferredPeersHolder    p2p.PreferredPeersHolderHandler
+	evictionScorer          *evictionScorer
+	nodeOperationMode       p2p.NodeOperation
+	peersRatingHandler      p2p.PeersRatingHandler
+	connectionAgeProvider   ConnectionAgeProvider
+	lastEvictionDecisionHolder
 }
 
 type peersConnections struct {
@@ -120,59 +138,118 @@ func NewListsSharder(arg ArgListsSharder) (*listsSharder, error) {
 	if check.IfNil(arg.PeerResolver) {
 		return nil, p2p.ErrNilPeerShardResolver
 	}
-	if arg.P2pConfig.Sharding.TargetPeerCount < minAllowedConnectedPeersListSharder {
-		return nil, fmt.Errorf("%w, maxPeerCount should be at least %d", p2p.ErrInvalidValue, minAllowedConnectedPeersListSharder)
+	err := checkShardingConfig(arg.P2pConfig.Sharding)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(arg.PreferredPeersHolder) {
+		return nil, fmt.Errorf("%w while creating a new listsShared", p2p.ErrNilPreferredPeersHolder)
+	}
+
+	ls := &listsSharder{
+		peerShardResolver:     arg.PeerResolver,
+		selfPeerId:            arg.SelfPeerId,
+		computeDistance:       computeDistanceByCountingBits,
+		preferredPeersHolder:  arg.PreferredPeersHolder,
+		nodeOperationMode:     arg.NodeOperationMode,
+		peersRatingHandler:    arg.PeersRatingHandler,
+		connectionAgeProvider: arg.ConnectionAgeProvider,
 	}
-	if arg.P2pConfig.Sharding.MaxIntraShardValidators < minAllowedValidators {
-		return nil, fmt.Errorf("%w, maxIntraShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
+	err = ls.applyShardingConfig(arg.P2pConfig.Sharding)
+	if err != nil {
+		return nil, err
 	}
-	if arg.P2pConfig.Sharding.MaxCrossShardValidators < minAllowedValidators {
-		return nil, fmt.Errorf("%w, maxCrossShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
+
+	return ls, nil
+}
+
+func checkShardingConfig(cfg config.ShardingConfig) error {
+	if cfg.TargetPeerCount < minAllowedConnectedPeersListSharder {
+		return fmt.Errorf("%w, maxPeerCount should be at least %d", p2p.ErrInvalidValue, minAllowedConnectedPeersListSharder)
 	}
-	if arg.P2pConfig.Sharding.MaxIntraShardObservers < minAllowedObservers {
-		return nil, fmt.Errorf("%w, maxIntraShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
+	if cfg.MaxIntraShardValidators < minAllowedValidators {
+		return fmt.Errorf("%w, maxIntraShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
 	}
-	if arg.P2pConfig.Sharding.MaxCrossShardObservers < minAllowedObservers {
-		return nil, fmt.Errorf("%w, maxCrossShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
+	if cfg.MaxCrossShardValidators < minAllowedValidators {
+		return fmt.Errorf("%w, maxCrossShardValidators should be at least %d", p2p.ErrInvalidValue, minAllowedValidators)
 	}
-	if check.IfNil(arg.PreferredPeersHolder) {
-		return nil, fmt.Errorf("%w while creating a new listsShared", p2p.ErrNilPreferredPeersHolder)
+	if cfg.MaxIntraShardObservers < minAllowedObservers {
+		return fmt.Errorf("%w, maxIntraShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
 	}
-	peersConn, err := processNumConnections(arg)
+	if cfg.MaxCrossShardObservers < minAllowedObservers {
+		return fmt.Errorf("%w, maxCrossShardObservers should be at least %d", p2p.ErrInvalidValue, minAllowedObservers)
+	}
+
+	return nil
+}
+
+// UpdateShardingConfig validates the provided sharding config and, if valid, replaces the connection quotas and
+// the eviction scoring settings. It is safe to call concurrently with the eviction list computation
+func (ls *listsSharder) UpdateShardingConfig(cfg config.ShardingConfig) error {
+	err := checkShardingConfig(cfg)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	ls := &listsSharder{
-		peerShardResolver:       arg.PeerResolver,
-		selfPeerId:              arg.SelfPeerId,
-		maxPeerCount:            peersConn.maxPeerCount,
-		computeDistance:         computeDistanceByCountingBits,
-		maxIntraShardValidators: peersConn.intraShardValidators,
-		maxCrossShardValidators: peersConn.crossShardValidators,
-		maxIntraShardObservers:  peersConn.intraShardObservers,
-		maxCrossShardObservers:  peersConn.crossShardObservers,
-		maxSeeders:              peersConn.seeders,
-		maxFullHistoryObservers: peersConn.fullHistoryObservers,
-		maxUnknown:              peersConn.unknown,
-		preferredPeersHolder:    arg.PreferredPeersHolder,
+	err = ls.applyShardingConfig(cfg)
+	if err != nil {
+		return err
 	}
 
-	return ls, nil
+	log.Debug("lists sharder config updated",
+		"MaxConnectionCount", cfg.TargetPeerCount,
+		"MaxIntraShardValidators", cfg.MaxIntraShardValidators,
+		"MaxCrossShardValidators", cfg.MaxCrossShardValidators,
+		"MaxIntraShardObservers", cfg.MaxIntraShardObservers,
+		"MaxCrossShardObservers", cfg.MaxCrossShardObservers,
+		"MaxFullHistoryObservers", cfg.AdditionalConnections.MaxFullHistoryObservers,
+		"MaxSeeders", cfg.MaxSeeders,
+		"eviction scoring", cfg.EvictionScoring.Enabled,
+	)
+
+	return nil
+}
+
+func (ls *listsSharder) applyShardingConfig(cfg config.ShardingConfig) error {
+	peersConn, err := processNumConnections(cfg, ls.nodeOperationMode)
+	if err != nil {
+		return err
+	}
+	var scorer *evictionScorer
+	if cfg.EvictionScoring.Enabled {
+		scorer, err = newEvictionScorer(cfg.EvictionScoring, ls.peersRatingHandler, ls.connectionAgeProvider)
+		if err != nil {
+			return err
+		}
+	}
+
+	ls.mutConfig.Lock()
+	ls.maxPeerCount = peersConn.maxPeerCount
+	ls.maxIntraShardValidators = peersConn.intraShardValidators
+	ls.maxCrossShardValidators = peersConn.crossShardValidators
+	ls.maxIntraShardObservers = peersConn.intraShardObservers
+	ls.maxCrossShardObservers = peersConn.crossShardObservers
+	ls.maxSeeders = peersConn.seeders
+	ls.maxFullHistoryObservers = peersConn.fullHistoryObservers
+	ls.maxUnknown = peersConn.unknown
+	ls.evictionScorer = scorer
+	ls.mutConfig.Unlock()
+
+	return nil
 }
 
-func processNumConnections(arg ArgListsSharder) (peersConnections, error) {
+func processNumConnections(cfg config.ShardingConfig, nodeOperationMode p2p.NodeOperation) (peersConnections, error) {
 	peersConn := peersConnections{
-		maxPeerCount:         int(arg.P2pConfig.Sharding.TargetPeerCount),
-		intraShardValidators: int(arg.P2pConfig.Sharding.MaxIntraShardValidators),
-		crossShardValidators: int(arg.P2pConfig.Sharding.MaxCrossShardValidators),
-		intraShardObservers:  int(arg.P2pConfig.Sharding.MaxIntraShardObservers),
-		crossShardObservers:  int(arg.P2pConfig.Sharding.MaxCrossShardObservers),
-		seeders:              int(arg.P2pConfig.Sharding.MaxSeeders),
+		maxPeerCount:         int(cfg.TargetPeerCount),
+		intraShardValidators: int(cfg.MaxIntraShardValidators),
+		crossShardValidators: int(cfg.MaxCrossShardValidators),
+		intraShardObservers:  int(cfg.MaxIntraShardObservers),
+		crossShardObservers:  int(cfg.MaxCrossShardObservers),
+		seeders:              int(cfg.MaxSeeders),
 		fullHistoryObservers: 0,
 	}
-	if arg.NodeOperationMode == p2p.FullArchiveMode {
-		peersConn.fullHistoryObservers = int(arg.P2pConfig.Sharding.AdditionalConnections.MaxFullHistoryObservers)
+	if nodeOperationMode == p2p.FullArchiveMode {
+		peersConn.fullHistoryObservers = int(cfg.AdditionalConnections.MaxFullHistoryObservers)
 		peersConn.maxPeerCount += peersConn.fullHistoryObservers
 	}
 
@@ -194,7 +271,28 @@ func processNumConnections(arg ArgListsSharder) (peersConnections, error) {
 
 // ComputeEvictionList returns the eviction list
 func (ls *listsSharder) ComputeEvictionList(pidList []peer.ID) []peer.ID {
-	peerDistances := ls.splitPeerIds(pidList)
+	categories, preferred, criteria := ls.computeEviction(pidList)
+	evictionProposed := collectEvicted(categories)
+	if len(evictionProposed) > 0 {
+		ls.setLastEvictionDecision(createEvictionDecision(categories, preferred, criteria))
+	}
+
+	return evictionProposed
+}
+
+// ExplainEviction computes the eviction list as ComputeEvictionList does and returns, for each provided peer, the
+// category it was placed in, its distance and the reason it would be kept or evicted
+func (ls *listsSharder) ExplainEviction(pidList []peer.ID) p2p.EvictionDecision {
+	categories, preferred, criteria := ls.computeEviction(pidList)
+
+	return createEvictionDecision(categories, preferred, criteria)
+}
+
+func (ls *listsSharder) computeEviction(pidList []peer.ID) ([]*categoryEviction, []*preferredPeer, string) {
+	ls.mutConfig.RLock()
+	defer ls.mutConfig.RUnlock()
+
+	peerDistances, preferred := ls.splitPeerIds(pidList)
 
 	existingNumIntraShardValidators := len(peerDistances[intraShardValidators])
 	existingNumIntraShardObservers := len(peerDistances[intraShardObservers])
@@ -217,21 +315,40 @@ func (ls *listsSharder) ComputeEvictionList(pidList []peer.ID) []peer.ID {
 	numFullHistoryObservers, _ = computeUsedAndSpare(existingNumFullHistoryObservers, ls.maxFullHistoryObservers)
 	numUnknown, _ = computeUsedAndSpare(existingNumUnknown, ls.maxUnknown+remaining)
 
-	evictionProposed := evict(peerDistances[intraShardValidators], numIntraShardValidators)
-	e := evict(peerDistances[crossShardValidators], numCrossShardValidators)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[intraShardObservers], numIntraShardObservers)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[crossShardObservers], numCrossShardObservers)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[seeders], numSeeders)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[fullHistoryObservers], numFullHistoryObservers)
-	evictionProposed = append(evictionProposed, e...)
-	e = evict(peerDistances[unknown], numUnknown)
-	evictionProposed = append(evictionProposed, e...)
+	categories := []*categoryEviction{
+		{category: p2p.IntraShardValidatorsCategory, distances: peerDistances[intraShardValidators], numKeep: numIntraShardValidators},
+		{category: p2p.CrossShardValidatorsCategory, distances: peerDistances[crossShardValidators], numKeep: numCrossShardValidators},
+		{category: p2p.IntraShardObserversCategory, distances: peerDistances[intraShardObservers], numKeep: numIntraShardObservers},
+		{category: p2p.CrossShardObserversCategory, distances: peerDistances[crossShardObservers], numKeep: numCrossShardObservers},
+		{category: p2p.SeedersCategory, distances: peerDistances[seeders], numKeep: numSeeders},
+		{category: p2p.FullHistoryObserversCategory, distances: peerDistances[fullHistoryObservers], numKeep: numFullHistoryObservers},
+		{category: p2p.UnknownCategory, distances: peerDistances[unknown], numKeep: numUnknown},
+	}
 
-	return evictionProposed
+	evictFunc := ls.createEvictFunc()
+	for _, ce := range categories {
+		ce.evicted = evictFunc(ce.distances, ce.numKeep)
+	}
+
+	return categories, preferred, ls.evictionCriteria()
+}
+
+// createEvictFunc returns the distance only eviction or, if enabled, the scored eviction. The config mutex should be
+// held by the caller
+func (ls *listsSharder) createEvictFunc() func(distances sorting.PeerDistances, numKeep int) []peer.ID {
+	if ls.evictionScorer == nil {
+		return evict
+	}
+
+	return ls.evictionScorer.evict
+}
+
+func (ls *listsSharder) evictionCriteria() string {
+	if ls.evictionScorer == nil {
+		return distanceCriteria
+	}
+
+	return scoreCriteria
 }
 
 // computeUsedAndSpare returns the used and the remaining of the two provided (capacity) values
@@ -259,7 +376,7 @@ func has(pid peer.ID, list []peer.ID) bool {
 	return false
 }
 
-func (ls *listsSharder) splitPeerIds(peers []peer.ID) map[int]sorting.PeerDistances {
+func (ls *listsSharder) splitPeerIds(peers []peer.ID) (map[int]sorting.PeerDistances, []*preferredPeer) {
 	peerDistances := map[int]sorting.PeerDistances{
 		intraShardValidators: {},
 		intraShardObservers:  {},
@@ -270,6 +387,8 @@ func (ls *listsSharder) splitPeerIds(peers []peer.ID) map[int]sorting.PeerDistan
 		unknown:              {},
 	}
 
+	preferred := make([]*preferredPeer, 0)
+
 	ls.mutResolver.RLock()
 	selfPeerInfo := ls.peerShardResolver.GetPeerInfo(core.PeerID(ls.selfPeerId))
 	ls.mutResolver.RUnlock()
@@ -290,41 +409,46 @@ func (ls *listsSharder) splitPeerIds(peers []peer.ID) map[int]sorting.PeerDistan
 		peerInfo := ls.peerShardResolver.GetPeerInfo(pid)
 		ls.mutResolver.RUnlock()
 
+		category := ls.computeCategory(peerInfo, selfPeerInfo)
 		if ls.preferredPeersHolder.Contains(pid) {
+			// preferred peers are categorized as well, but they are never evicted
+			preferred = append(preferred, &preferredPeer{
+				distance: pd,
+				category: categoriesNames[category],
+			})
 			continue
 		}
 
-		if peerInfo.PeerType == core.UnknownPeer {
-			peerDistances[unknown] = append(peerDistances[unknown], pd)
-			continue
-		}
+		peerDistances[category] = append(peerDistances[category], pd)
+	}
 
-		isCrossShard := peerInfo.ShardID != selfPeerInfo.ShardID
-		if isCrossShard {
-			switch peerInfo.PeerType {
-			case core.ValidatorPeer:
-				peerDistances[crossShardValidators] = append(peerDistances[crossShardValidators], pd)
-			case core.ObserverPeer:
-				peerDistances[crossShardObservers] = append(peerDistances[crossShardObservers], pd)
-			}
+	return peerDistances, preferred
+}
 
-			continue
-		}
+func (ls *listsSharder) computeCategory(peerInfo core.P2PPeerInfo, selfPeerInfo core.P2PPeerInfo) int {
+	if peerInfo.PeerType == core.UnknownPeer {
+		return unknown
+	}
 
-		switch peerInfo.PeerType {
-		case core.ValidatorPeer:
-			peerDistances[intraShardValidators] = append(peerDistances[intraShardValidators], pd)
-		case core.ObserverPeer:
-			shouldAppendToFullHistory := peerInfo.PeerSubType == core.FullHistoryObserver && ls.maxFullHistoryObservers > 0
-			if shouldAppendToFullHistory {
-				peerDistances[fullHistoryObservers] = append(peerDistances[fullHistoryObservers], pd)
-			} else {
-				peerDistances[intraShardObservers] = append(peerDistances[intraShardObservers], pd)
-			}
+	isCrossShard := peerInfo.ShardID != selfPeerInfo.ShardID
+	if isCrossShard {
+		if peerInfo.PeerType == core.ValidatorPeer {
+			return crossShardValidators
 		}
+
+		return crossShardObservers
+	}
+
+	if peerInfo.PeerType == core.ValidatorPeer {
+		return intraShardValidators
+	}
+
+	shouldAppendToFullHistory := peerInfo.PeerSubType == core.FullHistoryObserver && ls.maxFullHistoryObservers > 0
+	if shouldAppendToFullHistory {
+		return fullHistoryObservers
 	}
 
-	return peerDistances
+	return intraShardObservers
 }
 
 func evict(distances sorting.PeerDistances, numKeep int) []peer.ID {
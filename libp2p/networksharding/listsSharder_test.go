@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiversx/mx-chain-core-go/core"
@@ -573,3 +575,366 @@ func TestListsSharder_SetPeerShardResolverShouldWork(t *testing.T) {
 	assert.True(t, ls.GetPeerShardResolver() == newPeerShardResolver)
 	assert.Nil(t, err)
 }
+
+func createMockListSharderArgumentsWithEvictionScoring(
+	ratings map[peer.ID]int32,
+	ages map[peer.ID]time.Duration,
+) networksharding.ArgListsSharder {
+	arg := createMockListSharderArguments()
+	arg.P2pConfig.Sharding.EvictionScoring = config.EvictionScoringConfig{
+		Enabled:             true,
+		DistanceWeight:      1,
+		RatingWeight:        1,
+		ConnectionAgeWeight: 1,
+	}
+	arg.PeersRatingHandler = &mock.PeersRatingHandlerStub{
+		GetRatingCalled: func(pid core.PeerID) (int32, bool) {
+			rating, found := ratings[peer.ID(pid)]
+			return rating, found
+		},
+	}
+	arg.ConnectionAgeProvider = &mock.ConnectionAgeProviderStub{
+		ConnectionAgeCalled: func(pid peer.ID) time.Duration {
+			return ages[pid]
+		},
+	}
+
+	return arg
+}
+
+func TestNewListsSharder_WithEvictionScoring(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil peers rating handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArgumentsWithEvictionScoring(nil, nil)
+		arg.PeersRatingHandler = nil
+		ls, err := networksharding.NewListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.True(t, errors.Is(err, p2p.ErrNilPeersRatingHandler))
+	})
+	t.Run("nil connection age provider should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArgumentsWithEvictionScoring(nil, nil)
+		arg.ConnectionAgeProvider = nil
+		ls, err := networksharding.NewListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.True(t, errors.Is(err, p2p.ErrNilConnectionAgeProvider))
+	})
+	t.Run("all weights zero should error", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArgumentsWithEvictionScoring(nil, nil)
+		arg.P2pConfig.Sharding.EvictionScoring.DistanceWeight = 0
+		arg.P2pConfig.Sharding.EvictionScoring.RatingWeight = 0
+		arg.P2pConfig.Sharding.EvictionScoring.ConnectionAgeWeight = 0
+		ls, err := networksharding.NewListsSharder(arg)
+
+		assert.True(t, check.IfNil(ls))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("disabled scoring should not check the components", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArguments()
+		ls, err := networksharding.NewListsSharder(arg)
+
+		assert.False(t, check.IfNil(ls))
+		assert.Nil(t, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArgumentsWithEvictionScoring(nil, nil)
+		ls, err := networksharding.NewListsSharder(arg)
+
+		assert.False(t, check.IfNil(ls))
+		assert.Nil(t, err)
+	})
+}
+
+func TestListsSharder_ComputeEvictionListWithEvictionScoring(t *testing.T) {
+	t.Parallel()
+
+	pid1 := peer.ID(fmt.Sprintf("%d - 1 - %s", crtShardId, validatorMarker))
+	pid2 := peer.ID(fmt.Sprintf("%d - 2 - %s", crtShardId, validatorMarker))
+	pid3 := peer.ID(fmt.Sprintf("%d - 3 - %s", crtShardId, validatorMarker))
+	pids := []peer.ID{pid1, pid2, pid3}
+
+	t.Run("only distance weight should evict as the distance based eviction", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArgumentsWithEvictionScoring(
+			map[peer.ID]int32{pid1: 100, pid2: -100, pid3: 0},
+			map[peer.ID]time.Duration{pid1: time.Hour},
+		)
+		arg.P2pConfig.Sharding.EvictionScoring.RatingWeight = 0
+		arg.P2pConfig.Sharding.EvictionScoring.ConnectionAgeWeight = 0
+		scoredSharder, _ := networksharding.NewListsSharder(arg)
+		distanceSharder, _ := networksharding.NewListsSharder(createMockListSharderArguments())
+
+		assert.ElementsMatch(t, distanceSharder.ComputeEvictionList(pids), scoredSharder.ComputeEvictionList(pids))
+	})
+	t.Run("bad rated peers should be evicted first", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArgumentsWithEvictionScoring(
+			map[peer.ID]int32{pid1: -50, pid2: 10, pid3: 50},
+			nil,
+		)
+		arg.P2pConfig.Sharding.EvictionScoring.DistanceWeight = 0
+		arg.P2pConfig.Sharding.EvictionScoring.ConnectionAgeWeight = 0
+		ls, _ := networksharding.NewListsSharder(arg)
+
+		evictList := ls.ComputeEvictionList(pids)
+
+		assert.ElementsMatch(t, []peer.ID{pid1, pid2}, evictList)
+	})
+	t.Run("long-lived peers should be protected", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArgumentsWithEvictionScoring(
+			nil,
+			map[peer.ID]time.Duration{pid1: time.Hour, pid2: time.Minute},
+		)
+		arg.P2pConfig.Sharding.EvictionScoring.DistanceWeight = 0
+		arg.P2pConfig.Sharding.EvictionScoring.RatingWeight = 0
+		ls, _ := networksharding.NewListsSharder(arg)
+
+		evictList := ls.ComputeEvictionList(pids)
+
+		assert.ElementsMatch(t, []peer.ID{pid2, pid3}, evictList)
+	})
+	t.Run("heavier weight should win", func(t *testing.T) {
+		t.Parallel()
+
+		// pid1 is the oldest connection but has the worst rating
+		arg := createMockListSharderArgumentsWithEvictionScoring(
+			map[peer.ID]int32{pid1: -100, pid2: 50, pid3: 100},
+			map[peer.ID]time.Duration{pid1: time.Hour, pid2: time.Minute, pid3: time.Second},
+		)
+		arg.P2pConfig.Sharding.EvictionScoring.DistanceWeight = 0
+		arg.P2pConfig.Sharding.EvictionScoring.RatingWeight = 3
+		arg.P2pConfig.Sharding.EvictionScoring.ConnectionAgeWeight = 1
+		ls, _ := networksharding.NewListsSharder(arg)
+
+		evictList := ls.ComputeEvictionList(pids)
+		assert.ElementsMatch(t, []peer.ID{pid1, pid2}, evictList)
+
+		arg.P2pConfig.Sharding.EvictionScoring.RatingWeight = 1
+		arg.P2pConfig.Sharding.EvictionScoring.ConnectionAgeWeight = 3
+		ls, _ = networksharding.NewListsSharder(arg)
+
+		evictList = ls.ComputeEvictionList(pids)
+		assert.ElementsMatch(t, []peer.ID{pid2, pid3}, evictList)
+	})
+}
+
+func getExplanation(decision p2p.EvictionDecision, pid peer.ID) p2p.PeerEvictionExplanation {
+	for _, explanation := range decision.Peers {
+		if explanation.Pid == core.PeerID(pid) {
+			return explanation
+		}
+	}
+
+	return p2p.PeerEvictionExplanation{}
+}
+
+func TestListsSharder_ExplainEviction(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockListSharderArguments()
+	arg.PreferredPeersHolder = &mock.PeersHolderStub{
+		ContainsCalled: func(peerID core.PeerID) bool {
+			return strings.HasPrefix(string(peerID), "preferred")
+		},
+	}
+	ls, _ := networksharding.NewListsSharder(arg)
+	seeder := peer.ID(fmt.Sprintf("%d %s", crossShardId, seederMarker))
+	ls.SetSeeders([]string{"ip6/" + seeder.String()})
+
+	pidIntraValidator1 := peer.ID(fmt.Sprintf("%d - 1 - %s", crtShardId, validatorMarker))
+	pidIntraValidator2 := peer.ID(fmt.Sprintf("%d - 2 - %s", crtShardId, validatorMarker))
+	pidCrossObserver := peer.ID(fmt.Sprintf("%d - 1 - %s", crossShardId, observerMarker))
+	pidUnknown := peer.ID(fmt.Sprintf("%d - 1 - %s", crossShardId, unknownMarker))
+	pidPreferred := peer.ID(fmt.Sprintf("preferred %d - %s", crtShardId, validatorMarker))
+	pidPreferredCrossObserver := peer.ID(fmt.Sprintf("preferred %d - %s", crossShardId, observerMarker))
+	pids := []peer.ID{pidIntraValidator1, pidIntraValidator2, pidCrossObserver, pidUnknown, pidPreferred, pidPreferredCrossObserver, seeder}
+
+	decision := ls.ExplainEviction(pids)
+
+	assert.Equal(t, len(pids), len(decision.Peers))
+	assert.Equal(t, 2, decision.NumEvicted)
+	assert.False(t, decision.Timestamp.IsZero())
+
+	explanation := getExplanation(decision, pidIntraValidator2)
+	assert.Equal(t, p2p.IntraShardValidatorsCategory, explanation.Category)
+	assert.False(t, explanation.IsEvicted)
+	assert.NotNil(t, explanation.Distance)
+	assert.True(t, strings.HasPrefix(explanation.Reason, "kept"))
+
+	explanation = getExplanation(decision, pidIntraValidator1)
+	assert.Equal(t, p2p.IntraShardValidatorsCategory, explanation.Category)
+	assert.True(t, explanation.IsEvicted)
+	assert.True(t, strings.HasPrefix(explanation.Reason, "evicted"))
+	assert.True(t, strings.Contains(explanation.Reason, "the closest peers are kept"))
+
+	assert.Equal(t, p2p.CrossShardObserversCategory, getExplanation(decision, pidCrossObserver).Category)
+	assert.False(t, getExplanation(decision, pidCrossObserver).IsEvicted)
+	assert.Equal(t, p2p.UnknownCategory, getExplanation(decision, pidUnknown).Category)
+	assert.False(t, getExplanation(decision, pidUnknown).IsEvicted)
+
+	// no seeders are allowed in the mock configuration
+	explanation = getExplanation(decision, seeder)
+	assert.Equal(t, p2p.SeedersCategory, explanation.Category)
+	assert.True(t, explanation.IsEvicted)
+
+	explanation = getExplanation(decision, pidPreferred)
+	assert.Equal(t, p2p.IntraShardValidatorsCategory, explanation.Category)
+	assert.True(t, explanation.IsPreferred)
+	assert.False(t, explanation.IsEvicted)
+
+	explanation = getExplanation(decision, pidPreferredCrossObserver)
+	assert.Equal(t, p2p.CrossShardObserversCategory, explanation.Category)
+	assert.True(t, explanation.IsPreferred)
+	assert.False(t, explanation.IsEvicted)
+
+	// the dry run should not alter the last eviction decision
+	assert.Equal(t, 0, len(ls.LastEvictionDecision().Peers))
+}
+
+func TestListsSharder_ExplainEvictionWithScoringShouldMentionTheScore(t *testing.T) {
+	t.Parallel()
+
+	pid1 := peer.ID(fmt.Sprintf("%d - 1 - %s", crtShardId, validatorMarker))
+	pid2 := peer.ID(fmt.Sprintf("%d - 2 - %s", crtShardId, validatorMarker))
+	arg := createMockListSharderArgumentsWithEvictionScoring(map[peer.ID]int32{pid1: 100, pid2: -100}, nil)
+	arg.P2pConfig.Sharding.EvictionScoring.DistanceWeight = 0
+	ls, _ := networksharding.NewListsSharder(arg)
+
+	decision := ls.ExplainEviction([]peer.ID{pid1, pid2})
+
+	explanation := getExplanation(decision, pid2)
+	assert.True(t, explanation.IsEvicted)
+	assert.True(t, strings.Contains(explanation.Reason, "the best scored peers are kept"))
+}
+
+func TestListsSharder_LastEvictionDecision(t *testing.T) {
+	t.Parallel()
+
+	arg := createMockListSharderArguments()
+	ls, _ := networksharding.NewListsSharder(arg)
+	pid1 := peer.ID(fmt.Sprintf("%d - 1 - %s", crtShardId, validatorMarker))
+	pid2 := peer.ID(fmt.Sprintf("%d - 2 - %s", crtShardId, validatorMarker))
+
+	evictList := ls.ComputeEvictionList([]peer.ID{pid1, pid2})
+	require.Equal(t, []peer.ID{pid1}, evictList)
+
+	decision := ls.LastEvictionDecision()
+	assert.Equal(t, 1, decision.NumEvicted)
+	assert.Equal(t, 2, len(decision.Peers))
+	assert.True(t, getExplanation(decision, pid1).IsEvicted)
+
+	// a computation that does not evict should not overwrite the last decision
+	evictList = ls.ComputeEvictionList([]peer.ID{pid2})
+	require.Equal(t, 0, len(evictList))
+
+	decision = ls.LastEvictionDecision()
+	assert.Equal(t, 1, decision.NumEvicted)
+	assert.Equal(t, 2, len(decision.Peers))
+}
+
+func TestListsSharder_UpdateShardingConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid config should error and keep the old quotas", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArguments()
+		ls, _ := networksharding.NewListsSharder(arg)
+
+		cfg := arg.P2pConfig.Sharding
+		cfg.MaxIntraShardValidators = networksharding.MinAllowedValidators - 1
+		err := ls.UpdateShardingConfig(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+		cfg = arg.P2pConfig.Sharding
+		cfg.MaxIntraShardValidators = cfg.TargetPeerCount
+		err = ls.UpdateShardingConfig(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+
+		cfg = arg.P2pConfig.Sharding
+		cfg.EvictionScoring.Enabled = true
+		cfg.EvictionScoring.DistanceWeight = 1
+		err = ls.UpdateShardingConfig(cfg)
+		assert.True(t, errors.Is(err, p2p.ErrNilPeersRatingHandler))
+
+		assert.Equal(t, int(arg.P2pConfig.Sharding.TargetPeerCount), ls.GetMaxPeerCount())
+		assert.Equal(t, int(arg.P2pConfig.Sharding.MaxIntraShardValidators), ls.GetMaxIntraShardValidators())
+	})
+	t.Run("should recompute the quotas", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArguments()
+		ls, _ := networksharding.NewListsSharder(arg)
+		pid1 := peer.ID(fmt.Sprintf("%d - 1 - %s", crtShardId, validatorMarker))
+		pid2 := peer.ID(fmt.Sprintf("%d - 2 - %s", crtShardId, validatorMarker))
+		pids := []peer.ID{pid1, pid2}
+		require.Equal(t, 1, len(ls.ComputeEvictionList(pids)))
+
+		cfg := arg.P2pConfig.Sharding
+		cfg.TargetPeerCount = 10
+		cfg.MaxIntraShardValidators = 2
+		cfg.MaxCrossShardValidators = 2
+		cfg.MaxIntraShardObservers = 2
+		cfg.MaxCrossShardObservers = 2
+		cfg.MaxSeeders = 1
+		err := ls.UpdateShardingConfig(cfg)
+		require.Nil(t, err)
+
+		assert.Equal(t, 10, ls.GetMaxPeerCount())
+		assert.Equal(t, 2, ls.GetMaxIntraShardValidators())
+		assert.Equal(t, 2, ls.GetMaxCrossShardValidators())
+		assert.Equal(t, 2, ls.GetMaxIntraShardObservers())
+		assert.Equal(t, 2, ls.GetMaxCrossShardObservers())
+		assert.Equal(t, 1, ls.GetMaxSeeders())
+		assert.Equal(t, 1, ls.GetMaxUnknown())
+		assert.Equal(t, 0, len(ls.ComputeEvictionList(pids)))
+	})
+	t.Run("should be safe to call concurrently with the eviction computation", func(t *testing.T) {
+		t.Parallel()
+
+		arg := createMockListSharderArguments()
+		ls, _ := networksharding.NewListsSharder(arg)
+		pids := []peer.ID{
+			peer.ID(fmt.Sprintf("%d - 1 - %s", crtShardId, validatorMarker)),
+			peer.ID(fmt.Sprintf("%d - 2 - %s", crtShardId, validatorMarker)),
+			peer.ID(fmt.Sprintf("%d - 1 - %s", crossShardId, observerMarker)),
+		}
+
+		numCalls := 100
+		wg := sync.WaitGroup{}
+		wg.Add(numCalls)
+		for i := 0; i < numCalls; i++ {
+			go func(idx int) {
+				defer wg.Done()
+
+				if idx%2 == 0 {
+					cfg := arg.P2pConfig.Sharding
+					cfg.TargetPeerCount += uint32(idx)
+					_ = ls.UpdateShardingConfig(cfg)
+					return
+				}
+
+				_ = ls.ComputeEvictionList(pids)
+				_ = ls.ExplainEviction(pids)
+			}(i)
+		}
+
+		wg.Wait()
+	})
+}
@@ -1,9 +1,12 @@
 package networksharding
 
 import (
+	"time"
+
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
 )
 
 var _ p2p.Sharder = (*nilListSharder)(nil)
@@ -21,11 +24,38 @@ func (nls *nilListSharder) ComputeEvictionList(_ []peer.ID) []peer.ID {
 	return make([]peer.ID, 0)
 }
 
+// ExplainEviction returns all the provided peers as kept
+func (nls *nilListSharder) ExplainEviction(pidList []peer.ID) p2p.EvictionDecision {
+	decision := p2p.EvictionDecision{
+		Timestamp: time.Now(),
+		Peers:     make([]p2p.PeerEvictionExplanation, 0, len(pidList)),
+	}
+	for _, pid := range pidList {
+		decision.Peers = append(decision.Peers, p2p.PeerEvictionExplanation{
+			Pid:      core.PeerID(pid),
+			Category: p2p.ShardAgnosticCategory,
+			Reason:   "kept, the nil list sharder does not evict peers",
+		})
+	}
+
+	return decision
+}
+
+// LastEvictionDecision returns an empty decision as this implementation never evicts
+func (nls *nilListSharder) LastEvictionDecision() p2p.EvictionDecision {
+	return p2p.EvictionDecision{}
+}
+
 // Has will output false, causing all peers to connect to each other
 func (nls *nilListSharder) Has(_ peer.ID, _ []peer.ID) bool {
 	return false
 }
 
+// UpdateShardingConfig does nothing
+func (nls *nilListSharder) UpdateShardingConfig(_ config.ShardingConfig) error {
+	return nil
+}
+
 // SetPeerShardResolver will do nothing
 func (nls *nilListSharder) SetPeerShardResolver(_ p2p.PeerShardResolver) error {
 	return nil
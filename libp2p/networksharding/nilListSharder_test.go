@@ -3,7 +3,9 @@ package networksharding_test
 import (
 	"testing"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go/config"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/networksharding"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,4 +17,10 @@ func TestNilListSharderSharder(t *testing.T) {
 	assert.Equal(t, 0, len(nls.ComputeEvictionList(nil)))
 	assert.False(t, nls.Has("", nil))
 	assert.Nil(t, nls.SetPeerShardResolver(nil))
+	assert.Nil(t, nls.UpdateShardingConfig(config.ShardingConfig{}))
+
+	decision := nls.ExplainEviction([]peer.ID{"pid1", "pid2"})
+	assert.Equal(t, 2, len(decision.Peers))
+	assert.Equal(t, 0, decision.NumEvicted)
+	assert.Equal(t, 0, len(nls.LastEvictionDecision().Peers))
 }
@@ -3,10 +3,12 @@ package networksharding
 import (
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/networksharding/sorting"
 )
 
@@ -16,8 +18,10 @@ const minAllowedConnectedPeersOneSharder = 3
 
 type oneListSharder struct {
 	selfPeerId      peer.ID
+	mutConfig       sync.RWMutex
 	maxPeerCount    int
 	computeDistance func(src peer.ID, dest peer.ID) *big.Int
+	lastEvictionDecisionHolder
 }
 
 // NewOneListSharder creates a new sharder instance that is shard agnostic and uses one list
@@ -38,12 +42,55 @@ func NewOneListSharder(
 
 // ComputeEvictionList returns the eviction list
 func (ols *oneListSharder) ComputeEvictionList(pidList []peer.ID) []peer.ID {
-	list := ols.convertList(pidList)
-	evictionProposed := evict(list, ols.maxPeerCount)
+	categories := ols.computeEviction(pidList)
+	evictionProposed := collectEvicted(categories)
+	if len(evictionProposed) > 0 {
+		ols.setLastEvictionDecision(createEvictionDecision(categories, nil, distanceCriteria))
+	}
 
 	return evictionProposed
 }
 
+// ExplainEviction computes the eviction list as ComputeEvictionList does and returns, for each provided peer, its
+// distance and the reason it would be kept or evicted
+func (ols *oneListSharder) ExplainEviction(pidList []peer.ID) p2p.EvictionDecision {
+	return createEvictionDecision(ols.computeEviction(pidList), nil, distanceCriteria)
+}
+
+func (ols *oneListSharder) computeEviction(pidList []peer.ID) []*categoryEviction {
+	list := ols.convertList(pidList)
+
+	ols.mutConfig.RLock()
+	maxPeerCount := ols.maxPeerCount
+	ols.mutConfig.RUnlock()
+
+	return []*categoryEviction{
+		{
+			category:  p2p.ShardAgnosticCategory,
+			distances: list,
+			numKeep:   maxPeerCount,
+			evicted:   evict(list, maxPeerCount),
+		},
+	}
+}
+
+// UpdateShardingConfig validates and applies the new maximum peer count. The other values are not used
+// by this implementation
+func (ols *oneListSharder) UpdateShardingConfig(cfg config.ShardingConfig) error {
+	maxPeerCount := int(cfg.TargetPeerCount)
+	if maxPeerCount < minAllowedConnectedPeersOneSharder {
+		return fmt.Errorf("%w, maxPeerCount should be at least %d", p2p.ErrInvalidValue, minAllowedConnectedPeersOneSharder)
+	}
+
+	ols.mutConfig.Lock()
+	ols.maxPeerCount = maxPeerCount
+	ols.mutConfig.Unlock()
+
+	log.Debug("one list sharder config updated", "MaxConnectionCount", maxPeerCount)
+
+	return nil
+}
+
 func (ols *oneListSharder) convertList(peers []peer.ID) sorting.PeerDistances {
 	list := sorting.PeerDistances{}
 
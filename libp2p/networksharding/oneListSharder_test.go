@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p/networksharding"
 	"github.com/stretchr/testify/assert"
 )
@@ -72,6 +74,56 @@ func TestOneListSharder_ComputeEvictionListReachedIntraShardShouldSortAndEvict(t
 	assert.Equal(t, pid3, evictList[0])
 }
 
+func TestOneListSharder_ExplainEvictionAndLastEvictionDecision(t *testing.T) {
+	t.Parallel()
+
+	ols, _ := networksharding.NewOneListSharder(
+		crtPid,
+		networksharding.MinAllowedConnectedPeersOneSharder,
+	)
+	pid1 := peer.ID("pid1")
+	pid2 := peer.ID("pid2")
+	pid3 := peer.ID("pid3")
+	pid4 := peer.ID("pid4")
+	pids := []peer.ID{pid1, pid2, pid3, pid4}
+
+	decision := ols.ExplainEviction(pids)
+	assert.Equal(t, 4, len(decision.Peers))
+	assert.Equal(t, 1, decision.NumEvicted)
+	for _, explanation := range decision.Peers {
+		assert.Equal(t, p2p.ShardAgnosticCategory, explanation.Category)
+		assert.NotNil(t, explanation.Distance)
+		assert.Equal(t, explanation.Pid == core.PeerID(pid3), explanation.IsEvicted)
+	}
+	assert.Equal(t, 0, len(ols.LastEvictionDecision().Peers))
+
+	_ = ols.ComputeEvictionList(pids)
+	assert.Equal(t, decision.Peers, ols.LastEvictionDecision().Peers)
+}
+
+func TestOneListSharder_UpdateShardingConfig(t *testing.T) {
+	t.Parallel()
+
+	ols, _ := networksharding.NewOneListSharder(
+		crtPid,
+		networksharding.MinAllowedConnectedPeersOneSharder+1,
+	)
+	pids := []peer.ID{"pid1", "pid2", "pid3", "pid4"}
+	assert.Equal(t, 0, len(ols.ComputeEvictionList(pids)))
+
+	err := ols.UpdateShardingConfig(config.ShardingConfig{
+		TargetPeerCount: networksharding.MinAllowedConnectedPeersOneSharder - 1,
+	})
+	assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	assert.Equal(t, 0, len(ols.ComputeEvictionList(pids)))
+
+	err = ols.UpdateShardingConfig(config.ShardingConfig{
+		TargetPeerCount: networksharding.MinAllowedConnectedPeersOneSharder,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []peer.ID{"pid3"}, ols.ComputeEvictionList(pids))
+}
+
 // ------- Has
 
 func TestOneListSharder_HasNotFound(t *testing.T) {
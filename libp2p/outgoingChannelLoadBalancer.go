@@ -2,6 +2,8 @@ package libp2p
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/multiversx/mx-chain-p2p-go"
@@ -11,18 +13,72 @@ var _ ChannelLoadBalancer = (*OutgoingChannelLoadBalancer)(nil)
 
 const defaultSendChannel = "default send channel"
 
-// OutgoingChannelLoadBalancer is a component that evenly balances requests to be sent
+// OutgoingChannelStatistics holds the settings and the counters of an outgoing channel
+type OutgoingChannelStatistics struct {
+	Name       string
+	Priority   uint32
+	Weight     uint32
+	QueueLen   int
+	NumQueued  uint64
+	NumDropped uint64
+}
+
+type outgoingChannel struct {
+	name          string
+	options       p2p.OutgoingChannelOptions
+	queue         []*SendableData
+	currentWeight int64
+	numQueued     uint64
+	numDropped    uint64
+}
+
+func (ch *outgoingChannel) isFull() bool {
+	return uint32(len(ch.queue)) >= ch.options.QueueSize
+}
+
+func (ch *outgoingChannel) push(data *SendableData) error {
+	if ch.isFull() {
+		ch.numDropped++
+		if ch.options.DropPolicy == p2p.DropNewestPolicy {
+			return fmt.Errorf("%w for channel %s", p2p.ErrOutgoingQueueFull, ch.name)
+		}
+
+		ch.queue[0] = nil
+		ch.queue = ch.queue[1:]
+	}
+
+	ch.queue = append(ch.queue, data)
+	ch.numQueued++
+
+	return nil
+}
+
+func (ch *outgoingChannel) pop() *SendableData {
+	data := ch.queue[0]
+	ch.queue[0] = nil
+	ch.queue = ch.queue[1:]
+	if len(ch.queue) == 0 {
+		// an idle channel should not keep any advantage or penalty from its previous sends
+		ch.currentWeight = 0
+	}
+
+	return data
+}
+
+// OutgoingChannelLoadBalancer is a component that schedules the requests to be sent. The channels with a higher
+// priority are always served first, while the channels with the same priority are served in a weighted round-robin
+// manner. Each channel holds a bounded queue, the channel's drop policy being applied when the queue is full.
 type OutgoingChannelLoadBalancer struct {
-	mut      sync.RWMutex
-	chans    []chan *SendableData
-	mainChan chan *SendableData
-	names    []string
-	//namesChans is defined only for performance purposes as to fast search by name
-	//iteration is done directly on slices as that is used very often and is about 50x
-	//faster then an iteration over a map
-	namesChans map[string]chan *SendableData
-	cancelFunc context.CancelFunc
-	ctx        context.Context //we need the context saved here in order to call appendChannel from exported func AddChannel
+	mut sync.Mutex
+	// channels are sorted by their descending priority, keeping the insertion order for the same priority
+	channels      []*outgoingChannel
+	namesChannels map[string]*outgoingChannel
+	chanNewData   chan struct{}
+	// chanSpaceFreed is closed and renewed each time an element is collected while there are blocked enqueue calls
+	chanSpaceFreed     chan struct{}
+	numBlockedEnqueues int
+	cancelFunc         context.CancelFunc
+	ctx                context.Context
 }
 
 // NewOutgoingChannelLoadBalancer creates a new instance of a ChannelLoadBalancer instance
@@ -30,62 +86,74 @@ func NewOutgoingChannelLoadBalancer() *OutgoingChannelLoadBalancer {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 
 	oclb := &OutgoingChannelLoadBalancer{
-		chans:      make([]chan *SendableData, 0),
-		names:      make([]string, 0),
-		namesChans: make(map[string]chan *SendableData),
-		mainChan:   make(chan *SendableData),
-		cancelFunc: cancelFunc,
-		ctx:        ctx,
+		channels:       make([]*outgoingChannel, 0),
+		namesChannels:  make(map[string]*outgoingChannel),
+		chanNewData:    make(chan struct{}, 1),
+		chanSpaceFreed: make(chan struct{}),
+		cancelFunc:     cancelFunc,
+		ctx:            ctx,
 	}
 
-	oclb.appendChannel(defaultSendChannel)
+	oclb.appendChannel(defaultSendChannel, p2p.DefaultOutgoingChannelOptions())
 
 	return oclb
 }
 
-func (oplb *OutgoingChannelLoadBalancer) appendChannel(channel string) {
-	oplb.names = append(oplb.names, channel)
-	ch := make(chan *SendableData)
-	oplb.chans = append(oplb.chans, ch)
-	oplb.namesChans[channel] = ch
+func (oplb *OutgoingChannelLoadBalancer) appendChannel(channel string, options p2p.OutgoingChannelOptions) {
+	ch := &outgoingChannel{
+		name:    channel,
+		options: options,
+		queue:   make([]*SendableData, 0),
+	}
+	oplb.channels = append(oplb.channels, ch)
+	oplb.namesChannels[channel] = ch
 
-	go func() {
-		for {
-			var obj *SendableData
+	sort.SliceStable(oplb.channels, func(i, j int) bool {
+		return oplb.channels[i].options.Priority > oplb.channels[j].options.Priority
+	})
+}
 
-			select {
-			case obj = <-ch:
-			case <-oplb.ctx.Done():
-				log.Debug("closing OutgoingChannelLoadBalancer's append channel go routine")
-				return
-			}
+func checkOutgoingChannelOptions(options p2p.OutgoingChannelOptions) error {
+	if options.Weight == 0 {
+		return fmt.Errorf("%w for Weight, provided %d", p2p.ErrInvalidValue, options.Weight)
+	}
+	if options.QueueSize == 0 {
+		return fmt.Errorf("%w for QueueSize, provided %d", p2p.ErrInvalidValue, options.QueueSize)
+	}
 
-			oplb.mainChan <- obj
-		}
-	}()
+	switch options.DropPolicy {
+	case p2p.DropNewestPolicy, p2p.DropOldestPolicy:
+		return nil
+	default:
+		return fmt.Errorf("%w %s", p2p.ErrInvalidDropPolicy, options.DropPolicy)
+	}
 }
 
 // AddChannel adds a new channel to the throttler, if it does not exists
-func (oplb *OutgoingChannelLoadBalancer) AddChannel(channel string) error {
+func (oplb *OutgoingChannelLoadBalancer) AddChannel(channel string, options p2p.OutgoingChannelOptions) error {
 	if channel == defaultSendChannel {
 		return p2p.ErrChannelCanNotBeReAdded
 	}
 
+	err := checkOutgoingChannelOptions(options)
+	if err != nil {
+		return fmt.Errorf("%w for channel %s", err, channel)
+	}
+
 	oplb.mut.Lock()
 	defer oplb.mut.Unlock()
 
-	for _, name := range oplb.names {
-		if name == channel {
-			return nil
-		}
+	_, found := oplb.namesChannels[channel]
+	if found {
+		return nil
 	}
 
-	oplb.appendChannel(channel)
+	oplb.appendChannel(channel, options)
 
 	return nil
 }
 
-// RemoveChannel removes an existing channel from the throttler
+// RemoveChannel removes an existing channel from the throttler, dropping its pending data
 func (oplb *OutgoingChannelLoadBalancer) RemoveChannel(channel string) error {
 	if channel == defaultSendChannel {
 		return p2p.ErrChannelCanNotBeDeleted
@@ -95,9 +163,8 @@ func (oplb *OutgoingChannelLoadBalancer) RemoveChannel(channel string) error {
 	defer oplb.mut.Unlock()
 
 	index := -1
-
-	for idx, name := range oplb.names {
-		if name == channel {
+	for idx, ch := range oplb.channels {
+		if ch.name == channel {
 			index = idx
 			break
 		}
@@ -107,45 +174,159 @@ func (oplb *OutgoingChannelLoadBalancer) RemoveChannel(channel string) error {
 		return p2p.ErrChannelDoesNotExist
 	}
 
-	sendableChan := oplb.chans[index]
+	//remove the index-th element in the channels slice
+	copy(oplb.channels[index:], oplb.channels[index+1:])
+	oplb.channels[len(oplb.channels)-1] = nil
+	oplb.channels = oplb.channels[:len(oplb.channels)-1]
 
-	//remove the index-th element in the chan slice
-	copy(oplb.chans[index:], oplb.chans[index+1:])
-	oplb.chans[len(oplb.chans)-1] = nil
-	oplb.chans = oplb.chans[:len(oplb.chans)-1]
+	delete(oplb.namesChannels, channel)
 
-	//remove the index-th element in the names slice
-	copy(oplb.names[index:], oplb.names[index+1:])
-	oplb.names = oplb.names[:len(oplb.names)-1]
+	return nil
+}
 
-	close(sendableChan)
+func (oplb *OutgoingChannelLoadBalancer) getChannelOrDefault(channel string) *outgoingChannel {
+	ch := oplb.namesChannels[channel]
+	if ch == nil {
+		return oplb.namesChannels[defaultSendChannel]
+	}
+
+	return ch
+}
+
+// Enqueue adds the data in the queue of the required channel or of the default channel if the channel is not present.
+// It returns an error if the data was dropped because the queue is full
+func (oplb *OutgoingChannelLoadBalancer) Enqueue(channel string, data *SendableData) error {
+	oplb.mut.Lock()
+	err := oplb.getChannelOrDefault(channel).push(data)
+	oplb.mut.Unlock()
+	if err != nil {
+		return err
+	}
 
-	delete(oplb.namesChans, channel)
+	oplb.notifyNewData()
 
 	return nil
 }
 
-// GetChannelOrDefault fetches the required channel or the default if the channel is not present
-func (oplb *OutgoingChannelLoadBalancer) GetChannelOrDefault(channel string) chan *SendableData {
-	oplb.mut.RLock()
-	defer oplb.mut.RUnlock()
+// EnqueueWithContext adds the data in the queue of the required channel or of the default channel if the channel is
+// not present. If the queue is full, it blocks until there is room in the queue, regardless of the channel's drop
+// policy, or until the context is done.
+func (oplb *OutgoingChannelLoadBalancer) EnqueueWithContext(ctx context.Context, channel string, data *SendableData) error {
+	for {
+		oplb.mut.Lock()
+		ch := oplb.getChannelOrDefault(channel)
+		if !ch.isFull() {
+			err := ch.push(data)
+			oplb.mut.Unlock()
+			if err != nil {
+				return err
+			}
+
+			oplb.notifyNewData()
+
+			return nil
+		}
+
+		oplb.numBlockedEnqueues++
+		chanSpaceFreed := oplb.chanSpaceFreed
+		oplb.mut.Unlock()
+
+		var err error
+		select {
+		case <-chanSpaceFreed:
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-oplb.ctx.Done():
+			err = p2p.ErrLoadBalancerClosed
+		}
 
-	ch := oplb.namesChans[channel]
-	if ch != nil {
-		return ch
+		oplb.mut.Lock()
+		oplb.numBlockedEnqueues--
+		oplb.mut.Unlock()
+
+		if err != nil {
+			return err
+		}
 	}
+}
 
-	return oplb.chans[0]
+func (oplb *OutgoingChannelLoadBalancer) notifyNewData() {
+	select {
+	case oplb.chanNewData <- struct{}{}:
+	default:
+	}
 }
 
-// CollectOneElementFromChannels gets the waiting object from mainChan. It is a blocking call.
+// CollectOneElementFromChannels gets the next object to be sent, as decided by the scheduler. It is a blocking call.
 func (oplb *OutgoingChannelLoadBalancer) CollectOneElementFromChannels() *SendableData {
-	select {
-	case obj := <-oplb.mainChan:
-		return obj
-	case <-oplb.ctx.Done():
+	for {
+		oplb.mut.Lock()
+		data := oplb.selectNext()
+		oplb.mut.Unlock()
+
+		if data != nil {
+			return data
+		}
+
+		select {
+		case <-oplb.chanNewData:
+		case <-oplb.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// selectNext picks the next object using the smooth weighted round-robin algorithm on the non-empty channels
+// with the highest priority
+func (oplb *OutgoingChannelLoadBalancer) selectNext() *SendableData {
+	var selected *outgoingChannel
+	totalWeight := int64(0)
+	for _, ch := range oplb.channels {
+		if selected != nil && ch.options.Priority < selected.options.Priority {
+			break
+		}
+		if len(ch.queue) == 0 {
+			continue
+		}
+
+		ch.currentWeight += int64(ch.options.Weight)
+		totalWeight += int64(ch.options.Weight)
+		if selected == nil || ch.currentWeight > selected.currentWeight {
+			selected = ch
+		}
+	}
+
+	if selected == nil {
 		return nil
 	}
+
+	selected.currentWeight -= totalWeight
+	if oplb.numBlockedEnqueues > 0 {
+		close(oplb.chanSpaceFreed)
+		oplb.chanSpaceFreed = make(chan struct{})
+	}
+
+	return selected.pop()
+}
+
+// ChannelsStatistics returns the settings and the counters of all the channels
+func (oplb *OutgoingChannelLoadBalancer) ChannelsStatistics() []OutgoingChannelStatistics {
+	oplb.mut.Lock()
+	defer oplb.mut.Unlock()
+
+	statistics := make([]OutgoingChannelStatistics, 0, len(oplb.channels))
+	for _, ch := range oplb.channels {
+		statistics = append(statistics, OutgoingChannelStatistics{
+			Name:       ch.name,
+			Priority:   ch.options.Priority,
+			Weight:     ch.options.Weight,
+			QueueLen:   len(ch.queue),
+			NumQueued:  ch.numQueued,
+			NumDropped: ch.numDropped,
+		})
+	}
+
+	return statistics
 }
 
 // Close finishes all started go routines in this instance
@@ -1,6 +1,7 @@
 package libp2p_test
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"testing"
@@ -9,40 +10,36 @@ import (
 	"github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-var errLenDifferent = errors.New("len different for names and chans")
-var errLenDifferentNamesChans = errors.New("len different for names and chans")
-var errMissingChannel = errors.New("missing channel")
-var errChannelsMismatch = errors.New("channels mismatch")
 var durationWait = time.Second * 2
 
-func checkIntegrity(oclb *libp2p.OutgoingChannelLoadBalancer, name string) error {
-	if len(oclb.Names()) != len(oclb.Chans()) {
-		return errLenDifferent
-	}
+func createOutgoingChannelOptions(priority uint32, weight uint32) p2p.OutgoingChannelOptions {
+	options := p2p.DefaultOutgoingChannelOptions()
+	options.Priority = priority
+	options.Weight = weight
 
-	if len(oclb.Names()) != len(oclb.NamesChans()) {
-		return errLenDifferentNamesChans
-	}
+	return options
+}
 
-	idxFound := -1
-	for i, n := range oclb.Names() {
-		if n == name {
-			idxFound = i
-			break
+func getChannelStatistics(oclb *libp2p.OutgoingChannelLoadBalancer, name string) libp2p.OutgoingChannelStatistics {
+	for _, statistics := range oclb.ChannelsStatistics() {
+		if statistics.Name == name {
+			return statistics
 		}
 	}
 
-	if idxFound == -1 && oclb.NamesChans()[name] == nil {
-		return errMissingChannel
-	}
+	return libp2p.OutgoingChannelStatistics{}
+}
 
-	if oclb.NamesChans()[name] != oclb.Chans()[idxFound] {
-		return errChannelsMismatch
+func collectTopics(oclb *libp2p.OutgoingChannelLoadBalancer, numElements int) []string {
+	topics := make([]string, 0, numElements)
+	for i := 0; i < numElements; i++ {
+		topics = append(topics, oclb.CollectOneElementFromChannels().Topic)
 	}
 
-	return nil
+	return topics
 }
 
 //------- NewOutgoingChannelLoadBalancer
@@ -60,8 +57,7 @@ func TestNewOutgoingChannelLoadBalancer_ShouldAddDefaultChannel(t *testing.T) {
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	assert.Equal(t, 1, len(oclb.Names()))
-	assert.Nil(t, checkIntegrity(oclb, libp2p.DefaultSendChannel()))
+	assert.Equal(t, []string{libp2p.DefaultSendChannel()}, oclb.Names())
 }
 
 //------- AddChannel
@@ -71,12 +67,12 @@ func TestOutgoingChannelLoadBalancer_AddChannelNewChannelShouldNotErrAndAddNewCh
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	err := oclb.AddChannel("test")
+	err := oclb.AddChannel("test", p2p.DefaultOutgoingChannelOptions())
 
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(oclb.Names()))
-	assert.Nil(t, checkIntegrity(oclb, libp2p.DefaultSendChannel()))
-	assert.Nil(t, checkIntegrity(oclb, "test"))
+	assert.True(t, oclb.HasChannel(libp2p.DefaultSendChannel()))
+	assert.True(t, oclb.HasChannel("test"))
 }
 
 func TestOutgoingChannelLoadBalancer_AddChannelDefaultChannelShouldErr(t *testing.T) {
@@ -84,7 +80,7 @@ func TestOutgoingChannelLoadBalancer_AddChannelDefaultChannelShouldErr(t *testin
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	err := oclb.AddChannel(libp2p.DefaultSendChannel())
+	err := oclb.AddChannel(libp2p.DefaultSendChannel(), p2p.DefaultOutgoingChannelOptions())
 
 	assert.Equal(t, p2p.ErrChannelCanNotBeReAdded, err)
 }
@@ -94,11 +90,62 @@ func TestOutgoingChannelLoadBalancer_AddChannelReAddChannelShouldDoNothing(t *te
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	_ = oclb.AddChannel("test")
-	err := oclb.AddChannel("test")
+	_ = oclb.AddChannel("test", p2p.DefaultOutgoingChannelOptions())
+	err := oclb.AddChannel("test", createOutgoingChannelOptions(10, 1))
 
 	assert.Nil(t, err)
-	assert.Equal(t, 2, len(oclb.Chans()))
+	assert.Equal(t, 2, len(oclb.Names()))
+	assert.Equal(t, uint32(0), getChannelStatistics(oclb, "test").Priority)
+}
+
+func TestOutgoingChannelLoadBalancer_AddChannelInvalidOptionsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zero weight should error", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		err := oclb.AddChannel("test", createOutgoingChannelOptions(0, 0))
+
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.False(t, oclb.HasChannel("test"))
+	})
+	t.Run("zero queue size should error", func(t *testing.T) {
+		t.Parallel()
+
+		options := p2p.DefaultOutgoingChannelOptions()
+		options.QueueSize = 0
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		err := oclb.AddChannel("test", options)
+
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.False(t, oclb.HasChannel("test"))
+	})
+	t.Run("unknown drop policy should error", func(t *testing.T) {
+		t.Parallel()
+
+		options := p2p.DefaultOutgoingChannelOptions()
+		options.DropPolicy = "unknown"
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		err := oclb.AddChannel("test", options)
+
+		assert.True(t, errors.Is(err, p2p.ErrInvalidDropPolicy))
+		assert.False(t, oclb.HasChannel("test"))
+	})
+}
+
+func TestOutgoingChannelLoadBalancer_AddChannelShouldKeepChannelsSortedByPriority(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+	_ = oclb.AddChannel("low1", createOutgoingChannelOptions(0, 1))
+	_ = oclb.AddChannel("high", createOutgoingChannelOptions(10, 1))
+	_ = oclb.AddChannel("medium", createOutgoingChannelOptions(5, 1))
+	_ = oclb.AddChannel("low2", createOutgoingChannelOptions(0, 1))
+
+	expectedNames := []string{"high", "medium", libp2p.DefaultSendChannel(), "low1", "low2"}
+	assert.Equal(t, expectedNames, oclb.Names())
 }
 
 //------- RemoveChannel
@@ -128,19 +175,15 @@ func TestOutgoingChannelLoadBalancer_RemoveChannelRemoveLastChannelAddedShouldWo
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	_ = oclb.AddChannel("test1")
-	_ = oclb.AddChannel("test2")
-	_ = oclb.AddChannel("test3")
+	_ = oclb.AddChannel("test1", p2p.DefaultOutgoingChannelOptions())
+	_ = oclb.AddChannel("test2", p2p.DefaultOutgoingChannelOptions())
+	_ = oclb.AddChannel("test3", p2p.DefaultOutgoingChannelOptions())
 
 	err := oclb.RemoveChannel("test3")
 
 	assert.Nil(t, err)
-
-	assert.Equal(t, 3, len(oclb.Names()))
-	assert.Nil(t, checkIntegrity(oclb, libp2p.DefaultSendChannel()))
-	assert.Nil(t, checkIntegrity(oclb, "test1"))
-	assert.Nil(t, checkIntegrity(oclb, "test2"))
-	assert.Equal(t, errMissingChannel, checkIntegrity(oclb, "test3"))
+	assert.Equal(t, []string{libp2p.DefaultSendChannel(), "test1", "test2"}, oclb.Names())
+	assert.False(t, oclb.HasChannel("test3"))
 }
 
 func TestOutgoingChannelLoadBalancer_RemoveChannelRemoveFirstChannelAddedShouldWork(t *testing.T) {
@@ -148,19 +191,15 @@ func TestOutgoingChannelLoadBalancer_RemoveChannelRemoveFirstChannelAddedShouldW
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	_ = oclb.AddChannel("test1")
-	_ = oclb.AddChannel("test2")
-	_ = oclb.AddChannel("test3")
+	_ = oclb.AddChannel("test1", p2p.DefaultOutgoingChannelOptions())
+	_ = oclb.AddChannel("test2", p2p.DefaultOutgoingChannelOptions())
+	_ = oclb.AddChannel("test3", p2p.DefaultOutgoingChannelOptions())
 
 	err := oclb.RemoveChannel("test1")
 
 	assert.Nil(t, err)
-
-	assert.Equal(t, 3, len(oclb.Names()))
-	assert.Nil(t, checkIntegrity(oclb, libp2p.DefaultSendChannel()))
-	assert.Equal(t, errMissingChannel, checkIntegrity(oclb, "test1"))
-	assert.Nil(t, checkIntegrity(oclb, "test2"))
-	assert.Nil(t, checkIntegrity(oclb, "test3"))
+	assert.Equal(t, []string{libp2p.DefaultSendChannel(), "test2", "test3"}, oclb.Names())
+	assert.False(t, oclb.HasChannel("test1"))
 }
 
 func TestOutgoingChannelLoadBalancer_RemoveChannelRemoveMiddleChannelAddedShouldWork(t *testing.T) {
@@ -168,45 +207,104 @@ func TestOutgoingChannelLoadBalancer_RemoveChannelRemoveMiddleChannelAddedShould
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	_ = oclb.AddChannel("test1")
-	_ = oclb.AddChannel("test2")
-	_ = oclb.AddChannel("test3")
+	_ = oclb.AddChannel("test1", p2p.DefaultOutgoingChannelOptions())
+	_ = oclb.AddChannel("test2", p2p.DefaultOutgoingChannelOptions())
+	_ = oclb.AddChannel("test3", p2p.DefaultOutgoingChannelOptions())
 
 	err := oclb.RemoveChannel("test2")
 
 	assert.Nil(t, err)
+	assert.Equal(t, []string{libp2p.DefaultSendChannel(), "test1", "test3"}, oclb.Names())
+	assert.False(t, oclb.HasChannel("test2"))
+}
+
+func TestOutgoingChannelLoadBalancer_RemoveChannelShouldDropPendingData(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	assert.Equal(t, 3, len(oclb.Names()))
-	assert.Nil(t, checkIntegrity(oclb, libp2p.DefaultSendChannel()))
-	assert.Nil(t, checkIntegrity(oclb, "test1"))
-	assert.Equal(t, errMissingChannel, checkIntegrity(oclb, "test2"))
-	assert.Nil(t, checkIntegrity(oclb, "test3"))
+	_ = oclb.AddChannel("test", p2p.DefaultOutgoingChannelOptions())
+	_ = oclb.Enqueue("test", &libp2p.SendableData{Topic: "test"})
+	_ = oclb.RemoveChannel("test")
+	_ = oclb.Enqueue(libp2p.DefaultSendChannel(), &libp2p.SendableData{Topic: "default"})
+
+	assert.Equal(t, "default", oclb.CollectOneElementFromChannels().Topic)
 }
 
-//------- GetChannelOrDefault
+//------- Enqueue
 
-func TestOutgoingChannelLoadBalancer_GetChannelOrDefaultNotFoundShouldReturnDefault(t *testing.T) {
+func TestOutgoingChannelLoadBalancer_EnqueueNotFoundShouldUseDefault(t *testing.T) {
 	t.Parallel()
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	_ = oclb.AddChannel("test1")
+	_ = oclb.AddChannel("test1", p2p.DefaultOutgoingChannelOptions())
 
-	channel := oclb.GetChannelOrDefault("missing channel")
+	err := oclb.Enqueue("missing channel", &libp2p.SendableData{})
 
-	assert.True(t, oclb.NamesChans()[libp2p.DefaultSendChannel()] == channel)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, getChannelStatistics(oclb, libp2p.DefaultSendChannel()).QueueLen)
+	assert.Equal(t, 0, getChannelStatistics(oclb, "test1").QueueLen)
 }
 
-func TestOutgoingChannelLoadBalancer_GetChannelOrDefaultFoundShouldReturnChannel(t *testing.T) {
+func TestOutgoingChannelLoadBalancer_EnqueueFoundShouldUseChannel(t *testing.T) {
 	t.Parallel()
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	_ = oclb.AddChannel("test1")
+	_ = oclb.AddChannel("test1", p2p.DefaultOutgoingChannelOptions())
 
-	channel := oclb.GetChannelOrDefault("test1")
+	err := oclb.Enqueue("test1", &libp2p.SendableData{})
 
-	assert.True(t, oclb.NamesChans()["test1"] == channel)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, getChannelStatistics(oclb, libp2p.DefaultSendChannel()).QueueLen)
+	assert.Equal(t, 1, getChannelStatistics(oclb, "test1").QueueLen)
+}
+
+func TestOutgoingChannelLoadBalancer_EnqueueFullQueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drop newest should drop the new data", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		_ = oclb.AddChannel("test", p2p.OutgoingChannelOptions{
+			Weight:     1,
+			QueueSize:  2,
+			DropPolicy: p2p.DropNewestPolicy,
+		})
+
+		assert.Nil(t, oclb.Enqueue("test", &libp2p.SendableData{Topic: "1"}))
+		assert.Nil(t, oclb.Enqueue("test", &libp2p.SendableData{Topic: "2"}))
+		err := oclb.Enqueue("test", &libp2p.SendableData{Topic: "3"})
+
+		assert.True(t, errors.Is(err, p2p.ErrOutgoingQueueFull))
+		statistics := getChannelStatistics(oclb, "test")
+		assert.Equal(t, 2, statistics.QueueLen)
+		assert.Equal(t, uint64(2), statistics.NumQueued)
+		assert.Equal(t, uint64(1), statistics.NumDropped)
+		assert.Equal(t, []string{"1", "2"}, collectTopics(oclb, 2))
+	})
+	t.Run("drop oldest should drop the first data", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		_ = oclb.AddChannel("test", p2p.OutgoingChannelOptions{
+			Weight:     1,
+			QueueSize:  2,
+			DropPolicy: p2p.DropOldestPolicy,
+		})
+
+		assert.Nil(t, oclb.Enqueue("test", &libp2p.SendableData{Topic: "1"}))
+		assert.Nil(t, oclb.Enqueue("test", &libp2p.SendableData{Topic: "2"}))
+		assert.Nil(t, oclb.Enqueue("test", &libp2p.SendableData{Topic: "3"}))
+
+		statistics := getChannelStatistics(oclb, "test")
+		assert.Equal(t, 2, statistics.QueueLen)
+		assert.Equal(t, uint64(3), statistics.NumQueued)
+		assert.Equal(t, uint64(1), statistics.NumDropped)
+		assert.Equal(t, []string{"2", "3"}, collectTopics(oclb, 2))
+	})
 }
 
 //------- CollectOneElementFromChannels
@@ -231,12 +329,32 @@ func TestOutgoingChannelLoadBalancer_CollectFromChannelsNoObjectsShouldWaitBlock
 	}
 }
 
+func TestOutgoingChannelLoadBalancer_CollectFromChannelsShouldUnblockOnClose(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+	chanDone := make(chan *libp2p.SendableData)
+	go func() {
+		chanDone <- oclb.CollectOneElementFromChannels()
+	}()
+
+	_ = oclb.Close()
+
+	select {
+	case obj := <-chanDone:
+		assert.Nil(t, obj)
+	case <-time.After(durationWait):
+		assert.Fail(t, "timeout")
+	}
+}
+
 func TestOutgoingChannelLoadBalancer_CollectOneElementFromChannelsShouldWork(t *testing.T) {
 	t.Parallel()
 
 	oclb := libp2p.NewOutgoingChannelLoadBalancer()
 
-	_ = oclb.AddChannel("test")
+	_ = oclb.AddChannel("test", p2p.DefaultOutgoingChannelOptions())
 
 	obj1 := &libp2p.SendableData{Topic: "test"}
 	obj2 := &libp2p.SendableData{Topic: "default"}
@@ -247,13 +365,13 @@ func TestOutgoingChannelLoadBalancer_CollectOneElementFromChannelsShouldWork(t *
 
 	//send on channel test
 	go func() {
-		oclb.GetChannelOrDefault("test") <- obj1
+		_ = oclb.Enqueue("test", obj1)
 		wg.Done()
 	}()
 
 	//send on default channel
 	go func() {
-		oclb.GetChannelOrDefault(libp2p.DefaultSendChannel()) <- obj2
+		_ = oclb.Enqueue(libp2p.DefaultSendChannel(), obj2)
 		wg.Done()
 	}()
 
@@ -299,3 +417,135 @@ func TestOutgoingChannelLoadBalancer_CollectOneElementFromChannelsShouldWork(t *
 		return
 	}
 }
+
+func TestOutgoingChannelLoadBalancer_CollectOneElementFromChannelsShouldServeHigherPriorityFirst(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+	_ = oclb.AddChannel("transactions", createOutgoingChannelOptions(0, 100))
+	_ = oclb.AddChannel("consensus", createOutgoingChannelOptions(10, 1))
+
+	for i := 0; i < 5; i++ {
+		require.Nil(t, oclb.Enqueue("transactions", &libp2p.SendableData{Topic: "transactions"}))
+	}
+	for i := 0; i < 2; i++ {
+		require.Nil(t, oclb.Enqueue("consensus", &libp2p.SendableData{Topic: "consensus"}))
+	}
+
+	expectedTopics := []string{"consensus", "consensus", "transactions", "transactions", "transactions"}
+	assert.Equal(t, expectedTopics, collectTopics(oclb, 5))
+}
+
+func TestOutgoingChannelLoadBalancer_CollectOneElementFromChannelsShouldShareByWeight(t *testing.T) {
+	t.Parallel()
+
+	oclb := libp2p.NewOutgoingChannelLoadBalancer()
+
+	_ = oclb.AddChannel("heavy", createOutgoingChannelOptions(0, 3))
+	_ = oclb.AddChannel("light", createOutgoingChannelOptions(0, 1))
+
+	numElements := 40
+	for i := 0; i < numElements; i++ {
+		require.Nil(t, oclb.Enqueue("heavy", &libp2p.SendableData{Topic: "heavy"}))
+		require.Nil(t, oclb.Enqueue("light", &libp2p.SendableData{Topic: "light"}))
+	}
+
+	counts := make(map[string]int)
+	for _, topic := range collectTopics(oclb, numElements) {
+		counts[topic]++
+	}
+
+	assert.Equal(t, 30, counts["heavy"])
+	assert.Equal(t, 10, counts["light"])
+
+	// the light channel is not starved, being served at least once in every 4 sends
+	assert.Equal(t, []string{"heavy", "heavy", "light", "heavy"}, collectTopics(oclb, 4))
+}
+
+//------- EnqueueWithContext
+
+func TestOutgoingChannelLoadBalancer_EnqueueWithContext(t *testing.T) {
+	t.Parallel()
+
+	createFullChannel := func() *libp2p.OutgoingChannelLoadBalancer {
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		_ = oclb.AddChannel("test", p2p.OutgoingChannelOptions{
+			Weight:     1,
+			QueueSize:  1,
+			DropPolicy: p2p.DropOldestPolicy,
+		})
+		_ = oclb.Enqueue("test", &libp2p.SendableData{Topic: "1"})
+
+		return oclb
+	}
+
+	t.Run("queue with room should not block", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := libp2p.NewOutgoingChannelLoadBalancer()
+		err := oclb.EnqueueWithContext(context.Background(), "missing channel", &libp2p.SendableData{Topic: "1"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "1", oclb.CollectOneElementFromChannels().Topic)
+	})
+	t.Run("full queue should block until an element is collected", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := createFullChannel()
+		chanDone := make(chan error)
+		go func() {
+			chanDone <- oclb.EnqueueWithContext(context.Background(), "test", &libp2p.SendableData{Topic: "2"})
+		}()
+
+		select {
+		case <-chanDone:
+			require.Fail(t, "should have blocked")
+		case <-time.After(time.Millisecond * 100):
+		}
+
+		assert.Equal(t, "1", oclb.CollectOneElementFromChannels().Topic)
+		select {
+		case err := <-chanDone:
+			assert.Nil(t, err)
+		case <-time.After(durationWait):
+			require.Fail(t, "timeout")
+		}
+
+		// the drop oldest policy is not applied on the blocking calls
+		statistics := getChannelStatistics(oclb, "test")
+		assert.Equal(t, uint64(0), statistics.NumDropped)
+		assert.Equal(t, "2", oclb.CollectOneElementFromChannels().Topic)
+	})
+	t.Run("context done should return the context error", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := createFullChannel()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+
+		err := oclb.EnqueueWithContext(ctx, "test", &libp2p.SendableData{Topic: "2"})
+
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Equal(t, 1, getChannelStatistics(oclb, "test").QueueLen)
+	})
+	t.Run("closed load balancer should error", func(t *testing.T) {
+		t.Parallel()
+
+		oclb := createFullChannel()
+		chanDone := make(chan error)
+		go func() {
+			chanDone <- oclb.EnqueueWithContext(context.Background(), "test", &libp2p.SendableData{Topic: "2"})
+		}()
+
+		time.Sleep(time.Millisecond * 100)
+		_ = oclb.Close()
+
+		select {
+		case err := <-chanDone:
+			assert.Equal(t, p2p.ErrLoadBalancerClosed, err)
+		case <-time.After(durationWait):
+			require.Fail(t, "timeout")
+		}
+	})
+}
@@ -92,6 +92,14 @@ func (poc *peersOnChannel) updateConnectedPeersOnTopic(topic string, connectedPe
 	poc.mutPeers.Unlock()
 }
 
+// removeTopic forgets the connected peers on the provided topic so it won't be refreshed anymore
+func (poc *peersOnChannel) removeTopic(topic string) {
+	poc.mutPeers.Lock()
+	delete(poc.peers, topic)
+	delete(poc.lastUpdated, topic)
+	poc.mutPeers.Unlock()
+}
+
 // refreshPeersOnAllKnownTopics iterates each topic, fetching its last timestamp
 // it the timestamp + ttlInterval < time.Now, will trigger a fetch of connected peers on topic
 func (poc *peersOnChannel) refreshPeersOnAllKnownTopics(ctx context.Context) {
@@ -163,3 +163,35 @@ func TestPeersOnChannel_RefreshShouldBeDone(t *testing.T) {
 	assert.True(t, wasFetchCalled.IsSet())
 	assert.Empty(t, poc.GetPeers(testTopic))
 }
+
+func TestPeersOnChannel_RemoveTopicShouldStopRefreshing(t *testing.T) {
+	t.Parallel()
+
+	wasFetchCalled := coreAtomic.Flag{}
+	wasFetchCalled.Reset()
+
+	refreshInterval := time.Millisecond * 100
+	ttlInterval := time.Duration(2)
+
+	poc, _ := libp2p.NewPeersOnChannel(
+		&mock.PeersRatingHandlerStub{},
+		func(topic string) []peer.ID {
+			wasFetchCalled.SetValue(true)
+			return nil
+		},
+		refreshInterval,
+		ttlInterval,
+	)
+	poc.SetTimeHandler(func() time.Time {
+		return time.Unix(0, 4)
+	})
+	// manually put peers
+	poc.SetPeersOnTopic(testTopic, time.Unix(0, 1), []core.PeerID{"peer1", "peer2"})
+	poc.RemoveTopic(testTopic)
+
+	// wait for the go routine cycle finish up
+	time.Sleep(time.Second)
+
+	assert.False(t, wasFetchCalled.IsSet())
+	assert.Nil(t, poc.GetPeers(testTopic))
+}
@@ -0,0 +1,330 @@
+package libp2p
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/gogo/protobuf/proto"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubPb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/core/throttler"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/data"
+	"github.com/whyrusleeping/timecache"
+)
+
+var _ p2p.RequestSender = (*requestSender)(nil)
+
+const (
+	defaultRequestTimeout = time.Second * 10
+	// requestReadTimeout is the maximum time a responder waits for the request to be fully received
+	requestReadTimeout = time.Second * 5
+	// responseWriteTimeout is the maximum time a responder waits for the response to be fully sent
+	responseWriteTimeout = time.Second * 5
+)
+
+const (
+	responseStatusOk       = uint32(0)
+	responseStatusRejected = uint32(1)
+)
+
+type requestSender struct {
+	counter           uint64
+	ctx               context.Context
+	hostP2P           host.Host
+	requestHandler    func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error)
+	mutSeenMessages   sync.Mutex
+	seenMessages      *timecache.TimeCache
+	signer            p2p.SignerVerifier
+	requestsThrottler *throttler.NumGoRoutinesThrottler
+}
+
+// NewRequestSender returns a new instance of request sender object
+func NewRequestSender(
+	ctx context.Context,
+	h host.Host,
+	requestHandler func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error),
+	signer p2p.SignerVerifier,
+) (*requestSender, error) {
+
+	if h == nil {
+		return nil, p2p.ErrNilHost
+	}
+	if ctx == nil {
+		return nil, p2p.ErrNilContext
+	}
+	if requestHandler == nil {
+		return nil, p2p.ErrNilRequestMessageHandler
+	}
+	if check.IfNil(signer) {
+		return nil, p2p.ErrNilP2PSigner
+	}
+
+	requestsThrottler, err := throttler.NewNumGoRoutinesThrottler(streamHandlerGoRoutines)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &requestSender{
+		counter:           uint64(time.Now().UnixNano()),
+		ctx:               ctx,
+		hostP2P:           h,
+		seenMessages:      timecache.NewTimeCache(timeSeenMessages),
+		requestHandler:    requestHandler,
+		signer:            signer,
+		requestsThrottler: requestsThrottler,
+	}
+
+	// wire-up a handler for the requests
+	h.SetStreamHandler(RequestResponseID, rs.requestStreamHandler)
+
+	return rs, nil
+}
+
+// requestStreamHandler handles exactly one request on each stream: reads the request, calls the request handler
+// and writes back the response before closing the stream. The streams received while too many other requests are
+// being handled are reset
+func (rs *requestSender) requestStreamHandler(s network.Stream) {
+	if !rs.requestsThrottler.CanProcess() {
+		_ = s.Reset()
+		log.Trace("too many requests in progress", "from", s.Conn().RemotePeer())
+		return
+	}
+
+	rs.requestsThrottler.StartProcessing()
+	go func() {
+		defer rs.requestsThrottler.EndProcessing()
+
+		_ = s.SetReadDeadline(time.Now().Add(requestReadTimeout))
+
+		msg := &pubsubPb.Message{}
+		reader := ggio.NewDelimitedReader(s, maxSendBuffSize)
+		err := reader.ReadMsg(msg)
+		if err != nil {
+			_ = s.Reset()
+			log.Trace("error reading request",
+				"from", s.Conn().RemotePeer(),
+				"error", err.Error(),
+			)
+			return
+		}
+
+		response := &data.ResponseMessage{
+			RequestID: msg.GetSeqno(),
+			Status:    responseStatusOk,
+		}
+		response.Payload, err = rs.processReceivedRequest(msg, s.Conn().RemotePeer())
+		if err != nil {
+			log.Trace("p2p processReceivedRequest", "error", err.Error())
+			response.Status = responseStatusRejected
+			response.Payload = nil
+			response.Error = sanitizeRejectionReason(err)
+		}
+
+		_ = s.SetWriteDeadline(time.Now().Add(responseWriteTimeout))
+		err = writeDelimitedMessage(s, response)
+		if err != nil {
+			_ = s.Reset()
+			log.Trace("error writing response",
+				"to", s.Conn().RemotePeer(),
+				"error", err.Error(),
+			)
+			return
+		}
+
+		_ = s.Close()
+	}()
+}
+
+func (rs *requestSender) processReceivedRequest(message *pubsubPb.Message, fromConnectedPeer peer.ID) ([]byte, error) {
+	err := checkReceivedDirectMessage(message, fromConnectedPeer)
+	if err != nil {
+		return nil, err
+	}
+	if rs.checkAndSetSeenMessage(message) {
+		return nil, p2p.ErrAlreadySeenMessage
+	}
+	err = checkMessageSignature(rs.signer, message)
+	if err != nil {
+		return nil, err
+	}
+
+	pbMessage := &pubsub.Message{
+		Message: message,
+	}
+
+	response, err := rs.requestHandler(pbMessage, core.PeerID(fromConnectedPeer))
+	if err != nil {
+		return nil, err
+	}
+	if len(response) >= maxSendBuffSize {
+		return nil, fmt.Errorf("%w for the response, to be sent: %d, maximum: %d",
+			p2p.ErrMessageTooLarge, len(response), maxSendBuffSize)
+	}
+
+	return response, nil
+}
+
+func (rs *requestSender) checkAndSetSeenMessage(msg *pubsubPb.Message) bool {
+	rs.mutSeenMessages.Lock()
+	defer rs.mutSeenMessages.Unlock()
+
+	return checkAndSetSeenMessage(rs.seenMessages, msg)
+}
+
+// NextSequenceNumber returns the next uint64 found in *counter as byte slice
+func (rs *requestSender) NextSequenceNumber() []byte {
+	return nextSequenceNumber(&rs.counter)
+}
+
+// Request will send the request to the connected peer and will wait for its response. The sequence number of the
+// sent message is used as request ID. It returns p2p.ErrRequestTimeout if the response was not received before
+// the context deadline (or the default request timeout if the context has no deadline)
+func (rs *requestSender) Request(ctx context.Context, topic string, buff []byte, pid core.PeerID) ([]byte, error) {
+	if ctx == nil {
+		return nil, p2p.ErrNilContext
+	}
+	if len(buff) >= maxSendBuffSize {
+		return nil, fmt.Errorf("%w, to be sent: %d, maximum: %d", p2p.ErrMessageTooLarge, len(buff), maxSendBuffSize)
+	}
+
+	conn, err := getConnectionToPeer(rs.hostP2P, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	_, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	stream, err := rs.hostP2P.NewStream(ctx, conn.RemotePeer(), RequestResponseID)
+	if err != nil {
+		return nil, convertRequestError(ctx, err)
+	}
+
+	msg, err := createSignedMessage(rs.signer, rs.NextSequenceNumber(), topic, buff, conn)
+	if err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+
+	response, err := exchangeRequestWithContext(ctx, rs.ctx, stream, msg, p2p.ErrRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return processResponse(msg, response)
+}
+
+// exchangeRequestWithContext writes the message on the stream and waits for the response until the context is done.
+// The stream is closed after a successful exchange and reset otherwise
+func exchangeRequestWithContext(
+	ctx context.Context,
+	parentCtx context.Context,
+	stream network.Stream,
+	msg *pubsubPb.Message,
+	errTimeout error,
+) (*data.ResponseMessage, error) {
+	chanResponse := make(chan *data.ResponseMessage, 1)
+	chanErr := make(chan error, 1)
+	go func() {
+		response, errExchange := exchangeRequest(stream, msg)
+		if errExchange != nil {
+			chanErr <- errExchange
+			return
+		}
+
+		chanResponse <- response
+	}()
+
+	select {
+	case response := <-chanResponse:
+		_ = stream.Close()
+		return response, nil
+	case err := <-chanErr:
+		_ = stream.Reset()
+		return nil, convertTimeoutError(ctx, err, errTimeout)
+	case <-ctx.Done():
+		_ = stream.Reset()
+		return nil, convertTimeoutError(ctx, ctx.Err(), errTimeout)
+	case <-parentCtx.Done():
+		_ = stream.Reset()
+		return nil, parentCtx.Err()
+	}
+}
+
+func exchangeRequest(stream network.Stream, msg *pubsubPb.Message) (*data.ResponseMessage, error) {
+	err := writeDelimitedMessage(stream, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.CloseWrite()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &data.ResponseMessage{}
+	reader := ggio.NewDelimitedReader(stream, maxSendBuffSize)
+	err = reader.ReadMsg(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func writeDelimitedMessage(stream network.Stream, msg proto.Message) error {
+	bufw := bufio.NewWriter(stream)
+	w := ggio.NewDelimitedWriter(bufw)
+
+	err := w.WriteMsg(msg)
+	if err != nil {
+		return err
+	}
+
+	return bufw.Flush()
+}
+
+func processResponse(msg *pubsubPb.Message, response *data.ResponseMessage) ([]byte, error) {
+	if !bytes.Equal(msg.GetSeqno(), response.RequestID) {
+		return nil, p2p.ErrRequestIDMismatch
+	}
+	if response.Status != responseStatusOk {
+		return nil, fmt.Errorf("%w, reason: %s", p2p.ErrRequestRejected, response.Error)
+	}
+
+	return response.Payload, nil
+}
+
+func convertRequestError(ctx context.Context, err error) error {
+	return convertTimeoutError(ctx, err, p2p.ErrRequestTimeout)
+}
+
+func convertTimeoutError(ctx context.Context, err error, errTimeout error) error {
+	isTimeout := errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded)
+	if isTimeout {
+		return fmt.Errorf("%w: %s", errTimeout, err.Error())
+	}
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (rs *requestSender) IsInterfaceNil() bool {
+	return rs == nil
+}
@@ -0,0 +1,348 @@
+package libp2p_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var blankRequestHandler = func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error) {
+	return nil, nil
+}
+
+func createConnectedMockHosts(t *testing.T) (host.Host, host.Host) {
+	netw := mocknet.New()
+	h1, err := netw.GenPeer()
+	require.Nil(t, err)
+	h2, err := netw.GenPeer()
+	require.Nil(t, err)
+
+	err = netw.LinkAll()
+	require.Nil(t, err)
+	err = netw.ConnectAllButSelf()
+	require.Nil(t, err)
+
+	return h1, h2
+}
+
+func TestNewRequestSender(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context", func(t *testing.T) {
+		t.Parallel()
+
+		var ctx context.Context = nil
+		rs, err := libp2p.NewRequestSender(
+			ctx,
+			generateHostStub(),
+			blankRequestHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		assert.True(t, check.IfNil(rs))
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("nil host", func(t *testing.T) {
+		t.Parallel()
+
+		rs, err := libp2p.NewRequestSender(
+			context.Background(),
+			nil,
+			blankRequestHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		assert.True(t, check.IfNil(rs))
+		assert.Equal(t, p2p.ErrNilHost, err)
+	})
+	t.Run("nil request handler", func(t *testing.T) {
+		t.Parallel()
+
+		rs, err := libp2p.NewRequestSender(
+			context.Background(),
+			generateHostStub(),
+			nil,
+			&mock.P2PSignerStub{},
+		)
+
+		assert.True(t, check.IfNil(rs))
+		assert.Equal(t, p2p.ErrNilRequestMessageHandler, err)
+	})
+	t.Run("nil signer", func(t *testing.T) {
+		t.Parallel()
+
+		rs, err := libp2p.NewRequestSender(
+			context.Background(),
+			generateHostStub(),
+			blankRequestHandler,
+			nil,
+		)
+
+		assert.True(t, check.IfNil(rs))
+		assert.Equal(t, p2p.ErrNilP2PSigner, err)
+	})
+	t.Run("should work and set the stream handler", func(t *testing.T) {
+		t.Parallel()
+
+		var pidCalled protocol.ID
+		var handlerCalled network.StreamHandler
+		hs := &mock.ConnectableHostStub{
+			SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {
+				pidCalled = pid
+				handlerCalled = handler
+			},
+		}
+
+		rs, err := libp2p.NewRequestSender(
+			context.Background(),
+			hs,
+			blankRequestHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		assert.False(t, check.IfNil(rs))
+		assert.Nil(t, err)
+		assert.NotNil(t, handlerCalled)
+		assert.Equal(t, libp2p.RequestResponseID, pidCalled)
+	})
+}
+
+func TestRequestSender_Request(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil context should error", func(t *testing.T) {
+		t.Parallel()
+
+		rs, _ := libp2p.NewRequestSender(
+			context.Background(),
+			generateHostStub(),
+			blankRequestHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		var ctx context.Context = nil
+		response, err := rs.Request(ctx, "topic", []byte("data"), "pid")
+		assert.Nil(t, response)
+		assert.Equal(t, p2p.ErrNilContext, err)
+	})
+	t.Run("buffer too large should error", func(t *testing.T) {
+		t.Parallel()
+
+		rs, _ := libp2p.NewRequestSender(
+			context.Background(),
+			generateHostStub(),
+			blankRequestHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		messageTooLarge := bytes.Repeat([]byte{65}, libp2p.MaxSendBuffSize)
+		response, err := rs.Request(context.Background(), "topic", messageTooLarge, "pid")
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, p2p.ErrMessageTooLarge))
+	})
+	t.Run("not connected peer should error", func(t *testing.T) {
+		t.Parallel()
+
+		netw := &mock.NetworkStub{
+			ConnsToPeerCalled: func(p peer.ID) []network.Conn {
+				return make([]network.Conn, 0)
+			},
+		}
+		rs, _ := libp2p.NewRequestSender(
+			context.Background(),
+			&mock.ConnectableHostStub{
+				SetStreamHandlerCalled: func(pid protocol.ID, handler network.StreamHandler) {},
+				NetworkCalled: func() network.Network {
+					return netw
+				},
+			},
+			blankRequestHandler,
+			&mock.P2PSignerStub{},
+		)
+
+		response, err := rs.Request(context.Background(), "topic", []byte("data"), "not connected peer")
+		assert.Nil(t, response)
+		assert.Equal(t, p2p.ErrPeerNotDirectlyConnected, err)
+	})
+	t.Run("should receive the response", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		requestData := []byte("request")
+		responseData := []byte("response")
+		topic := "topic"
+		rs1, _ := libp2p.NewRequestSender(context.Background(), h1, blankRequestHandler, &mock.P2PSignerStub{})
+		_, _ = libp2p.NewRequestSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error) {
+				assert.Equal(t, requestData, msg.Data)
+				assert.Equal(t, topic, *msg.Topic)
+				assert.Equal(t, core.PeerID(h1.ID()), fromConnectedPeer)
+
+				return responseData, nil
+			},
+			&mock.P2PSignerStub{},
+		)
+
+		response, err := rs1.Request(context.Background(), topic, requestData, core.PeerID(h2.ID()))
+		assert.Nil(t, err)
+		assert.Equal(t, responseData, response)
+	})
+	t.Run("handler rejects the request should error", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		expectedErr := errors.New("expected error")
+		rs1, _ := libp2p.NewRequestSender(context.Background(), h1, blankRequestHandler, &mock.P2PSignerStub{})
+		_, _ = libp2p.NewRequestSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error) {
+				return []byte("response"), expectedErr
+			},
+			&mock.P2PSignerStub{},
+		)
+
+		response, err := rs1.Request(context.Background(), "topic", []byte("request"), core.PeerID(h2.ID()))
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, p2p.ErrRequestRejected))
+		assert.Contains(t, err.Error(), libp2p.GenericRejectionReason)
+		assert.NotContains(t, err.Error(), expectedErr.Error())
+	})
+	t.Run("signature verification fails should error", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		expectedErr := errors.New("invalid signature")
+		rs1, _ := libp2p.NewRequestSender(
+			context.Background(),
+			h1,
+			blankRequestHandler,
+			&mock.P2PSignerStub{
+				SignCalled: func(payload []byte) ([]byte, error) {
+					return []byte("signature"), nil
+				},
+			},
+		)
+		_, _ = libp2p.NewRequestSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error) {
+				assert.Fail(t, "should have not called the request handler")
+				return nil, nil
+			},
+			&mock.P2PSignerStub{
+				VerifyCalled: func(payload []byte, pid core.PeerID, signature []byte) error {
+					return expectedErr
+				},
+			},
+		)
+
+		response, err := rs1.Request(context.Background(), "topic", []byte("request"), core.PeerID(h2.ID()))
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, p2p.ErrRequestRejected))
+		assert.Contains(t, err.Error(), libp2p.GenericRejectionReason)
+		assert.NotContains(t, err.Error(), expectedErr.Error())
+	})
+	t.Run("response not received in time should error", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		rs1, _ := libp2p.NewRequestSender(context.Background(), h1, blankRequestHandler, &mock.P2PSignerStub{})
+		_, _ = libp2p.NewRequestSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error) {
+				time.Sleep(time.Second)
+				return []byte("response"), nil
+			},
+			&mock.P2PSignerStub{},
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+		defer cancel()
+
+		response, err := rs1.Request(ctx, "topic", []byte("request"), core.PeerID(h2.ID()))
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, p2p.ErrRequestTimeout))
+	})
+	t.Run("too many requests in progress should reset the stream", func(t *testing.T) {
+		t.Parallel()
+
+		h1, h2 := createConnectedMockHosts(t)
+		defer func() {
+			_ = h1.Close()
+			_ = h2.Close()
+		}()
+
+		chHandlerStarted := make(chan struct{}, 1)
+		chReleaseHandler := make(chan struct{})
+		numHandlerCalls := uint32(0)
+		rs1, _ := libp2p.NewRequestSender(context.Background(), h1, blankRequestHandler, &mock.P2PSignerStub{})
+		rs2, _ := libp2p.NewRequestSender(
+			context.Background(),
+			h2,
+			func(msg *pubsub.Message, fromConnectedPeer core.PeerID) ([]byte, error) {
+				atomic.AddUint32(&numHandlerCalls, 1)
+				chHandlerStarted <- struct{}{}
+				<-chReleaseHandler
+				return []byte("response"), nil
+			},
+			&mock.P2PSignerStub{},
+		)
+		rs2.SetRequestsThrottler(1)
+
+		chFirstResponse := make(chan []byte, 1)
+		go func() {
+			response, _ := rs1.Request(context.Background(), "topic", []byte("request1"), core.PeerID(h2.ID()))
+			chFirstResponse <- response
+		}()
+		<-chHandlerStarted
+
+		response, err := rs1.Request(context.Background(), "topic", []byte("request2"), core.PeerID(h2.ID()))
+		assert.Nil(t, response)
+		assert.NotNil(t, err)
+
+		close(chReleaseHandler)
+		assert.Equal(t, []byte("response"), <-chFirstResponse)
+		assert.Equal(t, uint32(1), atomic.LoadUint32(&numHandlerCalls))
+	})
+}
@@ -0,0 +1,73 @@
+package libp2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// temporaryConnections keeps track of the connections opened only for sending direct messages, together with the
+// moment each of them expires
+type temporaryConnections struct {
+	mut         sync.Mutex
+	expirations map[peer.ID]time.Time
+	timeHandler func() time.Time
+}
+
+func newTemporaryConnections() *temporaryConnections {
+	return &temporaryConnections{
+		expirations: make(map[peer.ID]time.Time),
+		timeHandler: time.Now,
+	}
+}
+
+// upsert marks the connection to the provided peer as temporary. An existing expiry is only extended, never shortened
+func (tc *temporaryConnections) upsert(pid peer.ID, ttl time.Duration) {
+	tc.mut.Lock()
+	defer tc.mut.Unlock()
+
+	expiry := tc.timeHandler().Add(ttl)
+	existing, found := tc.expirations[pid]
+	if found && existing.After(expiry) {
+		return
+	}
+
+	tc.expirations[pid] = expiry
+}
+
+// has returns true if the connection to the provided peer is tracked as temporary
+func (tc *temporaryConnections) has(pid peer.ID) bool {
+	tc.mut.Lock()
+	defer tc.mut.Unlock()
+
+	_, found := tc.expirations[pid]
+
+	return found
+}
+
+// remove stops tracking the connection to the provided peer
+func (tc *temporaryConnections) remove(pid peer.ID) {
+	tc.mut.Lock()
+	delete(tc.expirations, pid)
+	tc.mut.Unlock()
+}
+
+// popExpired returns and stops tracking all the expired connections
+func (tc *temporaryConnections) popExpired() []peer.ID {
+	tc.mut.Lock()
+	defer tc.mut.Unlock()
+
+	now := tc.timeHandler()
+	expired := make([]peer.ID, 0)
+	for pid, expiry := range tc.expirations {
+		if expiry.After(now) {
+			continue
+		}
+
+		expired = append(expired, pid)
+		delete(tc.expirations, pid)
+	}
+
+	return expired
+}
@@ -0,0 +1,71 @@
+package libp2p_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiversx/mx-chain-p2p-go/libp2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemporaryConnections_UpsertAndHas(t *testing.T) {
+	t.Parallel()
+
+	tc := libp2p.NewTemporaryConnections()
+	pid := peer.ID("pid")
+	assert.False(t, tc.Has(pid))
+
+	tc.Upsert(pid, time.Minute)
+	assert.True(t, tc.Has(pid))
+
+	tc.Remove(pid)
+	assert.False(t, tc.Has(pid))
+}
+
+func TestTemporaryConnections_PopExpired(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return only the expired connections", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		tc := libp2p.NewTemporaryConnections()
+		tc.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		pid1 := peer.ID("pid1")
+		pid2 := peer.ID("pid2")
+		tc.Upsert(pid1, time.Second)
+		tc.Upsert(pid2, time.Minute)
+		assert.Empty(t, tc.PopExpired())
+
+		currentTime = currentTime.Add(time.Second)
+		assert.Equal(t, []peer.ID{pid1}, tc.PopExpired())
+		assert.False(t, tc.Has(pid1))
+		assert.True(t, tc.Has(pid2))
+		assert.Empty(t, tc.PopExpired())
+	})
+	t.Run("upsert should not shorten the expiry", func(t *testing.T) {
+		t.Parallel()
+
+		currentTime := time.Now()
+		tc := libp2p.NewTemporaryConnections()
+		tc.SetTimeHandler(func() time.Time {
+			return currentTime
+		})
+
+		pid := peer.ID("pid")
+		tc.Upsert(pid, time.Minute)
+		tc.Upsert(pid, 0)
+		assert.Empty(t, tc.PopExpired())
+
+		tc.Upsert(pid, time.Minute*2)
+		currentTime = currentTime.Add(time.Minute)
+		assert.Empty(t, tc.PopExpired())
+
+		currentTime = currentTime.Add(time.Minute)
+		assert.Equal(t, []peer.ID{pid}, tc.PopExpired())
+	})
+}
@@ -0,0 +1,81 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// AddressDenialEvaluatorStub -
+type AddressDenialEvaluatorStub struct {
+	IsAddressDeniedCalled     func(address multiaddr.Multiaddr) bool
+	CanAcceptPeerCalled       func(pid core.PeerID, address multiaddr.Multiaddr) bool
+	AddConnectedPeerCalled    func(pid core.PeerID, address multiaddr.Multiaddr)
+	RemoveConnectedPeerCalled func(pid core.PeerID, address multiaddr.Multiaddr)
+	DenyPeerAddressesCalled   func(pid core.PeerID)
+	UpsertIPCalled            func(ip string, duration time.Duration) error
+	UpsertSubnetCalled        func(subnet string, duration time.Duration) error
+}
+
+// IsAddressDenied -
+func (stub *AddressDenialEvaluatorStub) IsAddressDenied(address multiaddr.Multiaddr) bool {
+	if stub.IsAddressDeniedCalled != nil {
+		return stub.IsAddressDeniedCalled(address)
+	}
+
+	return false
+}
+
+// CanAcceptPeer -
+func (stub *AddressDenialEvaluatorStub) CanAcceptPeer(pid core.PeerID, address multiaddr.Multiaddr) bool {
+	if stub.CanAcceptPeerCalled != nil {
+		return stub.CanAcceptPeerCalled(pid, address)
+	}
+
+	return true
+}
+
+// AddConnectedPeer -
+func (stub *AddressDenialEvaluatorStub) AddConnectedPeer(pid core.PeerID, address multiaddr.Multiaddr) {
+	if stub.AddConnectedPeerCalled != nil {
+		stub.AddConnectedPeerCalled(pid, address)
+	}
+}
+
+// RemoveConnectedPeer -
+func (stub *AddressDenialEvaluatorStub) RemoveConnectedPeer(pid core.PeerID, address multiaddr.Multiaddr) {
+	if stub.RemoveConnectedPeerCalled != nil {
+		stub.RemoveConnectedPeerCalled(pid, address)
+	}
+}
+
+// DenyPeerAddresses -
+func (stub *AddressDenialEvaluatorStub) DenyPeerAddresses(pid core.PeerID) {
+	if stub.DenyPeerAddressesCalled != nil {
+		stub.DenyPeerAddressesCalled(pid)
+	}
+}
+
+// UpsertIP -
+func (stub *AddressDenialEvaluatorStub) UpsertIP(ip string, duration time.Duration) error {
+	if stub.UpsertIPCalled != nil {
+		return stub.UpsertIPCalled(ip, duration)
+	}
+
+	return nil
+}
+
+// UpsertSubnet -
+func (stub *AddressDenialEvaluatorStub) UpsertSubnet(subnet string, duration time.Duration) error {
+	if stub.UpsertSubnetCalled != nil {
+		return stub.UpsertSubnetCalled(subnet, duration)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *AddressDenialEvaluatorStub) IsInterfaceNil() bool {
+	return stub == nil
+}
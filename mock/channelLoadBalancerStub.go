@@ -1,21 +1,26 @@
 package mock
 
 import (
+	"context"
+
+	p2p "github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/libp2p"
 )
 
 // ChannelLoadBalancerStub -
 type ChannelLoadBalancerStub struct {
-	AddChannelCalled                    func(pipe string) error
+	AddChannelCalled                    func(pipe string, options p2p.OutgoingChannelOptions) error
 	RemoveChannelCalled                 func(pipe string) error
-	GetChannelOrDefaultCalled           func(pipe string) chan *libp2p.SendableData
+	EnqueueCalled                       func(pipe string, data *libp2p.SendableData) error
+	EnqueueWithContextCalled            func(ctx context.Context, pipe string, data *libp2p.SendableData) error
 	CollectOneElementFromChannelsCalled func() *libp2p.SendableData
+	ChannelsStatisticsCalled            func() []libp2p.OutgoingChannelStatistics
 	CloseCalled                         func() error
 }
 
 // AddChannel -
-func (clbs *ChannelLoadBalancerStub) AddChannel(pipe string) error {
-	return clbs.AddChannelCalled(pipe)
+func (clbs *ChannelLoadBalancerStub) AddChannel(pipe string, options p2p.OutgoingChannelOptions) error {
+	return clbs.AddChannelCalled(pipe, options)
 }
 
 // RemoveChannel -
@@ -23,9 +28,14 @@ func (clbs *ChannelLoadBalancerStub) RemoveChannel(pipe string) error {
 	return clbs.RemoveChannelCalled(pipe)
 }
 
-// GetChannelOrDefault -
-func (clbs *ChannelLoadBalancerStub) GetChannelOrDefault(pipe string) chan *libp2p.SendableData {
-	return clbs.GetChannelOrDefaultCalled(pipe)
+// Enqueue -
+func (clbs *ChannelLoadBalancerStub) Enqueue(pipe string, data *libp2p.SendableData) error {
+	return clbs.EnqueueCalled(pipe, data)
+}
+
+// EnqueueWithContext -
+func (clbs *ChannelLoadBalancerStub) EnqueueWithContext(ctx context.Context, pipe string, data *libp2p.SendableData) error {
+	return clbs.EnqueueWithContextCalled(ctx, pipe, data)
 }
 
 // CollectOneElementFromChannels -
@@ -33,6 +43,15 @@ func (clbs *ChannelLoadBalancerStub) CollectOneElementFromChannels() *libp2p.Sen
 	return clbs.CollectOneElementFromChannelsCalled()
 }
 
+// ChannelsStatistics -
+func (clbs *ChannelLoadBalancerStub) ChannelsStatistics() []libp2p.OutgoingChannelStatistics {
+	if clbs.ChannelsStatisticsCalled != nil {
+		return clbs.ChannelsStatisticsCalled()
+	}
+
+	return make([]libp2p.OutgoingChannelStatistics, 0)
+}
+
 // Close -
 func (clbs *ChannelLoadBalancerStub) Close() error {
 	if clbs.CloseCalled != nil {
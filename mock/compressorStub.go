@@ -0,0 +1,50 @@
+package mock
+
+// CompressorStub -
+type CompressorStub struct {
+	CompressCalled   func(buff []byte) ([]byte, error)
+	DecompressCalled func(buff []byte, maxDecompressedSize int) ([]byte, error)
+	IDCalled         func() uint32
+	NameCalled       func() string
+}
+
+// Compress -
+func (cs *CompressorStub) Compress(buff []byte) ([]byte, error) {
+	if cs.CompressCalled != nil {
+		return cs.CompressCalled(buff)
+	}
+
+	return buff, nil
+}
+
+// Decompress -
+func (cs *CompressorStub) Decompress(buff []byte, maxDecompressedSize int) ([]byte, error) {
+	if cs.DecompressCalled != nil {
+		return cs.DecompressCalled(buff, maxDecompressedSize)
+	}
+
+	return buff, nil
+}
+
+// ID -
+func (cs *CompressorStub) ID() uint32 {
+	if cs.IDCalled != nil {
+		return cs.IDCalled()
+	}
+
+	return 0
+}
+
+// Name -
+func (cs *CompressorStub) Name() string {
+	if cs.NameCalled != nil {
+		return cs.NameCalled()
+	}
+
+	return ""
+}
+
+// IsInterfaceNil -
+func (cs *CompressorStub) IsInterfaceNil() bool {
+	return cs == nil
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ConnectionAgeProviderStub -
+type ConnectionAgeProviderStub struct {
+	ConnectionAgeCalled func(pid peer.ID) time.Duration
+}
+
+// ConnectionAge -
+func (stub *ConnectionAgeProviderStub) ConnectionAge(pid peer.ID) time.Duration {
+	if stub.ConnectionAgeCalled != nil {
+		return stub.ConnectionAgeCalled(pid)
+	}
+
+	return 0
+}
+
+// IsInterfaceNil -
+func (stub *ConnectionAgeProviderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
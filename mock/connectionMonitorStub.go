@@ -14,6 +14,7 @@ type ConnectionMonitorStub struct {
 	IsConnectedToTheNetworkCalled       func(netw network.Network) bool
 	SetThresholdMinConnectedPeersCalled func(thresholdMinConnectedPeers int, netw network.Network)
 	ThresholdMinConnectedPeersCalled    func() int
+	EvictUnwantedPeersCalled            func(netw network.Network)
 }
 
 // Listen -
@@ -69,6 +70,13 @@ func (cms *ConnectionMonitorStub) ThresholdMinConnectedPeers() int {
 	return 0
 }
 
+// EvictUnwantedPeers -
+func (cms *ConnectionMonitorStub) EvictUnwantedPeers(netw network.Network) {
+	if cms.EvictUnwantedPeersCalled != nil {
+		cms.EvictUnwantedPeersCalled(netw)
+	}
+}
+
 // Close -
 func (cms *ConnectionMonitorStub) Close() error {
 	return nil
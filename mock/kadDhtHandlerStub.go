@@ -1,10 +1,17 @@
 package mock
 
-import "context"
+import (
+	"context"
+
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
 
 // KadDhtHandlerStub -
 type KadDhtHandlerStub struct {
-	BootstrapCalled func(ctx context.Context) error
+	BootstrapCalled    func(ctx context.Context) error
+	FindPeerCalled     func(ctx context.Context, id peer.ID) (peer.AddrInfo, error)
+	RoutingTableCalled func() *kbucket.RoutingTable
 }
 
 // Bootstrap -
@@ -15,3 +22,21 @@ func (kdhs *KadDhtHandlerStub) Bootstrap(ctx context.Context) error {
 
 	return nil
 }
+
+// FindPeer -
+func (kdhs *KadDhtHandlerStub) FindPeer(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+	if kdhs.FindPeerCalled != nil {
+		return kdhs.FindPeerCalled(ctx, id)
+	}
+
+	return peer.AddrInfo{}, nil
+}
+
+// RoutingTable -
+func (kdhs *KadDhtHandlerStub) RoutingTable() *kbucket.RoutingTable {
+	if kdhs.RoutingTableCalled != nil {
+		return kdhs.RoutingTableCalled()
+	}
+
+	return nil
+}
@@ -9,6 +9,8 @@ import (
 // KadSharderStub -
 type KadSharderStub struct {
 	ComputeEvictListCalled     func(pidList []peer.ID) []peer.ID
+	ExplainEvictionCalled      func(pidList []peer.ID) p2p.EvictionDecision
+	LastEvictionDecisionCalled func() p2p.EvictionDecision
 	HasCalled                  func(pid peer.ID, list []peer.ID) bool
 	SetPeerShardResolverCalled func(psp p2p.PeerShardResolver) error
 	SetSeedersCalled           func(addresses []string)
@@ -24,6 +26,24 @@ func (kss *KadSharderStub) ComputeEvictionList(pidList []peer.ID) []peer.ID {
 	return make([]peer.ID, 0)
 }
 
+// ExplainEviction -
+func (kss *KadSharderStub) ExplainEviction(pidList []peer.ID) p2p.EvictionDecision {
+	if kss.ExplainEvictionCalled != nil {
+		return kss.ExplainEvictionCalled(pidList)
+	}
+
+	return p2p.EvictionDecision{}
+}
+
+// LastEvictionDecision -
+func (kss *KadSharderStub) LastEvictionDecision() p2p.EvictionDecision {
+	if kss.LastEvictionDecisionCalled != nil {
+		return kss.LastEvictionDecisionCalled()
+	}
+
+	return p2p.EvictionDecision{}
+}
+
 // Has -
 func (kss *KadSharderStub) Has(pid peer.ID, list []peer.ID) bool {
 	if kss.HasCalled != nil {
@@ -0,0 +1,25 @@
+package mock
+
+// MdnsServiceStub -
+type MdnsServiceStub struct {
+	StartCalled func() error
+	CloseCalled func() error
+}
+
+// Start -
+func (stub *MdnsServiceStub) Start() error {
+	if stub.StartCalled != nil {
+		return stub.StartCalled()
+	}
+
+	return nil
+}
+
+// Close -
+func (stub *MdnsServiceStub) Close() error {
+	if stub.CloseCalled != nil {
+		return stub.CloseCalled()
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package mock
+
+import p2p "github.com/multiversx/mx-chain-p2p-go"
+
+// PeerDenialEvaluatorHolderStub -
+type PeerDenialEvaluatorHolderStub struct {
+	PeerDenialEvaluatorCalled func() p2p.PeerDenialEvaluator
+}
+
+// PeerDenialEvaluator -
+func (stub *PeerDenialEvaluatorHolderStub) PeerDenialEvaluator() p2p.PeerDenialEvaluator {
+	if stub.PeerDenialEvaluatorCalled != nil {
+		return stub.PeerDenialEvaluatorCalled()
+	}
+
+	return &PeerDenialEvaluatorStub{}
+}
+
+// IsInterfaceNil -
+func (stub *PeerDenialEvaluatorHolderStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -1,9 +1,17 @@
 package mock
 
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
 // PeerDiscovererStub -
 type PeerDiscovererStub struct {
 	BootstrapCalled func() error
 	CloseCalled     func() error
+	FindPeerCalled  func(ctx context.Context, pid peer.ID) (peer.AddrInfo, error)
+	NameCalled      func() string
 }
 
 // Bootstrap -
@@ -11,8 +19,21 @@ func (pds *PeerDiscovererStub) Bootstrap() error {
 	return pds.BootstrapCalled()
 }
 
+// FindPeer -
+func (pds *PeerDiscovererStub) FindPeer(ctx context.Context, pid peer.ID) (peer.AddrInfo, error) {
+	if pds.FindPeerCalled != nil {
+		return pds.FindPeerCalled(ctx, pid)
+	}
+
+	return peer.AddrInfo{}, nil
+}
+
 // Name -
 func (pds *PeerDiscovererStub) Name() string {
+	if pds.NameCalled != nil {
+		return pds.NameCalled()
+	}
+
 	return "PeerDiscovererStub"
 }
 
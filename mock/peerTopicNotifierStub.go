@@ -5,6 +5,7 @@ import "github.com/multiversx/mx-chain-core-go/core"
 // PeerTopicNotifierStub -
 type PeerTopicNotifierStub struct {
 	NewPeerFoundCalled func(pid core.PeerID, topic string)
+	TopicLeftCalled    func(topic string)
 }
 
 // NewPeerFound -
@@ -14,6 +15,13 @@ func (stub *PeerTopicNotifierStub) NewPeerFound(pid core.PeerID, topic string) {
 	}
 }
 
+// TopicLeft -
+func (stub *PeerTopicNotifierStub) TopicLeft(topic string) {
+	if stub.TopicLeftCalled != nil {
+		stub.TopicLeftCalled(topic)
+	}
+}
+
 // IsInterfaceNil -
 func (stub *PeerTopicNotifierStub) IsInterfaceNil() bool {
 	return stub == nil
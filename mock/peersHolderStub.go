@@ -4,12 +4,40 @@ import "github.com/multiversx/mx-chain-core-go/core"
 
 // PeersHolderStub -
 type PeersHolderStub struct {
-	PutConnectionAddressCalled func(peerID core.PeerID, address string)
-	PutShardIDCalled           func(peerID core.PeerID, shardID uint32)
-	GetCalled                  func() map[uint32][]core.PeerID
-	ContainsCalled             func(peerID core.PeerID) bool
-	RemoveCalled               func(peerID core.PeerID)
-	ClearCalled                func()
+	AddPreferredConnectionAddressCalled    func(connectionAddress string) error
+	RemovePreferredConnectionAddressCalled func(connectionAddress string)
+	HasPreferredConnectionAddressCalled    func(connectionAddress string) bool
+	PutConnectionAddressCalled             func(peerID core.PeerID, address string)
+	PutShardIDCalled                       func(peerID core.PeerID, shardID uint32)
+	GetCalled                              func() map[uint32][]core.PeerID
+	ContainsCalled                         func(peerID core.PeerID) bool
+	RemoveCalled                           func(peerID core.PeerID)
+	ClearCalled                            func()
+}
+
+// AddPreferredConnectionAddress -
+func (p *PeersHolderStub) AddPreferredConnectionAddress(connectionAddress string) error {
+	if p.AddPreferredConnectionAddressCalled != nil {
+		return p.AddPreferredConnectionAddressCalled(connectionAddress)
+	}
+
+	return nil
+}
+
+// RemovePreferredConnectionAddress -
+func (p *PeersHolderStub) RemovePreferredConnectionAddress(connectionAddress string) {
+	if p.RemovePreferredConnectionAddressCalled != nil {
+		p.RemovePreferredConnectionAddressCalled(connectionAddress)
+	}
+}
+
+// HasPreferredConnectionAddress -
+func (p *PeersHolderStub) HasPreferredConnectionAddress(connectionAddress string) bool {
+	if p.HasPreferredConnectionAddressCalled != nil {
+		return p.HasPreferredConnectionAddressCalled(connectionAddress)
+	}
+
+	return false
 }
 
 // PutConnectionAddress -
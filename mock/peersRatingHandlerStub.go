@@ -1,13 +1,21 @@
 package mock
 
-import "github.com/multiversx/mx-chain-core-go/core"
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
 
 // PeersRatingHandlerStub -
 type PeersRatingHandlerStub struct {
 	AddPeerCalled                  func(pid core.PeerID)
 	IncreaseRatingCalled           func(pid core.PeerID)
 	DecreaseRatingCalled           func(pid core.PeerID)
+	IncreaseRatingWithReasonCalled func(pid core.PeerID, reason p2p.RatingReason)
+	DecreaseRatingWithReasonCalled func(pid core.PeerID, reason p2p.RatingReason)
 	GetTopRatedPeersFromListCalled func(peers []core.PeerID, numOfPeers int) []core.PeerID
+	GetTierSizesCalled             func() map[string]int
+	GetRatingsSnapshotCalled       func() []p2p.PeerRating
+	GetRatingCalled                func(pid core.PeerID) (int32, bool)
 }
 
 // AddPeer -
@@ -31,6 +39,20 @@ func (stub *PeersRatingHandlerStub) DecreaseRating(pid core.PeerID) {
 	}
 }
 
+// IncreaseRatingWithReason -
+func (stub *PeersRatingHandlerStub) IncreaseRatingWithReason(pid core.PeerID, reason p2p.RatingReason) {
+	if stub.IncreaseRatingWithReasonCalled != nil {
+		stub.IncreaseRatingWithReasonCalled(pid, reason)
+	}
+}
+
+// DecreaseRatingWithReason -
+func (stub *PeersRatingHandlerStub) DecreaseRatingWithReason(pid core.PeerID, reason p2p.RatingReason) {
+	if stub.DecreaseRatingWithReasonCalled != nil {
+		stub.DecreaseRatingWithReasonCalled(pid, reason)
+	}
+}
+
 // GetTopRatedPeersFromList -
 func (stub *PeersRatingHandlerStub) GetTopRatedPeersFromList(peers []core.PeerID, numOfPeers int) []core.PeerID {
 	if stub.GetTopRatedPeersFromListCalled != nil {
@@ -40,6 +62,33 @@ func (stub *PeersRatingHandlerStub) GetTopRatedPeersFromList(peers []core.PeerID
 	return peers
 }
 
+// GetTierSizes -
+func (stub *PeersRatingHandlerStub) GetTierSizes() map[string]int {
+	if stub.GetTierSizesCalled != nil {
+		return stub.GetTierSizesCalled()
+	}
+
+	return make(map[string]int)
+}
+
+// GetRatingsSnapshot -
+func (stub *PeersRatingHandlerStub) GetRatingsSnapshot() []p2p.PeerRating {
+	if stub.GetRatingsSnapshotCalled != nil {
+		return stub.GetRatingsSnapshotCalled()
+	}
+
+	return make([]p2p.PeerRating, 0)
+}
+
+// GetRating -
+func (stub *PeersRatingHandlerStub) GetRating(pid core.PeerID) (int32, bool) {
+	if stub.GetRatingCalled != nil {
+		return stub.GetRatingCalled(pid)
+	}
+
+	return 0, false
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (stub *PeersRatingHandlerStub) IsInterfaceNil() bool {
 	return stub == nil
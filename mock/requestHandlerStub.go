@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-p2p-go"
+)
+
+// RequestHandlerStub -
+type RequestHandlerStub struct {
+	ProcessRequestCalled func(request p2p.MessageP2P, fromConnectedPeer core.PeerID) ([]byte, error)
+}
+
+// ProcessRequest -
+func (stub *RequestHandlerStub) ProcessRequest(request p2p.MessageP2P, fromConnectedPeer core.PeerID) ([]byte, error) {
+	if stub.ProcessRequestCalled != nil {
+		return stub.ProcessRequestCalled(request, fromConnectedPeer)
+	}
+
+	return nil, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (stub *RequestHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,30 @@
+package mock
+
+// SnapshotStorerStub -
+type SnapshotStorerStub struct {
+	SaveCalled func(buff []byte) error
+	LoadCalled func() ([]byte, error)
+}
+
+// Save -
+func (stub *SnapshotStorerStub) Save(buff []byte) error {
+	if stub.SaveCalled != nil {
+		return stub.SaveCalled(buff)
+	}
+
+	return nil
+}
+
+// Load -
+func (stub *SnapshotStorerStub) Load() ([]byte, error) {
+	if stub.LoadCalled != nil {
+		return stub.LoadCalled()
+	}
+
+	return nil, nil
+}
+
+// IsInterfaceNil -
+func (stub *SnapshotStorerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,64 @@
+package mock
+
+import (
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+// TopicsHandlerStub -
+type TopicsHandlerStub struct {
+	CreateTopicCalled                func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error
+	HasTopicCalled                   func(name string) bool
+	RegisterMessageProcessorCalled   func(topic string, identifier string, handler p2p.MessageProcessor) error
+	UnregisterMessageProcessorCalled func(topic string, identifier string) error
+	LeaveTopicCalled                 func(name string) error
+}
+
+// CreateTopic -
+func (stub *TopicsHandlerStub) CreateTopic(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+	if stub.CreateTopicCalled != nil {
+		return stub.CreateTopicCalled(name, createChannelForTopic, options...)
+	}
+
+	return nil
+}
+
+// HasTopic -
+func (stub *TopicsHandlerStub) HasTopic(name string) bool {
+	if stub.HasTopicCalled != nil {
+		return stub.HasTopicCalled(name)
+	}
+
+	return false
+}
+
+// RegisterMessageProcessor -
+func (stub *TopicsHandlerStub) RegisterMessageProcessor(topic string, identifier string, handler p2p.MessageProcessor) error {
+	if stub.RegisterMessageProcessorCalled != nil {
+		return stub.RegisterMessageProcessorCalled(topic, identifier, handler)
+	}
+
+	return nil
+}
+
+// UnregisterMessageProcessor -
+func (stub *TopicsHandlerStub) UnregisterMessageProcessor(topic string, identifier string) error {
+	if stub.UnregisterMessageProcessorCalled != nil {
+		return stub.UnregisterMessageProcessorCalled(topic, identifier)
+	}
+
+	return nil
+}
+
+// LeaveTopic -
+func (stub *TopicsHandlerStub) LeaveTopic(name string) error {
+	if stub.LeaveTopicCalled != nil {
+		return stub.LeaveTopicCalled(name)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *TopicsHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -0,0 +1,49 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+// PeerRating holds the rating information of a known peer
+type PeerRating struct {
+	Pid        core.PeerID
+	Rating     int32
+	Tier       string
+	LastUpdate time.Time
+}
+
+// PeerRatingJSON is the JSON-serialisable form of a PeerRating
+type PeerRatingJSON struct {
+	Pid        string `json:"pid"`
+	Rating     int32  `json:"rating"`
+	Tier       string `json:"tier"`
+	LastUpdate int64  `json:"lastUpdate"`
+}
+
+// ToJSON returns the JSON-serialisable form of the peer rating. The last update is expressed as a unix timestamp
+// and is 0 if it is not known
+func (pr PeerRating) ToJSON() PeerRatingJSON {
+	lastUpdate := int64(0)
+	if !pr.LastUpdate.IsZero() {
+		lastUpdate = pr.LastUpdate.Unix()
+	}
+
+	return PeerRatingJSON{
+		Pid:        pr.Pid.Pretty(),
+		Rating:     pr.Rating,
+		Tier:       pr.Tier,
+		LastUpdate: lastUpdate,
+	}
+}
+
+// PeerRatingsToJSON returns the JSON-serialisable form of the provided peer ratings
+func PeerRatingsToJSON(ratings []PeerRating) []PeerRatingJSON {
+	result := make([]PeerRatingJSON, 0, len(ratings))
+	for _, rating := range ratings {
+		result = append(result, rating.ToJSON())
+	}
+
+	return result
+}
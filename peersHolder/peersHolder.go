@@ -10,6 +10,10 @@ import (
 	"github.com/multiversx/mx-chain-p2p-go/peersHolder/connectionStringValidator"
 )
 
+type connectionStringValidatorHandler interface {
+	IsValid(connStr string) bool
+}
+
 type peerInfo struct {
 	pid     core.PeerID
 	shardID uint32
@@ -22,6 +26,7 @@ type peerIDData struct {
 }
 
 type peersHolder struct {
+	connectionValidator        connectionStringValidatorHandler
 	preferredConnAddresses     []string
 	connAddrToPeersInfo        map[string][]*peerInfo
 	tempPeerIDsWaitingForShard map[core.PeerID]string
@@ -47,6 +52,7 @@ func NewPeersHolder(preferredConnectionAddresses []string) (*peersHolder, error)
 	}
 
 	return &peersHolder{
+		connectionValidator:        connectionValidator,
 		preferredConnAddresses:     preferredConnections,
 		connAddrToPeersInfo:        connAddrToPeerIDs,
 		tempPeerIDsWaitingForShard: make(map[core.PeerID]string),
@@ -55,6 +61,67 @@ func NewPeersHolder(preferredConnectionAddresses []string) (*peersHolder, error)
 	}, nil
 }
 
+// AddPreferredConnectionAddress adds a new preferred connection address, that can be either an IP or a peer ID
+func (ph *peersHolder) AddPreferredConnectionAddress(connectionAddress string) error {
+	if !ph.connectionValidator.IsValid(connectionAddress) {
+		return fmt.Errorf("%w for preferred connection address %s", p2p.ErrInvalidValue, connectionAddress)
+	}
+
+	ph.mut.Lock()
+	defer ph.mut.Unlock()
+
+	_, found := ph.connAddrToPeersInfo[connectionAddress]
+	if found {
+		return nil
+	}
+
+	ph.preferredConnAddresses = append(ph.preferredConnAddresses, connectionAddress)
+	ph.connAddrToPeersInfo[connectionAddress] = nil
+
+	return nil
+}
+
+// RemovePreferredConnectionAddress removes the provided preferred connection address together with all the peers
+// that were preferred because of it
+func (ph *peersHolder) RemovePreferredConnectionAddress(connectionAddress string) {
+	ph.mut.Lock()
+	defer ph.mut.Unlock()
+
+	for idx, preferredConnAddr := range ph.preferredConnAddresses {
+		if preferredConnAddr == connectionAddress {
+			ph.preferredConnAddresses = append(ph.preferredConnAddresses[:idx], ph.preferredConnAddresses[idx+1:]...)
+			break
+		}
+	}
+
+	peersInfo := ph.connAddrToPeersInfo[connectionAddress]
+	pids := make([]core.PeerID, 0, len(peersInfo))
+	for _, pInfo := range peersInfo {
+		pids = append(pids, pInfo.pid)
+	}
+	for _, pid := range pids {
+		ph.removePeer(pid)
+	}
+
+	for pid, connAddr := range ph.tempPeerIDsWaitingForShard {
+		if connAddr == connectionAddress {
+			delete(ph.tempPeerIDsWaitingForShard, pid)
+		}
+	}
+
+	delete(ph.connAddrToPeersInfo, connectionAddress)
+}
+
+// HasPreferredConnectionAddress returns true if the provided connection address is a preferred one
+func (ph *peersHolder) HasPreferredConnectionAddress(connectionAddress string) bool {
+	ph.mut.RLock()
+	defer ph.mut.RUnlock()
+
+	_, found := ph.connAddrToPeersInfo[connectionAddress]
+
+	return found
+}
+
 // PutConnectionAddress will perform the insert or the upgrade operation if the provided peerID is inside the preferred peers list
 func (ph *peersHolder) PutConnectionAddress(peerID core.PeerID, connectionAddress string) {
 	ph.mut.Lock()
@@ -159,6 +226,11 @@ func (ph *peersHolder) Remove(peerID core.PeerID) {
 	ph.mut.Lock()
 	defer ph.mut.Unlock()
 
+	ph.removePeer(peerID)
+}
+
+// this function must be called under mutex protection
+func (ph *peersHolder) removePeer(peerID core.PeerID) {
 	pidData, found := ph.peerIDs[peerID]
 	if !found {
 		return
@@ -225,6 +297,15 @@ func (ph *peersHolder) removePeerFromMapAtIndex(shardID uint32, index int) {
 	ph.peerIDsPerShard[shardID] = append(ph.peerIDsPerShard[shardID][:index], ph.peerIDsPerShard[shardID][index+1:]...)
 	if len(ph.peerIDsPerShard[shardID]) == 0 {
 		delete(ph.peerIDsPerShard, shardID)
+		return
+	}
+
+	// the peers after the removed one shifted to the left
+	for idx := index; idx < len(ph.peerIDsPerShard[shardID]); idx++ {
+		pidData, found := ph.peerIDs[ph.peerIDsPerShard[shardID][idx]]
+		if found {
+			pidData.index = idx
+		}
 	}
 }
 
@@ -197,6 +197,99 @@ func TestPeersHolder_Contains(t *testing.T) {
 	ph.Remove(unknownPid) // for code coverage
 }
 
+func TestPeersHolder_RemoveShouldKeepTheIndexesConsistent(t *testing.T) {
+	t.Parallel()
+
+	preferredPeers := []string{"10.100.100.100"}
+	ph, _ := NewPeersHolder(preferredPeers)
+
+	providedShardID := uint32(123)
+	pids := []core.PeerID{"pid 0", "pid 1", "pid 2"}
+	for _, pid := range pids {
+		ph.PutConnectionAddress(pid, "/ip4/10.100.100.100/tcp/38191/p2p/"+string(pid))
+		ph.PutShardID(pid, providedShardID)
+	}
+
+	ph.Remove(pids[0])
+	assert.Equal(t, 0, ph.peerIDs[pids[1]].index)
+	assert.Equal(t, 1, ph.peerIDs[pids[2]].index)
+
+	ph.Remove(pids[2])
+	assert.False(t, ph.Contains(pids[2]))
+	assert.True(t, ph.Contains(pids[1]))
+	assert.Equal(t, []core.PeerID{pids[1]}, ph.Get()[providedShardID])
+}
+
+func TestPeersHolder_AddPreferredConnectionAddress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid address should error", func(t *testing.T) {
+		t.Parallel()
+
+		ph, _ := NewPeersHolder(nil)
+
+		err := ph.AddPreferredConnectionAddress("invalid string")
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.Equal(t, 0, len(ph.preferredConnAddresses))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		preferredPid := "16Uiu2HAm6yvbp1oZ6zjnWsn9FdRqBSaQkbhELyaThuq48ybdojvJ"
+		ph, _ := NewPeersHolder(nil)
+		assert.False(t, ph.HasPreferredConnectionAddress(preferredPid))
+
+		err := ph.AddPreferredConnectionAddress(preferredPid)
+		assert.Nil(t, err)
+		assert.True(t, ph.HasPreferredConnectionAddress(preferredPid))
+		err = ph.AddPreferredConnectionAddress(preferredPid)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{preferredPid}, ph.preferredConnAddresses)
+
+		providedPid := core.PeerID("provided pid")
+		ph.PutConnectionAddress(providedPid, "/ip4/10.100.100.102/tcp/38191/p2p/"+preferredPid)
+		ph.PutShardID(providedPid, 0)
+		assert.True(t, ph.Contains(providedPid))
+	})
+}
+
+func TestPeersHolder_RemovePreferredConnectionAddress(t *testing.T) {
+	t.Parallel()
+
+	preferredPeers := []string{"10.100.100.100", "10.100.100.101"}
+	ph, _ := NewPeersHolder(preferredPeers)
+
+	providedShardID := uint32(123)
+	pid0 := core.PeerID("pid 0")
+	pid1 := core.PeerID("pid 1")
+	pidOther := core.PeerID("pid other")
+	pidWaiting := core.PeerID("pid waiting")
+	ph.PutConnectionAddress(pid0, "/ip4/10.100.100.100/tcp/38191")
+	ph.PutShardID(pid0, providedShardID)
+	ph.PutConnectionAddress(pidOther, "/ip4/10.100.100.101/tcp/38191")
+	ph.PutShardID(pidOther, providedShardID)
+	ph.PutConnectionAddress(pid1, "/ip4/10.100.100.100/tcp/38192")
+	ph.PutShardID(pid1, providedShardID)
+	ph.PutConnectionAddress(pidWaiting, "/ip4/10.100.100.100/tcp/38193")
+
+	ph.RemovePreferredConnectionAddress(preferredPeers[0])
+
+	assert.False(t, ph.Contains(pid0))
+	assert.False(t, ph.Contains(pid1))
+	assert.True(t, ph.Contains(pidOther))
+	assert.Equal(t, []core.PeerID{pidOther}, ph.Get()[providedShardID])
+	assert.Equal(t, []string{preferredPeers[1]}, ph.preferredConnAddresses)
+	_, found := ph.tempPeerIDsWaitingForShard[pidWaiting]
+	assert.False(t, found)
+
+	// new connections on the removed address are no longer preferred
+	ph.PutConnectionAddress(pidWaiting, "/ip4/10.100.100.100/tcp/38193")
+	ph.PutShardID(pidWaiting, providedShardID)
+	assert.False(t, ph.Contains(pidWaiting))
+
+	ph.RemovePreferredConnectionAddress("10.100.100.200") // for code coverage
+}
+
 func TestPeersHolder_Clear(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,57 @@
+package rating
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+const (
+	tempFileSuffix   = ".tmp"
+	snapshotFileMode = 0644
+	snapshotDirMode  = 0755
+)
+
+type fileSnapshotStorer struct {
+	filePath string
+}
+
+// NewFileSnapshotStorer creates a snapshot storer that keeps the ratings snapshot in the provided file
+func NewFileSnapshotStorer(filePath string) (*fileSnapshotStorer, error) {
+	if len(filePath) == 0 {
+		return nil, fmt.Errorf("%w for the file path, empty value", p2p.ErrInvalidValue)
+	}
+
+	return &fileSnapshotStorer{
+		filePath: filePath,
+	}, nil
+}
+
+// Save writes the buffer in a temporary file and then replaces the snapshot file, so a crash while saving
+// will not leave a partially written snapshot
+func (storer *fileSnapshotStorer) Save(buff []byte) error {
+	err := os.MkdirAll(filepath.Dir(storer.filePath), snapshotDirMode)
+	if err != nil {
+		return err
+	}
+
+	tempFilePath := storer.filePath + tempFileSuffix
+	err = os.WriteFile(tempFilePath, buff, snapshotFileMode)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFilePath, storer.filePath)
+}
+
+// Load returns the content of the snapshot file
+func (storer *fileSnapshotStorer) Load() ([]byte, error) {
+	return os.ReadFile(storer.filePath)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (storer *fileSnapshotStorer) IsInterfaceNil() bool {
+	return storer == nil
+}
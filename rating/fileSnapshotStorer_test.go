@@ -0,0 +1,63 @@
+package rating
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFileSnapshotStorer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty file path should error", func(t *testing.T) {
+		t.Parallel()
+
+		storer, err := NewFileSnapshotStorer("")
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(storer))
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		storer, err := NewFileSnapshotStorer("ratings.json")
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(storer))
+	})
+}
+
+func TestFileSnapshotStorer_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file should error on load", func(t *testing.T) {
+		t.Parallel()
+
+		storer, _ := NewFileSnapshotStorer(filepath.Join(t.TempDir(), "ratings.json"))
+
+		buff, err := storer.Load()
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+		assert.Nil(t, buff)
+	})
+	t.Run("should create the directory and replace the previous content", func(t *testing.T) {
+		t.Parallel()
+
+		filePath := filepath.Join(t.TempDir(), "dir", "ratings.json")
+		storer, _ := NewFileSnapshotStorer(filePath)
+
+		err := storer.Save([]byte("first snapshot"))
+		assert.Nil(t, err)
+		err = storer.Save([]byte("second"))
+		assert.Nil(t, err)
+
+		buff, err := storer.Load()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("second"), buff)
+
+		_, err = os.Stat(filePath + tempFileSuffix)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
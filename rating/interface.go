@@ -0,0 +1,8 @@
+package rating
+
+// SnapshotStorer defines a component able to persist the ratings snapshot between node restarts
+type SnapshotStorer interface {
+	Save(buff []byte) error
+	Load() ([]byte, error)
+	IsInterfaceNil() bool
+}
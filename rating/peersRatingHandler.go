@@ -1,13 +1,17 @@
 package rating
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	logger "github.com/multiversx/mx-chain-logger-go"
 	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
 	"github.com/multiversx/mx-chain-storage-go/types"
 )
 
@@ -29,12 +33,28 @@ var log = logger.GetOrCreate("p2p/peersRatingHandler")
 type ArgPeersRatingHandler struct {
 	TopRatedCache types.Cacher
 	BadRatedCache types.Cacher
+	// SnapshotStorer is optional. If provided, the ratings are reloaded on construction and saved periodically
+	// (each SnapshotInterval, if greater than 0) and on Close
+	SnapshotStorer   SnapshotStorer
+	SnapshotInterval time.Duration
+	// DecayPerHour is the number of rating points a reloaded rating moves toward the default rating for each
+	// hour the node was down
+	DecayPerHour uint32
+	// RatingPolicy is optional, the zero value config meaning the default rating policy
+	RatingPolicy config.RatingPolicyConfig
 }
 
 type peersRatingHandler struct {
-	topRatedCache types.Cacher
-	badRatedCache types.Cacher
-	mut           sync.Mutex
+	topRatedCache    types.Cacher
+	badRatedCache    types.Cacher
+	mut              sync.Mutex
+	policy           *ratingPolicy
+	lastUpdates      map[string]time.Time
+	snapshotStorer   SnapshotStorer
+	snapshotInterval time.Duration
+	decayPerHour     uint32
+	getTimeHandler   func() time.Time
+	cancelFunc       context.CancelFunc
 }
 
 // NewPeersRatingHandler returns a new peers rating handler
@@ -44,9 +64,32 @@ func NewPeersRatingHandler(args ArgPeersRatingHandler) (*peersRatingHandler, err
 		return nil, err
 	}
 
+	policy, err := newRatingPolicy(args.RatingPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	prh := &peersRatingHandler{
-		topRatedCache: args.TopRatedCache,
-		badRatedCache: args.BadRatedCache,
+		topRatedCache:    args.TopRatedCache,
+		badRatedCache:    args.BadRatedCache,
+		policy:           policy,
+		lastUpdates:      make(map[string]time.Time),
+		snapshotStorer:   args.SnapshotStorer,
+		snapshotInterval: args.SnapshotInterval,
+		decayPerHour:     args.DecayPerHour,
+		getTimeHandler:   time.Now,
+	}
+
+	var ctx context.Context
+	ctx, prh.cancelFunc = context.WithCancel(context.Background())
+	if prh.isPersistenceEnabled() {
+		prh.loadSnapshot()
+		if prh.snapshotInterval > 0 {
+			go prh.processSnapshotLoop(ctx)
+		}
+	}
+	if prh.policy.isDecayEnabled() {
+		go prh.processDecayLoop(ctx)
 	}
 
 	return prh, nil
@@ -59,6 +102,9 @@ func checkArgs(args ArgPeersRatingHandler) error {
 	if check.IfNil(args.BadRatedCache) {
 		return fmt.Errorf("%w for BadRatedCache", p2p.ErrNilCacher)
 	}
+	if args.SnapshotInterval < 0 {
+		return fmt.Errorf("%w for SnapshotInterval, should not be negative", p2p.ErrInvalidValue)
+	}
 
 	return nil
 }
@@ -74,23 +120,33 @@ func (prh *peersRatingHandler) AddPeer(pid core.PeerID) {
 		return
 	}
 
-	prh.topRatedCache.Put(pid.Bytes(), defaultRating, int32Size)
+	prh.putRating(pid.Bytes(), defaultRating)
 }
 
-// IncreaseRating increases the rating of a peer with the increase factor
+// IncreaseRating increases the rating of a peer with the default increase weight
 func (prh *peersRatingHandler) IncreaseRating(pid core.PeerID) {
+	prh.IncreaseRatingWithReason(pid, "")
+}
+
+// DecreaseRating decreases the rating of a peer with the default decrease weight
+func (prh *peersRatingHandler) DecreaseRating(pid core.PeerID) {
+	prh.DecreaseRatingWithReason(pid, "")
+}
+
+// IncreaseRatingWithReason increases the rating of a peer with the weight configured for the provided reason
+func (prh *peersRatingHandler) IncreaseRatingWithReason(pid core.PeerID, reason p2p.RatingReason) {
 	prh.mut.Lock()
 	defer prh.mut.Unlock()
 
-	prh.updateRatingIfNeeded(pid, increaseFactor)
+	prh.updateRatingIfNeeded(pid, prh.policy.increaseDelta(reason))
 }
 
-// DecreaseRating decreases the rating of a peer with the decrease factor
-func (prh *peersRatingHandler) DecreaseRating(pid core.PeerID) {
+// DecreaseRatingWithReason decreases the rating of a peer with the weight configured for the provided reason
+func (prh *peersRatingHandler) DecreaseRatingWithReason(pid core.PeerID, reason p2p.RatingReason) {
 	prh.mut.Lock()
 	defer prh.mut.Unlock()
 
-	prh.updateRatingIfNeeded(pid, decreaseFactor)
+	prh.updateRatingIfNeeded(pid, prh.policy.decreaseDelta(reason))
 }
 
 func (prh *peersRatingHandler) getOldRating(pid core.PeerID) (int32, bool) {
@@ -109,66 +165,68 @@ func (prh *peersRatingHandler) getOldRating(pid core.PeerID) (int32, bool) {
 	return defaultRating, found
 }
 
-func (prh *peersRatingHandler) updateRatingIfNeeded(pid core.PeerID, updateFactor int32) {
+func (prh *peersRatingHandler) updateRatingIfNeeded(pid core.PeerID, delta int64) {
 	oldRating, found := prh.getOldRating(pid)
 	if !found {
 		// new pid, add it with default rating
-		prh.topRatedCache.Put(pid.Bytes(), defaultRating, int32Size)
+		prh.putRating(pid.Bytes(), defaultRating)
 		return
 	}
 
-	decreasingUnderMin := oldRating == minRating && updateFactor == decreaseFactor
-	increasingOverMax := oldRating == maxRating && updateFactor == increaseFactor
-	shouldSkipUpdate := decreasingUnderMin || increasingOverMax
-	if shouldSkipUpdate {
+	newRating := prh.policy.clamp(int64(oldRating) + delta)
+	if newRating == oldRating {
 		return
 	}
 
-	newRating := oldRating + updateFactor
-	if newRating > maxRating {
-		newRating = maxRating
-	}
+	prh.updateRating(pid.Bytes(), oldRating, newRating)
+}
+
+func (prh *peersRatingHandler) updateRating(pidBytes []byte, oldRating, newRating int32) {
+	prh.moveRating(pidBytes, oldRating, newRating)
+	prh.setLastUpdate(pidBytes)
+}
 
-	if newRating < minRating {
-		newRating = minRating
+// moveRating stores the new rating in its tier cache without recording the update time, as the decay should not
+// look as peer activity
+func (prh *peersRatingHandler) moveRating(pidBytes []byte, oldRating, newRating int32) {
+	oldTier := prh.policy.computeTier(oldRating)
+	newTier := prh.policy.computeTier(newRating)
+	if newTier != oldTier {
+		prh.getTierCache(oldTier).Remove(pidBytes)
 	}
 
-	prh.updateRating(pid, oldRating, newRating)
+	prh.getTierCache(newTier).Put(pidBytes, newRating, int32Size)
 }
 
-func (prh *peersRatingHandler) updateRating(pid core.PeerID, oldRating, newRating int32) {
-	oldTier := computeRatingTier(oldRating)
-	newTier := computeRatingTier(newRating)
-	if newTier == oldTier {
-		if newTier == topRatedTier {
-			prh.topRatedCache.Put(pid.Bytes(), newRating, int32Size)
-		} else {
-			prh.badRatedCache.Put(pid.Bytes(), newRating, int32Size)
-		}
+func (prh *peersRatingHandler) putRating(pidBytes []byte, rating int32) {
+	prh.getTierCache(prh.policy.computeTier(rating)).Put(pidBytes, rating, int32Size)
+	prh.setLastUpdate(pidBytes)
+}
 
+// setLastUpdate records the time of the rating update. As the caches can evict peers, the records of the peers
+// that are no longer known are removed once the number of records exceeds the caches capacity
+func (prh *peersRatingHandler) setLastUpdate(pidBytes []byte) {
+	prh.lastUpdates[string(pidBytes)] = prh.getTimeHandler()
+
+	maxRecords := prh.topRatedCache.MaxSize() + prh.badRatedCache.MaxSize()
+	if len(prh.lastUpdates) <= maxRecords {
 		return
 	}
 
-	prh.movePeerToNewTier(newRating, pid)
-}
-
-func computeRatingTier(peerRating int32) string {
-	if peerRating >= defaultRating {
-		return topRatedTier
+	for pid := range prh.lastUpdates {
+		isKnown := prh.topRatedCache.Has([]byte(pid)) || prh.badRatedCache.Has([]byte(pid))
+		if !isKnown {
+			delete(prh.lastUpdates, pid)
+		}
 	}
-
-	return badRatedTier
 }
 
-func (prh *peersRatingHandler) movePeerToNewTier(newRating int32, pid core.PeerID) {
-	newTier := computeRatingTier(newRating)
-	if newTier == topRatedTier {
-		prh.badRatedCache.Remove(pid.Bytes())
-		prh.topRatedCache.Put(pid.Bytes(), newRating, int32Size)
-	} else {
-		prh.topRatedCache.Remove(pid.Bytes())
-		prh.badRatedCache.Put(pid.Bytes(), newRating, int32Size)
+func (prh *peersRatingHandler) getTierCache(tier string) types.Cacher {
+	if tier == topRatedTier {
+		return prh.topRatedCache
 	}
+
+	return prh.badRatedCache
 }
 
 // GetTopRatedPeersFromList returns a list of peers, searching them in the order of rating tiers
@@ -232,6 +290,223 @@ func (prh *peersRatingHandler) splitPeersByTiers(peers []core.PeerID) ([]core.Pe
 	return topRated, badRated
 }
 
+// GetTierSizes returns the number of peers in each rating tier
+func (prh *peersRatingHandler) GetTierSizes() map[string]int {
+	prh.mut.Lock()
+	defer prh.mut.Unlock()
+
+	return map[string]int{
+		topRatedTier: prh.topRatedCache.Len(),
+		badRatedTier: prh.badRatedCache.Len(),
+	}
+}
+
+func (prh *peersRatingHandler) isPersistenceEnabled() bool {
+	return !check.IfNil(prh.snapshotStorer)
+}
+
+// loadSnapshot reloads the persisted ratings. A missing, corrupt or version-mismatched snapshot is not fatal,
+// the node will just start with no known ratings
+func (prh *peersRatingHandler) loadSnapshot() {
+	buff, err := prh.snapshotStorer.Load()
+	if err != nil {
+		log.Debug("no ratings snapshot loaded", "error", err.Error())
+		return
+	}
+	if len(buff) == 0 {
+		log.Debug("empty ratings snapshot")
+		return
+	}
+
+	snapshot, err := decodeRatingsSnapshot(buff)
+	if err != nil {
+		log.Warn("ignoring the ratings snapshot", "error", err.Error())
+		return
+	}
+
+	downTime := prh.getTimeHandler().Sub(time.Unix(snapshot.Timestamp, 0))
+
+	prh.mut.Lock()
+	defer prh.mut.Unlock()
+
+	for _, entry := range snapshot.Ratings {
+		if len(entry.Pid) == 0 {
+			continue
+		}
+
+		rating := applyDownTimeDecay(prh.policy.clamp(int64(entry.Rating)), downTime, prh.decayPerHour)
+		prh.putRating(entry.Pid, rating)
+	}
+
+	log.Debug("loaded ratings snapshot",
+		"num peers", len(snapshot.Ratings),
+		"down time", downTime,
+	)
+}
+
+func (prh *peersRatingHandler) processDecayLoop(ctx context.Context) {
+	timer := time.NewTimer(prh.policy.decayInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("closing peersRatingHandler's decay go routine")
+			return
+		case <-timer.C:
+		}
+
+		prh.decayRatings()
+		timer.Reset(prh.policy.decayInterval)
+	}
+}
+
+// decayRatings moves all the known ratings toward the default rating, so old misbehaviour (or good behaviour)
+// is eventually forgotten
+func (prh *peersRatingHandler) decayRatings() {
+	prh.mut.Lock()
+	defer prh.mut.Unlock()
+
+	entries := appendCachedRatings(make([]peerRatingEntry, 0), prh.topRatedCache)
+	entries = appendCachedRatings(entries, prh.badRatedCache)
+	for _, entry := range entries {
+		newRating := prh.policy.decay(entry.Rating)
+		if newRating == entry.Rating {
+			continue
+		}
+
+		prh.moveRating(entry.Pid, entry.Rating, newRating)
+	}
+}
+
+func (prh *peersRatingHandler) processSnapshotLoop(ctx context.Context) {
+	timer := time.NewTimer(prh.snapshotInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Debug("closing peersRatingHandler's snapshot go routine")
+			return
+		case <-timer.C:
+		}
+
+		err := prh.saveSnapshot()
+		if err != nil {
+			log.Warn("error saving the ratings snapshot", "error", err.Error())
+		}
+		timer.Reset(prh.snapshotInterval)
+	}
+}
+
+func (prh *peersRatingHandler) saveSnapshot() error {
+	snapshot := prh.createSnapshot()
+	buff, err := encodeRatingsSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	return prh.snapshotStorer.Save(buff)
+}
+
+func (prh *peersRatingHandler) createSnapshot() *ratingsSnapshot {
+	prh.mut.Lock()
+	defer prh.mut.Unlock()
+
+	snapshot := &ratingsSnapshot{
+		Version:   ratingsSnapshotVersion,
+		Timestamp: prh.getTimeHandler().Unix(),
+		Ratings:   make([]peerRatingEntry, 0, prh.topRatedCache.Len()+prh.badRatedCache.Len()),
+	}
+	snapshot.Ratings = appendCachedRatings(snapshot.Ratings, prh.topRatedCache)
+	snapshot.Ratings = appendCachedRatings(snapshot.Ratings, prh.badRatedCache)
+
+	return snapshot
+}
+
+func appendCachedRatings(entries []peerRatingEntry, cache types.Cacher) []peerRatingEntry {
+	for _, key := range cache.Keys() {
+		value, found := cache.Peek(key)
+		if !found {
+			continue
+		}
+		rating, ok := value.(int32)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, peerRatingEntry{
+			Pid:    key,
+			Rating: rating,
+		})
+	}
+
+	return entries
+}
+
+// Close stops the snapshot and decay go routines and saves the ratings, if the persistence is enabled
+func (prh *peersRatingHandler) Close() error {
+	prh.cancelFunc()
+
+	if !prh.isPersistenceEnabled() {
+		return nil
+	}
+
+	return prh.saveSnapshot()
+}
+
+// GetRatingsSnapshot returns the rating information of all known peers, sorted by rating in descending order
+func (prh *peersRatingHandler) GetRatingsSnapshot() []p2p.PeerRating {
+	prh.mut.Lock()
+	defer prh.mut.Unlock()
+
+	ratings := make([]p2p.PeerRating, 0, prh.topRatedCache.Len()+prh.badRatedCache.Len())
+	ratings = prh.appendPeerRatings(ratings, prh.topRatedCache, topRatedTier)
+	ratings = prh.appendPeerRatings(ratings, prh.badRatedCache, badRatedTier)
+
+	sort.Slice(ratings, func(i, j int) bool {
+		if ratings[i].Rating == ratings[j].Rating {
+			return ratings[i].Pid < ratings[j].Pid
+		}
+
+		return ratings[i].Rating > ratings[j].Rating
+	})
+
+	return ratings
+}
+
+// GetRating returns the current rating of the provided peer and true if the peer is known. It does not alter the
+// caches order, so it can be called for any number of peers
+func (prh *peersRatingHandler) GetRating(pid core.PeerID) (int32, bool) {
+	prh.mut.Lock()
+	defer prh.mut.Unlock()
+
+	for _, cache := range []types.Cacher{prh.topRatedCache, prh.badRatedCache} {
+		value, found := cache.Peek(pid.Bytes())
+		if !found {
+			continue
+		}
+
+		rating, _ := value.(int32)
+		return rating, true
+	}
+
+	return defaultRating, false
+}
+
+func (prh *peersRatingHandler) appendPeerRatings(ratings []p2p.PeerRating, cache types.Cacher, tier string) []p2p.PeerRating {
+	for _, entry := range appendCachedRatings(make([]peerRatingEntry, 0), cache) {
+		ratings = append(ratings, p2p.PeerRating{
+			Pid:        core.PeerID(entry.Pid),
+			Rating:     entry.Rating,
+			Tier:       tier,
+			LastUpdate: prh.lastUpdates[string(entry.Pid)],
+		})
+	}
+
+	return ratings
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (prh *peersRatingHandler) IsInterfaceNil() bool {
 	return prh == nil
@@ -2,15 +2,22 @@ package rating
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-p2p-go"
 	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/multiversx/mx-chain-storage-go/lrucache"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func createMockArgs() ArgPeersRatingHandler {
@@ -45,6 +52,17 @@ func TestNewPeersRatingHandler(t *testing.T) {
 		assert.True(t, strings.Contains(err.Error(), "BadRatedCache"))
 		assert.True(t, check.IfNil(prh))
 	})
+	t.Run("negative snapshot interval should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs()
+		args.SnapshotInterval = -time.Second
+
+		prh, err := NewPeersRatingHandler(args)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, strings.Contains(err.Error(), "SnapshotInterval"))
+		assert.True(t, check.IfNil(prh))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -424,3 +442,423 @@ func TestPeersRatingHandler_GetTopRatedPeersFromList(t *testing.T) {
 		assert.Equal(t, expectedListOfPeers, res)
 	})
 }
+
+func TestPeersRatingHandler_GetTierSizes(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgs()
+	args.TopRatedCache = &mock.CacherStub{
+		LenCalled: func() int {
+			return 5
+		},
+	}
+	args.BadRatedCache = &mock.CacherStub{
+		LenCalled: func() int {
+			return 2
+		},
+	}
+	prh, _ := NewPeersRatingHandler(args)
+
+	expectedSizes := map[string]int{
+		topRatedTier: 5,
+		badRatedTier: 2,
+	}
+	assert.Equal(t, expectedSizes, prh.GetTierSizes())
+}
+
+func createArgsWithLRUCaches(t *testing.T) ArgPeersRatingHandler {
+	topRatedCache, err := lrucache.NewCache(100)
+	require.Nil(t, err)
+	badRatedCache, err := lrucache.NewCache(100)
+	require.Nil(t, err)
+
+	return ArgPeersRatingHandler{
+		TopRatedCache: topRatedCache,
+		BadRatedCache: badRatedCache,
+	}
+}
+
+func createSnapshotBuff(t *testing.T, version uint32, timestamp time.Time, ratings map[core.PeerID]int32) []byte {
+	snapshot := &ratingsSnapshot{
+		Version:   version,
+		Timestamp: timestamp.Unix(),
+	}
+	for pid, rating := range ratings {
+		snapshot.Ratings = append(snapshot.Ratings, peerRatingEntry{
+			Pid:    pid.Bytes(),
+			Rating: rating,
+		})
+	}
+
+	buff, err := json.Marshal(snapshot)
+	require.Nil(t, err)
+
+	return buff
+}
+
+func getRating(prh *peersRatingHandler, pid core.PeerID) (int32, string) {
+	rating, found := prh.topRatedCache.Get(pid.Bytes())
+	if found {
+		return rating.(int32), topRatedTier
+	}
+	rating, found = prh.badRatedCache.Get(pid.Bytes())
+	if found {
+		return rating.(int32), badRatedTier
+	}
+
+	return defaultRating, ""
+}
+
+func TestPeersRatingHandler_Persistence(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no snapshot storer should not save on close", func(t *testing.T) {
+		t.Parallel()
+
+		prh, _ := NewPeersRatingHandler(createArgsWithLRUCaches(t))
+		prh.AddPeer("pid")
+
+		assert.Nil(t, prh.Close())
+	})
+	t.Run("should save on close and reload in a new instance", func(t *testing.T) {
+		t.Parallel()
+
+		storer, _ := NewFileSnapshotStorer(filepath.Join(t.TempDir(), "ratings.json"))
+		args := createArgsWithLRUCaches(t)
+		args.SnapshotStorer = storer
+		prh, _ := NewPeersRatingHandler(args)
+
+		goodPid := core.PeerID("good pid")
+		badPid := core.PeerID("bad pid")
+		prh.AddPeer(goodPid)
+		prh.IncreaseRating(goodPid)
+		prh.IncreaseRating(goodPid)
+		prh.AddPeer(badPid)
+		prh.DecreaseRating(badPid)
+		require.Nil(t, prh.Close())
+
+		args = createArgsWithLRUCaches(t)
+		args.SnapshotStorer = storer
+		reloaded, _ := NewPeersRatingHandler(args)
+
+		rating, tier := getRating(reloaded, goodPid)
+		assert.Equal(t, int32(2*increaseFactor), rating)
+		assert.Equal(t, topRatedTier, tier)
+		rating, tier = getRating(reloaded, badPid)
+		assert.Equal(t, int32(decreaseFactor), rating)
+		assert.Equal(t, badRatedTier, tier)
+		assert.Nil(t, reloaded.Close())
+	})
+	t.Run("should apply the decay for the down time", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsWithLRUCaches(t)
+		args.DecayPerHour = 5
+		args.SnapshotStorer = &mock.SnapshotStorerStub{
+			LoadCalled: func() ([]byte, error) {
+				ratings := map[core.PeerID]int32{
+					"pid top":           50,
+					"pid bad":           -50,
+					"pid fully decayed": 8,
+					"pid out of range":  1000,
+				}
+
+				return createSnapshotBuff(t, ratingsSnapshotVersion, time.Now().Add(-2*time.Hour-time.Minute), ratings), nil
+			},
+		}
+		prh, _ := NewPeersRatingHandler(args)
+
+		rating, tier := getRating(prh, "pid top")
+		assert.Equal(t, int32(40), rating)
+		assert.Equal(t, topRatedTier, tier)
+		rating, tier = getRating(prh, "pid bad")
+		assert.Equal(t, int32(-40), rating)
+		assert.Equal(t, badRatedTier, tier)
+		rating, tier = getRating(prh, "pid fully decayed")
+		assert.Equal(t, defaultRating, rating)
+		assert.Equal(t, topRatedTier, tier)
+		rating, _ = getRating(prh, "pid out of range")
+		assert.Equal(t, int32(maxRating-10), rating)
+	})
+	t.Run("corrupt or version mismatched snapshots should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		buffs := [][]byte{
+			[]byte("not a snapshot"),
+			createSnapshotBuff(t, ratingsSnapshotVersion+1, time.Now(), map[core.PeerID]int32{"pid": 10}),
+		}
+		for _, buff := range buffs {
+			snapshotBuff := buff
+			args := createArgsWithLRUCaches(t)
+			args.SnapshotStorer = &mock.SnapshotStorerStub{
+				LoadCalled: func() ([]byte, error) {
+					return snapshotBuff, nil
+				},
+			}
+			prh, err := NewPeersRatingHandler(args)
+			assert.Nil(t, err)
+			assert.Equal(t, 0, prh.topRatedCache.Len())
+			assert.Equal(t, 0, prh.badRatedCache.Len())
+		}
+	})
+	t.Run("load error should be ignored", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsWithLRUCaches(t)
+		args.SnapshotStorer = &mock.SnapshotStorerStub{
+			LoadCalled: func() ([]byte, error) {
+				return nil, errors.New("expected error")
+			},
+		}
+		prh, err := NewPeersRatingHandler(args)
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(prh))
+	})
+	t.Run("should save periodically", func(t *testing.T) {
+		t.Parallel()
+
+		numSaves := uint32(0)
+		args := createArgsWithLRUCaches(t)
+		args.SnapshotInterval = time.Millisecond * 50
+		args.SnapshotStorer = &mock.SnapshotStorerStub{
+			SaveCalled: func(buff []byte) error {
+				snapshot, err := decodeRatingsSnapshot(buff)
+				assert.Nil(t, err)
+				assert.Equal(t, 1, len(snapshot.Ratings))
+				atomic.AddUint32(&numSaves, 1)
+
+				return nil
+			},
+		}
+		prh, _ := NewPeersRatingHandler(args)
+		prh.AddPeer("pid")
+
+		time.Sleep(time.Millisecond * 180)
+		assert.Nil(t, prh.Close())
+		numSavesAfterClose := atomic.LoadUint32(&numSaves)
+		assert.True(t, numSavesAfterClose >= 3)
+
+		time.Sleep(time.Millisecond * 100)
+		assert.Equal(t, numSavesAfterClose, atomic.LoadUint32(&numSaves))
+	})
+	t.Run("save error should be returned on close", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createArgsWithLRUCaches(t)
+		args.SnapshotStorer = &mock.SnapshotStorerStub{
+			SaveCalled: func(buff []byte) error {
+				return expectedErr
+			},
+		}
+		prh, _ := NewPeersRatingHandler(args)
+
+		assert.Equal(t, expectedErr, prh.Close())
+	})
+}
+
+func TestPeersRatingHandler_RatingPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid policy should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs()
+		args.RatingPolicy = createMockRatingPolicyConfig()
+		args.RatingPolicy.MaxRating = -1
+
+		prh, err := NewPeersRatingHandler(args)
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+		assert.True(t, check.IfNil(prh))
+	})
+	t.Run("should use the reason weights, the bounds and the tier threshold", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsWithLRUCaches(t)
+		args.RatingPolicy = createMockRatingPolicyConfig()
+		args.RatingPolicy.DecayIntervalInSec = 0
+		prh, _ := NewPeersRatingHandler(args)
+
+		pid := core.PeerID("pid")
+		prh.AddPeer(pid)
+		prh.IncreaseRatingWithReason(pid, p2p.ValidDirectResponseRatingReason)
+		rating, tier := getRating(prh, pid)
+		assert.Equal(t, int32(4), rating)
+		assert.Equal(t, topRatedTier, tier)
+
+		prh.DecreaseRatingWithReason(pid, p2p.TimeoutRatingReason)
+		prh.DecreaseRatingWithReason(pid, p2p.TimeoutRatingReason)
+		rating, tier = getRating(prh, pid)
+		assert.Equal(t, int32(-6), rating)
+		assert.Equal(t, topRatedTier, tier)
+
+		prh.DecreaseRatingWithReason(pid, p2p.InvalidDataRatingReason)
+		rating, tier = getRating(prh, pid)
+		assert.Equal(t, int32(-26), rating)
+		assert.Equal(t, badRatedTier, tier)
+		assert.Equal(t, 0, prh.topRatedCache.Len())
+
+		prh.DecreaseRatingWithReason(pid, p2p.InvalidDataRatingReason)
+		prh.DecreaseRatingWithReason(pid, p2p.InvalidDataRatingReason)
+		rating, _ = getRating(prh, pid)
+		assert.Equal(t, int32(-50), rating)
+
+		for i := 0; i < 40; i++ {
+			prh.IncreaseRatingWithReason(pid, p2p.ValidGossipRatingReason)
+		}
+		rating, tier = getRating(prh, pid)
+		assert.Equal(t, int32(50), rating)
+		assert.Equal(t, topRatedTier, tier)
+		assert.Equal(t, 0, prh.badRatedCache.Len())
+	})
+	t.Run("default rating below the threshold should add the peer in the bad rated tier", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsWithLRUCaches(t)
+		args.RatingPolicy = createMockRatingPolicyConfig()
+		args.RatingPolicy.TopRatedTierThreshold = 10
+		prh, _ := NewPeersRatingHandler(args)
+
+		prh.AddPeer("pid")
+		_, tier := getRating(prh, "pid")
+		assert.Equal(t, badRatedTier, tier)
+		assert.Nil(t, prh.Close())
+	})
+	t.Run("should decay the ratings toward zero", func(t *testing.T) {
+		t.Parallel()
+
+		args := createArgsWithLRUCaches(t)
+		args.RatingPolicy = createMockRatingPolicyConfig()
+		args.RatingPolicy.TopRatedTierThreshold = 0
+		args.RatingPolicy.DecayStep = 3
+		prh, _ := NewPeersRatingHandler(args)
+		defer func() {
+			_ = prh.Close()
+		}()
+		startTime := time.Unix(1000, 0)
+		currentTime := startTime
+		prh.getTimeHandler = func() time.Time {
+			return currentTime
+		}
+
+		prh.AddPeer("good pid")
+		prh.IncreaseRatingWithReason("good pid", p2p.ValidDirectResponseRatingReason)
+		prh.AddPeer("bad pid")
+		prh.DecreaseRatingWithReason("bad pid", p2p.TimeoutRatingReason)
+
+		currentTime = startTime.Add(time.Hour)
+		prh.decayRatings()
+		rating, tier := getRating(prh, "good pid")
+		assert.Equal(t, int32(1), rating)
+		assert.Equal(t, topRatedTier, tier)
+		rating, tier = getRating(prh, "bad pid")
+		assert.Equal(t, int32(-2), rating)
+		assert.Equal(t, badRatedTier, tier)
+
+		prh.decayRatings()
+		rating, _ = getRating(prh, "good pid")
+		assert.Equal(t, defaultRating, rating)
+		rating, tier = getRating(prh, "bad pid")
+		assert.Equal(t, defaultRating, rating)
+		assert.Equal(t, topRatedTier, tier)
+		assert.Equal(t, 0, prh.badRatedCache.Len())
+
+		// the decay should not be recorded as a rating update
+		for _, peerRating := range prh.GetRatingsSnapshot() {
+			assert.Equal(t, startTime, peerRating.LastUpdate)
+		}
+	})
+}
+
+func TestPeersRatingHandler_GetRatingsSnapshot(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return all known peers sorted by rating", func(t *testing.T) {
+		t.Parallel()
+
+		prh, _ := NewPeersRatingHandler(createArgsWithLRUCaches(t))
+		startTime := time.Unix(1000, 0)
+		currentTime := startTime
+		prh.getTimeHandler = func() time.Time {
+			return currentTime
+		}
+
+		prh.AddPeer("pid bad")
+		prh.AddPeer("pid good")
+		prh.AddPeer("pid new b")
+		prh.AddPeer("pid new a")
+		currentTime = startTime.Add(time.Minute)
+		prh.DecreaseRating("pid bad")
+		prh.IncreaseRating("pid good")
+
+		expectedRatings := []p2p.PeerRating{
+			{Pid: "pid good", Rating: increaseFactor, Tier: topRatedTier, LastUpdate: currentTime},
+			{Pid: "pid new a", Rating: defaultRating, Tier: topRatedTier, LastUpdate: startTime},
+			{Pid: "pid new b", Rating: defaultRating, Tier: topRatedTier, LastUpdate: startTime},
+			{Pid: "pid bad", Rating: decreaseFactor, Tier: badRatedTier, LastUpdate: currentTime},
+		}
+		assert.Equal(t, expectedRatings, prh.GetRatingsSnapshot())
+	})
+	t.Run("JSON form should be serialisable", func(t *testing.T) {
+		t.Parallel()
+
+		prh, _ := NewPeersRatingHandler(createArgsWithLRUCaches(t))
+		prh.getTimeHandler = func() time.Time {
+			return time.Unix(1000, 0)
+		}
+		pid := core.PeerID("pid")
+		prh.AddPeer(pid)
+		prh.IncreaseRating(pid)
+
+		buff, err := json.Marshal(p2p.PeerRatingsToJSON(prh.GetRatingsSnapshot()))
+		assert.Nil(t, err)
+
+		expectedJSON := `[{"pid":"` + pid.Pretty() + `","rating":2,"tier":"top rated tier","lastUpdate":1000}]`
+		assert.Equal(t, expectedJSON, string(buff))
+	})
+	t.Run("unknown last update should be 0 in the JSON form", func(t *testing.T) {
+		t.Parallel()
+
+		ratingJSON := p2p.PeerRating{Pid: "pid", Rating: 5, Tier: topRatedTier}.ToJSON()
+		assert.Equal(t, int64(0), ratingJSON.LastUpdate)
+	})
+	t.Run("last updates of evicted peers should be removed", func(t *testing.T) {
+		t.Parallel()
+
+		topRatedCache, _ := lrucache.NewCache(2)
+		badRatedCache, _ := lrucache.NewCache(2)
+		prh, _ := NewPeersRatingHandler(ArgPeersRatingHandler{
+			TopRatedCache: topRatedCache,
+			BadRatedCache: badRatedCache,
+		})
+
+		for i := 0; i < 10; i++ {
+			prh.AddPeer(core.PeerID(fmt.Sprintf("pid %d", i)))
+		}
+
+		assert.True(t, len(prh.lastUpdates) <= 4)
+		assert.Equal(t, 2, len(prh.GetRatingsSnapshot()))
+	})
+}
+
+func TestPeersRatingHandler_GetRating(t *testing.T) {
+	t.Parallel()
+
+	prh, _ := NewPeersRatingHandler(createArgsWithLRUCaches(t))
+
+	rating, found := prh.GetRating("unknown pid")
+	assert.False(t, found)
+	assert.Equal(t, defaultRating, rating)
+
+	prh.AddPeer("pid good")
+	prh.IncreaseRating("pid good")
+	prh.AddPeer("pid bad")
+	prh.DecreaseRating("pid bad")
+
+	rating, found = prh.GetRating("pid good")
+	assert.True(t, found)
+	assert.Equal(t, int32(increaseFactor), rating)
+	rating, found = prh.GetRating("pid bad")
+	assert.True(t, found)
+	assert.Equal(t, int32(decreaseFactor), rating)
+}
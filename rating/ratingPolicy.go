@@ -0,0 +1,150 @@
+package rating
+
+import (
+	"fmt"
+	"time"
+
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+)
+
+const (
+	defaultValidRequestWeight = 1
+	defaultInvalidDataWeight  = 10
+)
+
+type ratingPolicy struct {
+	minRating             int64
+	maxRating             int64
+	topRatedTierThreshold int32
+	increaseWeight        int64
+	decreaseWeight        int64
+	increaseWeights       map[p2p.RatingReason]int64
+	decreaseWeights       map[p2p.RatingReason]int64
+	decayInterval         time.Duration
+	decayStep             int64
+}
+
+func createDefaultRatingPolicyConfig() config.RatingPolicyConfig {
+	return config.RatingPolicyConfig{
+		MinRating:                 minRating,
+		MaxRating:                 maxRating,
+		TopRatedTierThreshold:     defaultRating,
+		IncreaseWeight:            increaseFactor,
+		DecreaseWeight:            -decreaseFactor,
+		ValidGossipWeight:         increaseFactor,
+		ValidDirectResponseWeight: increaseFactor,
+		ValidRequestWeight:        defaultValidRequestWeight,
+		TimeoutWeight:             -decreaseFactor,
+		InvalidDataWeight:         defaultInvalidDataWeight,
+		FloodingWeight:            -decreaseFactor,
+	}
+}
+
+func newRatingPolicy(cfg config.RatingPolicyConfig) (*ratingPolicy, error) {
+	cfg = applyRatingPolicyDefaults(cfg)
+	err := checkRatingPolicyConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ratingPolicy{
+		minRating:             int64(cfg.MinRating),
+		maxRating:             int64(cfg.MaxRating),
+		topRatedTierThreshold: cfg.TopRatedTierThreshold,
+		increaseWeight:        int64(cfg.IncreaseWeight),
+		decreaseWeight:        int64(cfg.DecreaseWeight),
+		increaseWeights: map[p2p.RatingReason]int64{
+			p2p.ValidGossipRatingReason:         int64(cfg.ValidGossipWeight),
+			p2p.ValidDirectResponseRatingReason: int64(cfg.ValidDirectResponseWeight),
+			p2p.ValidRequestRatingReason:        int64(cfg.ValidRequestWeight),
+		},
+		decreaseWeights: map[p2p.RatingReason]int64{
+			p2p.TimeoutRatingReason:     int64(cfg.TimeoutWeight),
+			p2p.InvalidDataRatingReason: int64(cfg.InvalidDataWeight),
+			p2p.FloodingRatingReason:    int64(cfg.FloodingWeight),
+		},
+		decayInterval: time.Duration(cfg.DecayIntervalInSec) * time.Second,
+		decayStep:     int64(cfg.DecayStep),
+	}, nil
+}
+
+// applyRatingPolicyDefaults returns the default policy only if the whole config is missing. A provided config is used
+// as it is, so any of its fields can be set to 0 (a zero decay interval meaning the decay is disabled)
+func applyRatingPolicyDefaults(cfg config.RatingPolicyConfig) config.RatingPolicyConfig {
+	if cfg == (config.RatingPolicyConfig{}) {
+		return createDefaultRatingPolicyConfig()
+	}
+
+	return cfg
+}
+
+func checkRatingPolicyConfig(cfg config.RatingPolicyConfig) error {
+	if cfg.MinRating > defaultRating {
+		return fmt.Errorf("%w for MinRating, should not be greater than %d", p2p.ErrInvalidValue, defaultRating)
+	}
+	if cfg.MaxRating < defaultRating {
+		return fmt.Errorf("%w for MaxRating, should not be lower than %d", p2p.ErrInvalidValue, defaultRating)
+	}
+	if cfg.MinRating >= cfg.MaxRating {
+		return fmt.Errorf("%w for MinRating, should be lower than MaxRating", p2p.ErrInvalidValue)
+	}
+	if cfg.TopRatedTierThreshold <= cfg.MinRating || cfg.TopRatedTierThreshold > cfg.MaxRating {
+		return fmt.Errorf("%w for TopRatedTierThreshold, should be in the interval (MinRating, MaxRating]",
+			p2p.ErrInvalidValue)
+	}
+	if cfg.DecayIntervalInSec > 0 && cfg.DecayStep == 0 {
+		return fmt.Errorf("%w for DecayStep, should be greater than 0 if the decay is enabled", p2p.ErrInvalidValue)
+	}
+
+	return nil
+}
+
+// increaseDelta returns the rating delta for an increase event. An unknown reason uses the default increase weight
+func (policy *ratingPolicy) increaseDelta(reason p2p.RatingReason) int64 {
+	weight, found := policy.increaseWeights[reason]
+	if !found {
+		return policy.increaseWeight
+	}
+
+	return weight
+}
+
+// decreaseDelta returns the (negative) rating delta for a decrease event. An unknown reason uses the default
+// decrease weight
+func (policy *ratingPolicy) decreaseDelta(reason p2p.RatingReason) int64 {
+	weight, found := policy.decreaseWeights[reason]
+	if !found {
+		return -policy.decreaseWeight
+	}
+
+	return -weight
+}
+
+func (policy *ratingPolicy) clamp(rating int64) int32 {
+	if rating > policy.maxRating {
+		return int32(policy.maxRating)
+	}
+	if rating < policy.minRating {
+		return int32(policy.minRating)
+	}
+
+	return int32(rating)
+}
+
+func (policy *ratingPolicy) computeTier(peerRating int32) string {
+	if peerRating >= policy.topRatedTierThreshold {
+		return topRatedTier
+	}
+
+	return badRatedTier
+}
+
+// decay moves the rating toward the default rating with the decay step
+func (policy *ratingPolicy) decay(peerRating int32) int32 {
+	return moveTowardDefaultRating(peerRating, policy.decayStep)
+}
+
+func (policy *ratingPolicy) isDecayEnabled() bool {
+	return policy.decayInterval > 0
+}
@@ -0,0 +1,144 @@
+package rating
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func createMockRatingPolicyConfig() config.RatingPolicyConfig {
+	return config.RatingPolicyConfig{
+		MinRating:                 -50,
+		MaxRating:                 50,
+		TopRatedTierThreshold:     -10,
+		IncreaseWeight:            1,
+		DecreaseWeight:            2,
+		ValidGossipWeight:         3,
+		ValidDirectResponseWeight: 4,
+		ValidRequestWeight:        6,
+		TimeoutWeight:             5,
+		InvalidDataWeight:         20,
+		FloodingWeight:            7,
+		DecayIntervalInSec:        60,
+		DecayStep:                 1,
+	}
+}
+
+func TestNewRatingPolicy(t *testing.T) {
+	t.Parallel()
+
+	testInvalidConfig := func(modify func(cfg *config.RatingPolicyConfig), field string) func(t *testing.T) {
+		return func(t *testing.T) {
+			t.Parallel()
+
+			cfg := createMockRatingPolicyConfig()
+			modify(&cfg)
+			policy, err := newRatingPolicy(cfg)
+
+			assert.Nil(t, policy)
+			assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+			assert.True(t, strings.Contains(err.Error(), field))
+		}
+	}
+
+	t.Run("positive min rating should error", testInvalidConfig(func(cfg *config.RatingPolicyConfig) {
+		cfg.MinRating = 1
+	}, "MinRating"))
+	t.Run("negative max rating should error", testInvalidConfig(func(cfg *config.RatingPolicyConfig) {
+		cfg.MaxRating = -1
+	}, "MaxRating"))
+	t.Run("threshold equal to min rating should error", testInvalidConfig(func(cfg *config.RatingPolicyConfig) {
+		cfg.TopRatedTierThreshold = cfg.MinRating
+	}, "TopRatedTierThreshold"))
+	t.Run("threshold above max rating should error", testInvalidConfig(func(cfg *config.RatingPolicyConfig) {
+		cfg.TopRatedTierThreshold = cfg.MaxRating + 1
+	}, "TopRatedTierThreshold"))
+	t.Run("decay enabled without step should error", testInvalidConfig(func(cfg *config.RatingPolicyConfig) {
+		cfg.DecayStep = 0
+	}, "DecayStep"))
+	t.Run("zero value config should use the default policy", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := newRatingPolicy(config.RatingPolicyConfig{})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(minRating), policy.minRating)
+		assert.Equal(t, int64(maxRating), policy.maxRating)
+		assert.Equal(t, defaultRating, policy.topRatedTierThreshold)
+		assert.Equal(t, int64(increaseFactor), policy.increaseDelta(""))
+		assert.Equal(t, int64(decreaseFactor), policy.decreaseDelta(""))
+		assert.False(t, policy.isDecayEnabled())
+	})
+	t.Run("zero fields of a provided config should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := createMockRatingPolicyConfig()
+		cfg.MinRating = 0
+		cfg.TopRatedTierThreshold = 10
+		cfg.ValidGossipWeight = 0
+		cfg.FloodingWeight = 0
+		cfg.IncreaseWeight = 0
+		policy, err := newRatingPolicy(cfg)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), policy.minRating)
+		assert.Equal(t, int64(50), policy.maxRating)
+		assert.Equal(t, int32(10), policy.topRatedTierThreshold)
+		assert.Equal(t, int64(0), policy.increaseDelta(""))
+		assert.Equal(t, int64(0), policy.increaseDelta(p2p.ValidGossipRatingReason))
+		assert.Equal(t, int64(4), policy.increaseDelta(p2p.ValidDirectResponseRatingReason))
+		assert.Equal(t, int64(0), policy.decreaseDelta(p2p.FloodingRatingReason))
+		assert.Equal(t, int64(-20), policy.decreaseDelta(p2p.InvalidDataRatingReason))
+	})
+	t.Run("partial config without max rating should error", testInvalidConfig(func(cfg *config.RatingPolicyConfig) {
+		*cfg = config.RatingPolicyConfig{
+			InvalidDataWeight: 20,
+		}
+	}, "MinRating"))
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		policy, err := newRatingPolicy(createMockRatingPolicyConfig())
+		assert.Nil(t, err)
+		assert.Equal(t, time.Minute, policy.decayInterval)
+		assert.True(t, policy.isDecayEnabled())
+	})
+}
+
+func TestRatingPolicy_Deltas(t *testing.T) {
+	t.Parallel()
+
+	policy, _ := newRatingPolicy(createMockRatingPolicyConfig())
+
+	assert.Equal(t, int64(1), policy.increaseDelta(""))
+	assert.Equal(t, int64(3), policy.increaseDelta(p2p.ValidGossipRatingReason))
+	assert.Equal(t, int64(4), policy.increaseDelta(p2p.ValidDirectResponseRatingReason))
+	assert.Equal(t, int64(6), policy.increaseDelta(p2p.ValidRequestRatingReason))
+	assert.Equal(t, int64(1), policy.increaseDelta(p2p.TimeoutRatingReason))
+
+	assert.Equal(t, int64(-2), policy.decreaseDelta(""))
+	assert.Equal(t, int64(-5), policy.decreaseDelta(p2p.TimeoutRatingReason))
+	assert.Equal(t, int64(-20), policy.decreaseDelta(p2p.InvalidDataRatingReason))
+	assert.Equal(t, int64(-7), policy.decreaseDelta(p2p.FloodingRatingReason))
+	assert.Equal(t, int64(-2), policy.decreaseDelta(p2p.ValidGossipRatingReason))
+}
+
+func TestRatingPolicy_ClampAndTiers(t *testing.T) {
+	t.Parallel()
+
+	policy, _ := newRatingPolicy(createMockRatingPolicyConfig())
+
+	assert.Equal(t, int32(50), policy.clamp(1000))
+	assert.Equal(t, int32(-50), policy.clamp(-1000))
+	assert.Equal(t, int32(7), policy.clamp(7))
+
+	assert.Equal(t, topRatedTier, policy.computeTier(-10))
+	assert.Equal(t, badRatedTier, policy.computeTier(-11))
+
+	assert.Equal(t, int32(9), policy.decay(10))
+	assert.Equal(t, int32(-9), policy.decay(-10))
+	assert.Equal(t, defaultRating, policy.decay(defaultRating))
+}
@@ -0,0 +1,67 @@
+package rating
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+const ratingsSnapshotVersion = uint32(1)
+
+type peerRatingEntry struct {
+	Pid    []byte `json:"pid"`
+	Rating int32  `json:"rating"`
+}
+
+type ratingsSnapshot struct {
+	Version   uint32            `json:"version"`
+	Timestamp int64             `json:"timestamp"`
+	Ratings   []peerRatingEntry `json:"ratings"`
+}
+
+func encodeRatingsSnapshot(snapshot *ratingsSnapshot) ([]byte, error) {
+	return json.Marshal(snapshot)
+}
+
+func decodeRatingsSnapshot(buff []byte) (*ratingsSnapshot, error) {
+	snapshot := &ratingsSnapshot{}
+	err := json.Unmarshal(buff, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", p2p.ErrInvalidRatingsSnapshot, err.Error())
+	}
+	if snapshot.Version != ratingsSnapshotVersion {
+		return nil, fmt.Errorf("%w, expected %d, got %d",
+			p2p.ErrRatingsSnapshotVersionMismatch, ratingsSnapshotVersion, snapshot.Version)
+	}
+
+	return snapshot, nil
+}
+
+// applyDownTimeDecay moves the rating toward the default rating with decayPerHour points for each full hour
+// that passed since the snapshot was taken
+func applyDownTimeDecay(rating int32, downTime time.Duration, decayPerHour uint32) int32 {
+	if downTime <= 0 || decayPerHour == 0 {
+		return rating
+	}
+
+	return moveTowardDefaultRating(rating, int64(downTime/time.Hour)*int64(decayPerHour))
+}
+
+func moveTowardDefaultRating(rating int32, amount int64) int32 {
+	distance := int64(rating) - int64(defaultRating)
+	if distance > 0 {
+		return defaultRating + int32(maxInt64(distance-amount, 0))
+	}
+
+	return defaultRating - int32(maxInt64(-distance-amount, 0))
+}
+
+func maxInt64(a int64, b int64) int64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,15 @@
+package p2p
+
+import "time"
+
+// SeederStatus holds the health state of one seeder from the initial peers list
+type SeederStatus struct {
+	Address             string
+	IsHealthy           bool
+	LastSuccess         time.Time
+	LastAttempt         time.Time
+	LastError           string
+	ConsecutiveFailures uint32
+	Latency             time.Duration
+	NextAttempt         time.Time
+}
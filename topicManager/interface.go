@@ -0,0 +1,15 @@
+package topicManager
+
+import (
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+// TopicsHandler defines the messenger subset used by the topic manager to handle the subscriptions
+type TopicsHandler interface {
+	CreateTopic(name string, createChannelForTopic bool, options ...p2p.TopicOption) error
+	HasTopic(name string) bool
+	RegisterMessageProcessor(topic string, identifier string, handler p2p.MessageProcessor) error
+	UnregisterMessageProcessor(topic string, identifier string) error
+	LeaveTopic(name string) error
+	IsInterfaceNil() bool
+}
@@ -0,0 +1,345 @@
+package topicManager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	logger "github.com/multiversx/mx-chain-logger-go"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+var log = logger.GetOrCreate("p2p/topicManager")
+
+// ArgsTopicManager is the DTO used to create a new topic manager
+type ArgsTopicManager struct {
+	TopicsHandler TopicsHandler
+	SelfShardID   uint32
+	NumShards     uint32
+	Templates     []TopicTemplate
+}
+
+type topicManager struct {
+	mut           sync.RWMutex
+	topicsHandler TopicsHandler
+	selfShardID   uint32
+	numShards     uint32
+	templates     map[string]TopicTemplate
+	// processors holds, for each template name, the message processors mapped by their identifiers
+	processors map[string]map[string]p2p.MessageProcessor
+}
+
+// NewTopicManager creates a component able to subscribe to the topics built from the provided templates
+// for the self shard
+func NewTopicManager(args ArgsTopicManager) (*topicManager, error) {
+	err := checkArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	tm := &topicManager{
+		topicsHandler: args.TopicsHandler,
+		selfShardID:   args.SelfShardID,
+		numShards:     args.NumShards,
+		templates:     make(map[string]TopicTemplate, len(args.Templates)),
+		processors:    make(map[string]map[string]p2p.MessageProcessor, len(args.Templates)),
+	}
+	for _, template := range args.Templates {
+		tm.templates[template.Name] = template
+		tm.processors[template.Name] = make(map[string]p2p.MessageProcessor)
+	}
+
+	err = tm.createTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	return tm, nil
+}
+
+func checkArgs(args ArgsTopicManager) error {
+	if check.IfNil(args.TopicsHandler) {
+		return p2p.ErrNilTopicsHandler
+	}
+	if args.NumShards == 0 {
+		return fmt.Errorf("%w for NumShards, provided %d", p2p.ErrInvalidValue, args.NumShards)
+	}
+	err := checkShardID(args.SelfShardID, args.NumShards)
+	if err != nil {
+		return err
+	}
+
+	templateNames := make(map[string]struct{}, len(args.Templates))
+	for _, template := range args.Templates {
+		err = checkTopicTemplate(template)
+		if err != nil {
+			return err
+		}
+
+		_, found := templateNames[template.Name]
+		if found {
+			return fmt.Errorf("%w: %s", p2p.ErrDuplicatedTopicTemplate, template.Name)
+		}
+		templateNames[template.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func checkShardID(shardID uint32, numShards uint32) error {
+	if shardID < numShards || shardID == core.MetachainShardId {
+		return nil
+	}
+
+	return fmt.Errorf("%w %d, number of shards %d", p2p.ErrInvalidShardID, shardID, numShards)
+}
+
+// computeTopics returns the topic names, mapped to their template names, the node should be subscribed to
+// when being in the provided shard
+func (tm *topicManager) computeTopics(shardID uint32) map[string]string {
+	topics := make(map[string]string)
+	for templateName, template := range tm.templates {
+		for _, topic := range template.topicNames(shardID, tm.numShards) {
+			topics[topic] = templateName
+		}
+	}
+
+	return topics
+}
+
+// createTopics creates the topics of the self shard, the already created ones being left if one of the creations fails
+func (tm *topicManager) createTopics() error {
+	_, err := tm.createNewTopics(make(map[string]string), tm.computeTopics(tm.selfShardID))
+
+	return err
+}
+
+// RegisterMessageProcessor registers the message processor on all the topics the provided template expands to
+// for the current shard. The processor is automatically moved on the new topics whenever the shard ID changes.
+func (tm *topicManager) RegisterMessageProcessor(templateName string, identifier string, handler p2p.MessageProcessor) error {
+	if check.IfNil(handler) {
+		return fmt.Errorf("%w when calling topicManager.RegisterMessageProcessor for template %s",
+			p2p.ErrNilValidator, templateName)
+	}
+
+	tm.mut.Lock()
+	defer tm.mut.Unlock()
+
+	template, found := tm.templates[templateName]
+	if !found {
+		return fmt.Errorf("%w: %s", p2p.ErrUnknownTopicTemplate, templateName)
+	}
+
+	topics := template.topicNames(tm.selfShardID, tm.numShards)
+	for index, topic := range topics {
+		err := tm.topicsHandler.RegisterMessageProcessor(topic, identifier, handler)
+		if err != nil {
+			tm.unregisterMessageProcessor(topics[:index], identifier)
+			return err
+		}
+	}
+
+	tm.processors[templateName][identifier] = handler
+
+	return nil
+}
+
+// UnregisterMessageProcessor unregisters the message processor from all the topics the provided template expands to
+func (tm *topicManager) UnregisterMessageProcessor(templateName string, identifier string) error {
+	tm.mut.Lock()
+	defer tm.mut.Unlock()
+
+	template, found := tm.templates[templateName]
+	if !found {
+		return fmt.Errorf("%w: %s", p2p.ErrUnknownTopicTemplate, templateName)
+	}
+
+	var lastErr error
+	for _, topic := range template.topicNames(tm.selfShardID, tm.numShards) {
+		err := tm.topicsHandler.UnregisterMessageProcessor(topic, identifier)
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	delete(tm.processors[templateName], identifier)
+
+	return lastErr
+}
+
+func (tm *topicManager) unregisterMessageProcessor(topics []string, identifier string) {
+	for _, topic := range topics {
+		err := tm.topicsHandler.UnregisterMessageProcessor(topic, identifier)
+		if err != nil {
+			log.Warn("topicManager: error unregistering message processor",
+				"topic", topic,
+				"identifier", identifier,
+				"error", err,
+			)
+		}
+	}
+}
+
+// UpdateShardID migrates the subscriptions and the registered message processors on the topics of the new shard.
+// The topics of the new shard are created and the message processors are registered on them first, everything being
+// rolled back on failure, so the call does not alter the current subscriptions if that fails. Afterwards, the
+// obsolete topics are left, along with their processors.
+func (tm *topicManager) UpdateShardID(shardID uint32) error {
+	err := checkShardID(shardID, tm.numShards)
+	if err != nil {
+		return err
+	}
+
+	tm.mut.Lock()
+	defer tm.mut.Unlock()
+
+	if tm.selfShardID == shardID {
+		return nil
+	}
+
+	oldTopics := tm.computeTopics(tm.selfShardID)
+	newTopics := tm.computeTopics(shardID)
+
+	createdTopics, err := tm.createNewTopics(oldTopics, newTopics)
+	if err != nil {
+		return err
+	}
+
+	err = tm.registerProcessorsOnNewTopics(oldTopics, newTopics)
+	if err != nil {
+		tm.leaveTopics(createdTopics)
+		return err
+	}
+
+	obsoleteTopics := make([]string, 0)
+	for topic := range oldTopics {
+		_, found := newTopics[topic]
+		if !found {
+			obsoleteTopics = append(obsoleteTopics, topic)
+		}
+	}
+	tm.leaveTopics(obsoleteTopics)
+
+	log.Debug("topicManager: shard ID changed",
+		"old shard", core.GetShardIDString(tm.selfShardID),
+		"new shard", core.GetShardIDString(shardID),
+		"num topics", len(newTopics),
+	)
+	tm.selfShardID = shardID
+
+	return nil
+}
+
+// createNewTopics creates the topics of the new shard that are not already subscribed and returns them, so they can
+// be left if the migration fails. The already created topics are left if one of the creations fails
+func (tm *topicManager) createNewTopics(oldTopics map[string]string, newTopics map[string]string) ([]string, error) {
+	createdTopics := make([]string, 0)
+	for topic, templateName := range newTopics {
+		_, found := oldTopics[topic]
+		if found || tm.topicsHandler.HasTopic(topic) {
+			continue
+		}
+
+		template := tm.templates[templateName]
+		err := tm.topicsHandler.CreateTopic(topic, template.CreateChannelForTopic, template.Options...)
+		if err != nil {
+			tm.leaveTopics(createdTopics)
+			return nil, fmt.Errorf("%w while creating topic %s", err, topic)
+		}
+
+		createdTopics = append(createdTopics, topic)
+	}
+
+	return createdTopics, nil
+}
+
+func (tm *topicManager) leaveTopics(topics []string) {
+	for _, topic := range topics {
+		err := tm.topicsHandler.LeaveTopic(topic)
+		if err != nil {
+			log.Warn("topicManager: error leaving topic",
+				"topic", topic,
+				"error", err,
+			)
+		}
+	}
+}
+
+func (tm *topicManager) registerProcessorsOnNewTopics(oldTopics map[string]string, newTopics map[string]string) error {
+	type registration struct {
+		topic      string
+		identifier string
+	}
+
+	registered := make([]registration, 0)
+	for topic, templateName := range newTopics {
+		_, found := oldTopics[topic]
+		if found {
+			continue
+		}
+
+		for identifier, handler := range tm.processors[templateName] {
+			err := tm.topicsHandler.RegisterMessageProcessor(topic, identifier, handler)
+			if err != nil {
+				for _, reg := range registered {
+					tm.unregisterMessageProcessor([]string{reg.topic}, reg.identifier)
+				}
+
+				return fmt.Errorf("%w while registering processor %s on topic %s", err, identifier, topic)
+			}
+
+			registered = append(registered, registration{
+				topic:      topic,
+				identifier: identifier,
+			})
+		}
+	}
+
+	return nil
+}
+
+// SelfShardID returns the shard ID used to build the current topics
+func (tm *topicManager) SelfShardID() uint32 {
+	tm.mut.RLock()
+	defer tm.mut.RUnlock()
+
+	return tm.selfShardID
+}
+
+// Topics returns the sorted names of the topics the node should be currently subscribed to
+func (tm *topicManager) Topics() []string {
+	tm.mut.RLock()
+	topics := tm.computeTopics(tm.selfShardID)
+	tm.mut.RUnlock()
+
+	names := make([]string, 0, len(topics))
+	for topic := range topics {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// TopicsForTemplate returns the sorted names of the topics the provided template currently expands to
+func (tm *topicManager) TopicsForTemplate(templateName string) ([]string, error) {
+	tm.mut.RLock()
+	defer tm.mut.RUnlock()
+
+	template, found := tm.templates[templateName]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", p2p.ErrUnknownTopicTemplate, templateName)
+	}
+
+	names := template.topicNames(tm.selfShardID, tm.numShards)
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tm *topicManager) IsInterfaceNil() bool {
+	return tm == nil
+}
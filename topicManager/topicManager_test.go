@@ -0,0 +1,619 @@
+package topicManager_test
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+	"github.com/multiversx/mx-chain-p2p-go/mock"
+	"github.com/multiversx/mx-chain-p2p-go/topicManager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	intraTemplate = "transactions"
+	crossTemplate = "unsignedTransactions"
+	allTemplate   = "headers"
+)
+
+var expectedErr = errors.New("expected error")
+
+// topicsHandlerMock keeps track of the topics and processors, as a messenger would
+type topicsHandlerMock struct {
+	mut        sync.Mutex
+	topics     map[string]bool
+	processors map[string]map[string]p2p.MessageProcessor
+}
+
+func newTopicsHandlerMock() *topicsHandlerMock {
+	return &topicsHandlerMock{
+		topics:     make(map[string]bool),
+		processors: make(map[string]map[string]p2p.MessageProcessor),
+	}
+}
+
+func (thm *topicsHandlerMock) stub() *mock.TopicsHandlerStub {
+	return &mock.TopicsHandlerStub{
+		CreateTopicCalled: func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+			thm.mut.Lock()
+			thm.topics[name] = createChannelForTopic
+			thm.mut.Unlock()
+
+			return nil
+		},
+		HasTopicCalled: func(name string) bool {
+			thm.mut.Lock()
+			_, found := thm.topics[name]
+			thm.mut.Unlock()
+
+			return found
+		},
+		RegisterMessageProcessorCalled: func(topic string, identifier string, handler p2p.MessageProcessor) error {
+			thm.mut.Lock()
+			defer thm.mut.Unlock()
+
+			if thm.processors[topic] == nil {
+				thm.processors[topic] = make(map[string]p2p.MessageProcessor)
+			}
+			_, found := thm.processors[topic][identifier]
+			if found {
+				return p2p.ErrMessageProcessorAlreadyDefined
+			}
+			thm.processors[topic][identifier] = handler
+
+			return nil
+		},
+		UnregisterMessageProcessorCalled: func(topic string, identifier string) error {
+			thm.mut.Lock()
+			delete(thm.processors[topic], identifier)
+			if len(thm.processors[topic]) == 0 {
+				delete(thm.processors, topic)
+			}
+			thm.mut.Unlock()
+
+			return nil
+		},
+		LeaveTopicCalled: func(name string) error {
+			thm.mut.Lock()
+			delete(thm.topics, name)
+			delete(thm.processors, name)
+			thm.mut.Unlock()
+
+			return nil
+		},
+	}
+}
+
+func (thm *topicsHandlerMock) topicNames() []string {
+	thm.mut.Lock()
+	defer thm.mut.Unlock()
+
+	names := make([]string, 0, len(thm.topics))
+	for name := range thm.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (thm *topicsHandlerMock) processorTopics(identifier string) []string {
+	thm.mut.Lock()
+	defer thm.mut.Unlock()
+
+	topics := make([]string, 0)
+	for topic, processors := range thm.processors {
+		_, found := processors[identifier]
+		if found {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Strings(topics)
+
+	return topics
+}
+
+func createMockArgsTopicManager() topicManager.ArgsTopicManager {
+	return topicManager.ArgsTopicManager{
+		TopicsHandler: &mock.TopicsHandlerStub{},
+		SelfShardID:   0,
+		NumShards:     3,
+		Templates: []topicManager.TopicTemplate{
+			{
+				Name: intraTemplate,
+				Type: topicManager.IntraShardTopic,
+			},
+			{
+				Name:                  crossTemplate,
+				Type:                  topicManager.CrossShardTopic,
+				CreateChannelForTopic: true,
+			},
+			{
+				Name: allTemplate,
+				Type: topicManager.AllShardTopic,
+			},
+		},
+	}
+}
+
+func TestNewTopicManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil topics handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = nil
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.Equal(t, p2p.ErrNilTopicsHandler, err)
+	})
+	t.Run("zero number of shards should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.NumShards = 0
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidValue))
+	})
+	t.Run("invalid self shard ID should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.SelfShardID = args.NumShards
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidShardID))
+	})
+	t.Run("empty template name should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.Templates[1].Name = ""
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.Equal(t, p2p.ErrEmptyTopicTemplateName, err)
+	})
+	t.Run("invalid topic type should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.Templates[1].Type = topicManager.AllShardTopic + 1
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.True(t, errors.Is(err, p2p.ErrInvalidTopicType))
+	})
+	t.Run("duplicated template should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.Templates[2].Name = intraTemplate
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.True(t, errors.Is(err, p2p.ErrDuplicatedTopicTemplate))
+	})
+	t.Run("create topic errors should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = &mock.TopicsHandlerStub{
+			CreateTopicCalled: func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+				return expectedErr
+			},
+		}
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+	t.Run("create topic error should leave the already created topics", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		stub := handler.stub()
+		createHandler := stub.CreateTopicCalled
+		numCreateCalls := 0
+		stub.CreateTopicCalled = func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+			numCreateCalls++
+			if numCreateCalls > 2 {
+				return expectedErr
+			}
+
+			return createHandler(name, createChannelForTopic, options...)
+		}
+		leaveHandler := stub.LeaveTopicCalled
+		numLeaveCalls := 0
+		stub.LeaveTopicCalled = func(name string) error {
+			numLeaveCalls++
+			return leaveHandler(name)
+		}
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = stub
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Equal(t, 2, numLeaveCalls)
+		assert.Empty(t, handler.topicNames())
+	})
+	t.Run("already subscribed topics should not be left on error", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		stub := handler.stub()
+		_ = stub.CreateTopic(intraTemplate+"_0", false)
+		createHandler := stub.CreateTopicCalled
+		numCreateCalls := 0
+		stub.CreateTopicCalled = func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+			numCreateCalls++
+			if numCreateCalls > 1 {
+				return expectedErr
+			}
+
+			return createHandler(name, createChannelForTopic, options...)
+		}
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = stub
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.True(t, check.IfNil(tm))
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Equal(t, []string{intraTemplate + "_0"}, handler.topicNames())
+	})
+	t.Run("should create the shard topics", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = handler.stub()
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.False(t, check.IfNil(tm))
+		assert.Nil(t, err)
+		expectedTopics := []string{
+			"headers_ALL",
+			"transactions_0",
+			"unsignedTransactions_0_1",
+			"unsignedTransactions_0_2",
+			"unsignedTransactions_0_META",
+		}
+		assert.Equal(t, expectedTopics, handler.topicNames())
+		assert.Equal(t, expectedTopics, tm.Topics())
+		assert.False(t, handler.topics["transactions_0"])
+		assert.True(t, handler.topics["unsignedTransactions_0_META"])
+	})
+	t.Run("should create the metachain topics", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = handler.stub()
+		args.SelfShardID = core.MetachainShardId
+		tm, err := topicManager.NewTopicManager(args)
+
+		assert.Nil(t, err)
+		expectedTopics := []string{
+			"headers_ALL",
+			"transactions_META",
+			"unsignedTransactions_0_META",
+			"unsignedTransactions_1_META",
+			"unsignedTransactions_2_META",
+		}
+		assert.Equal(t, expectedTopics, handler.topicNames())
+		assert.Equal(t, core.MetachainShardId, tm.SelfShardID())
+	})
+	t.Run("should pass the topic options", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.Templates[0].Options = []p2p.TopicOption{p2p.WithCompression(p2p.SnappyCompression)}
+		compressions := make(map[string]string)
+		args.TopicsHandler = &mock.TopicsHandlerStub{
+			CreateTopicCalled: func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+				compressions[name] = p2p.NewTopicOptions(options...).Compression
+				return nil
+			},
+		}
+		_, err := topicManager.NewTopicManager(args)
+
+		assert.Nil(t, err)
+		assert.Equal(t, p2p.SnappyCompression, compressions["transactions_0"])
+		assert.Equal(t, p2p.NoCompression, compressions["headers_ALL"])
+	})
+}
+
+func TestTopicManager_RegisterMessageProcessor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		tm, _ := topicManager.NewTopicManager(createMockArgsTopicManager())
+		err := tm.RegisterMessageProcessor(intraTemplate, "id", nil)
+
+		assert.True(t, errors.Is(err, p2p.ErrNilValidator))
+	})
+	t.Run("unknown template should error", func(t *testing.T) {
+		t.Parallel()
+
+		tm, _ := topicManager.NewTopicManager(createMockArgsTopicManager())
+		err := tm.RegisterMessageProcessor("unknown", "id", &mock.MessageProcessorStub{})
+
+		assert.True(t, errors.Is(err, p2p.ErrUnknownTopicTemplate))
+	})
+	t.Run("register error should rollback", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		args := createMockArgsTopicManager()
+		stub := handler.stub()
+		registerHandler := stub.RegisterMessageProcessorCalled
+		stub.RegisterMessageProcessorCalled = func(topic string, identifier string, processor p2p.MessageProcessor) error {
+			if topic == "unsignedTransactions_0_META" {
+				return expectedErr
+			}
+
+			return registerHandler(topic, identifier, processor)
+		}
+		args.TopicsHandler = stub
+		tm, _ := topicManager.NewTopicManager(args)
+
+		err := tm.RegisterMessageProcessor(crossTemplate, "id", &mock.MessageProcessorStub{})
+		assert.Equal(t, expectedErr, err)
+		assert.Empty(t, handler.processorTopics("id"))
+	})
+	t.Run("should register on all template topics", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = handler.stub()
+		tm, _ := topicManager.NewTopicManager(args)
+
+		err := tm.RegisterMessageProcessor(crossTemplate, "id", &mock.MessageProcessorStub{})
+		assert.Nil(t, err)
+		expectedTopics := []string{"unsignedTransactions_0_1", "unsignedTransactions_0_2", "unsignedTransactions_0_META"}
+		assert.Equal(t, expectedTopics, handler.processorTopics("id"))
+
+		topics, err := tm.TopicsForTemplate(crossTemplate)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedTopics, topics)
+
+		err = tm.UnregisterMessageProcessor(crossTemplate, "id")
+		assert.Nil(t, err)
+		assert.Empty(t, handler.processorTopics("id"))
+	})
+}
+
+func TestTopicManager_UnregisterMessageProcessor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown template should error", func(t *testing.T) {
+		t.Parallel()
+
+		tm, _ := topicManager.NewTopicManager(createMockArgsTopicManager())
+		err := tm.UnregisterMessageProcessor("unknown", "id")
+
+		assert.True(t, errors.Is(err, p2p.ErrUnknownTopicTemplate))
+	})
+	t.Run("unregister error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = &mock.TopicsHandlerStub{
+			UnregisterMessageProcessorCalled: func(topic string, identifier string) error {
+				return expectedErr
+			},
+		}
+		tm, _ := topicManager.NewTopicManager(args)
+		err := tm.UnregisterMessageProcessor(intraTemplate, "id")
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestTopicManager_TopicsForTemplate(t *testing.T) {
+	t.Parallel()
+
+	tm, _ := topicManager.NewTopicManager(createMockArgsTopicManager())
+	topics, err := tm.TopicsForTemplate("unknown")
+
+	assert.Nil(t, topics)
+	assert.True(t, errors.Is(err, p2p.ErrUnknownTopicTemplate))
+}
+
+func TestTopicManager_UpdateShardID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid shard ID should error", func(t *testing.T) {
+		t.Parallel()
+
+		tm, _ := topicManager.NewTopicManager(createMockArgsTopicManager())
+		err := tm.UpdateShardID(core.AllShardId)
+
+		assert.True(t, errors.Is(err, p2p.ErrInvalidShardID))
+		assert.Equal(t, uint32(0), tm.SelfShardID())
+	})
+	t.Run("same shard ID should not alter the topics", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = &mock.TopicsHandlerStub{
+			LeaveTopicCalled: func(name string) error {
+				assert.Fail(t, "should have not called LeaveTopic")
+				return nil
+			},
+		}
+		tm, _ := topicManager.NewTopicManager(args)
+		err := tm.UpdateShardID(0)
+
+		assert.Nil(t, err)
+	})
+	t.Run("register error should not alter the subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		args := createMockArgsTopicManager()
+		stub := handler.stub()
+		registerHandler := stub.RegisterMessageProcessorCalled
+		stub.RegisterMessageProcessorCalled = func(topic string, identifier string, processor p2p.MessageProcessor) error {
+			if topic == "unsignedTransactions_1_2" {
+				return expectedErr
+			}
+
+			return registerHandler(topic, identifier, processor)
+		}
+		args.TopicsHandler = stub
+		tm, _ := topicManager.NewTopicManager(args)
+		_ = tm.RegisterMessageProcessor(intraTemplate, "id", &mock.MessageProcessorStub{})
+		_ = tm.RegisterMessageProcessor(crossTemplate, "id", &mock.MessageProcessorStub{})
+		expectedProcessorTopics := handler.processorTopics("id")
+		expectedTopics := handler.topicNames()
+
+		err := tm.UpdateShardID(1)
+
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Equal(t, uint32(0), tm.SelfShardID())
+		assert.Equal(t, expectedProcessorTopics, handler.processorTopics("id"))
+		assert.Equal(t, expectedTopics, handler.topicNames())
+		assert.Equal(t, expectedTopics, tm.Topics())
+	})
+	t.Run("create topic error should not alter the subscriptions", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		args := createMockArgsTopicManager()
+		stub := handler.stub()
+		args.TopicsHandler = stub
+		tm, _ := topicManager.NewTopicManager(args)
+		_ = tm.RegisterMessageProcessor(intraTemplate, "id", &mock.MessageProcessorStub{})
+		_ = tm.RegisterMessageProcessor(crossTemplate, "id", &mock.MessageProcessorStub{})
+		expectedProcessorTopics := handler.processorTopics("id")
+		expectedTopics := handler.topicNames()
+
+		createHandler := stub.CreateTopicCalled
+		numCreateCalls := 0
+		stub.CreateTopicCalled = func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+			numCreateCalls++
+			if numCreateCalls > 1 {
+				return expectedErr
+			}
+
+			return createHandler(name, createChannelForTopic, options...)
+		}
+		leaveHandler := stub.LeaveTopicCalled
+		leftTopics := make([]string, 0)
+		stub.LeaveTopicCalled = func(name string) error {
+			leftTopics = append(leftTopics, name)
+			return leaveHandler(name)
+		}
+
+		err := tm.UpdateShardID(1)
+
+		assert.True(t, errors.Is(err, expectedErr))
+		assert.Equal(t, uint32(0), tm.SelfShardID())
+		assert.Equal(t, expectedProcessorTopics, handler.processorTopics("id"))
+		assert.Equal(t, expectedTopics, handler.topicNames())
+		assert.Equal(t, expectedTopics, tm.Topics())
+		// only the topic created during the failed migration was left
+		require.Equal(t, 1, len(leftTopics))
+		assert.NotContains(t, expectedTopics, leftTopics[0])
+	})
+	t.Run("should migrate the subscriptions and the processors", func(t *testing.T) {
+		t.Parallel()
+
+		handler := newTopicsHandlerMock()
+		args := createMockArgsTopicManager()
+		args.TopicsHandler = handler.stub()
+		tm, _ := topicManager.NewTopicManager(args)
+		_ = tm.RegisterMessageProcessor(intraTemplate, "intra", &mock.MessageProcessorStub{})
+		_ = tm.RegisterMessageProcessor(crossTemplate, "cross", &mock.MessageProcessorStub{})
+		_ = tm.RegisterMessageProcessor(allTemplate, "all", &mock.MessageProcessorStub{})
+
+		err := tm.UpdateShardID(1)
+		require.Nil(t, err)
+
+		expectedTopics := []string{
+			"headers_ALL",
+			"transactions_1",
+			"unsignedTransactions_0_1",
+			"unsignedTransactions_1_2",
+			"unsignedTransactions_1_META",
+		}
+		assert.Equal(t, uint32(1), tm.SelfShardID())
+		assert.Equal(t, expectedTopics, handler.topicNames())
+		assert.Equal(t, expectedTopics, tm.Topics())
+		assert.Equal(t, []string{"transactions_1"}, handler.processorTopics("intra"))
+		assert.Equal(t, []string{"unsignedTransactions_0_1", "unsignedTransactions_1_2", "unsignedTransactions_1_META"},
+			handler.processorTopics("cross"))
+		assert.Equal(t, []string{"headers_ALL"}, handler.processorTopics("all"))
+
+		err = tm.UpdateShardID(core.MetachainShardId)
+		require.Nil(t, err)
+
+		assert.Equal(t, []string{"transactions_META"}, handler.processorTopics("intra"))
+		assert.Equal(t, []string{"unsignedTransactions_0_META", "unsignedTransactions_1_META", "unsignedTransactions_2_META"},
+			handler.processorTopics("cross"))
+	})
+	t.Run("create topic error should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsTopicManager()
+		tm, _ := topicManager.NewTopicManager(args)
+		args.TopicsHandler.(*mock.TopicsHandlerStub).CreateTopicCalled = func(name string, createChannelForTopic bool, options ...p2p.TopicOption) error {
+			return expectedErr
+		}
+
+		err := tm.UpdateShardID(2)
+		assert.True(t, errors.Is(err, expectedErr))
+	})
+}
+
+func TestTopicManager_ConcurrentOperations(t *testing.T) {
+	t.Parallel()
+
+	handler := newTopicsHandlerMock()
+	args := createMockArgsTopicManager()
+	args.TopicsHandler = handler.stub()
+	tm, _ := topicManager.NewTopicManager(args)
+
+	numCalls := 100
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			identifier := fmt.Sprintf("id%d", idx)
+			switch idx % 6 {
+			case 0:
+				_ = tm.UpdateShardID(uint32(idx % 3))
+			case 1:
+				_ = tm.RegisterMessageProcessor(crossTemplate, identifier, &mock.MessageProcessorStub{})
+			case 2:
+				_ = tm.UnregisterMessageProcessor(crossTemplate, identifier)
+			case 3:
+				_ = tm.Topics()
+			case 4:
+				_, _ = tm.TopicsForTemplate(intraTemplate)
+			case 5:
+				_ = tm.SelfShardID()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
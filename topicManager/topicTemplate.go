@@ -0,0 +1,79 @@
+package topicManager
+
+import (
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	p2p "github.com/multiversx/mx-chain-p2p-go"
+)
+
+// TopicType defines how a topic template is expanded into topic names
+type TopicType uint8
+
+const (
+	// IntraShardTopic expands into a single topic shared by the nodes of the same shard, e.g. transactions_0
+	IntraShardTopic TopicType = iota
+	// CrossShardTopic expands into one topic for each pair made of the self shard and any other shard
+	// (metachain included), e.g. transactions_0_1, transactions_0_META
+	CrossShardTopic
+	// AllShardTopic expands into a single topic shared by all the nodes, e.g. transactions_ALL
+	AllShardTopic
+)
+
+// String returns the human-readable representation of the topic type
+func (tt TopicType) String() string {
+	switch tt {
+	case IntraShardTopic:
+		return "intra-shard"
+	case CrossShardTopic:
+		return "cross-shard"
+	case AllShardTopic:
+		return "all-shard"
+	default:
+		return fmt.Sprintf("unknown topic type %d", tt)
+	}
+}
+
+// TopicTemplate defines a family of topics that the node should be subscribed to, depending on its shard ID
+type TopicTemplate struct {
+	Name                  string
+	Type                  TopicType
+	CreateChannelForTopic bool
+	Options               []p2p.TopicOption
+}
+
+// topicNames returns the topic names obtained by expanding the template for the provided shard configuration
+func (template TopicTemplate) topicNames(selfShardID uint32, numShards uint32) []string {
+	switch template.Type {
+	case IntraShardTopic:
+		return []string{template.Name + core.CommunicationIdentifierBetweenShards(selfShardID, selfShardID)}
+	case CrossShardTopic:
+		names := make([]string, 0, numShards)
+		for shardID := uint32(0); shardID < numShards; shardID++ {
+			if shardID == selfShardID {
+				continue
+			}
+			names = append(names, template.Name+core.CommunicationIdentifierBetweenShards(selfShardID, shardID))
+		}
+		if selfShardID != core.MetachainShardId {
+			names = append(names, template.Name+core.CommunicationIdentifierBetweenShards(selfShardID, core.MetachainShardId))
+		}
+
+		return names
+	case AllShardTopic:
+		return []string{template.Name + core.CommunicationIdentifierBetweenShards(selfShardID, core.AllShardId)}
+	default:
+		return nil
+	}
+}
+
+func checkTopicTemplate(template TopicTemplate) error {
+	if len(template.Name) == 0 {
+		return p2p.ErrEmptyTopicTemplateName
+	}
+	if template.Type > AllShardTopic {
+		return fmt.Errorf("%w %d for template %s", p2p.ErrInvalidTopicType, template.Type, template.Name)
+	}
+
+	return nil
+}
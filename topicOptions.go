@@ -0,0 +1,82 @@
+package p2p
+
+const (
+	defaultOutgoingChannelPriority  = 0
+	defaultOutgoingChannelWeight    = 1
+	defaultOutgoingChannelQueueSize = 1000
+)
+
+// TopicOptions holds the settings that can be customized when creating a topic
+type TopicOptions struct {
+	Compression     string
+	OutgoingChannel OutgoingChannelOptions
+}
+
+// OutgoingChannelOptions holds the settings used when scheduling the messages broadcast on the channel of a topic
+type OutgoingChannelOptions struct {
+	// Priority - the messages of a channel are sent only if all the channels with a higher priority are empty
+	Priority uint32
+	// Weight - the share of the sends a channel gets among the non-empty channels with the same priority
+	Weight uint32
+	// QueueSize - the maximum number of messages waiting to be sent on the channel
+	QueueSize uint32
+	// DropPolicy - decides which message is dropped when the queue is full
+	DropPolicy string
+}
+
+// DefaultOutgoingChannelOptions returns the settings used for the outgoing channels if not customized
+func DefaultOutgoingChannelOptions() OutgoingChannelOptions {
+	return OutgoingChannelOptions{
+		Priority:   defaultOutgoingChannelPriority,
+		Weight:     defaultOutgoingChannelWeight,
+		QueueSize:  defaultOutgoingChannelQueueSize,
+		DropPolicy: DropNewestPolicy,
+	}
+}
+
+// TopicOption defines a function able to alter the settings of a topic
+type TopicOption func(options *TopicOptions)
+
+// NewTopicOptions returns the topic settings obtained after applying all provided options on the default settings
+func NewTopicOptions(options ...TopicOption) *TopicOptions {
+	topicOptions := &TopicOptions{
+		Compression:     NoCompression,
+		OutgoingChannel: DefaultOutgoingChannelOptions(),
+	}
+
+	for _, option := range options {
+		if option != nil {
+			option(topicOptions)
+		}
+	}
+
+	return topicOptions
+}
+
+// WithCompression sets the compression type used for the messages sent on the topic
+// Receivers decompress the messages transparently, regardless of their own setting on the topic. The messages are
+// compressed only after the topic compression is enabled network-wide, otherwise they are sent uncompressed
+func WithCompression(compressionType string) TopicOption {
+	return func(options *TopicOptions) {
+		options.Compression = compressionType
+	}
+}
+
+// WithPriority sets the priority and the weight of the outgoing channel created for the topic
+// Higher priority channels are always served first, while the channels with the same priority share the sends
+// proportionally to their weights
+func WithPriority(priority uint32, weight uint32) TopicOption {
+	return func(options *TopicOptions) {
+		options.OutgoingChannel.Priority = priority
+		options.OutgoingChannel.Weight = weight
+	}
+}
+
+// WithOutgoingQueue sets the size of the outgoing channel queue created for the topic and the policy applied
+// when the queue is full
+func WithOutgoingQueue(size uint32, dropPolicy string) TopicOption {
+	return func(options *TopicOptions) {
+		options.OutgoingChannel.QueueSize = size
+		options.OutgoingChannel.DropPolicy = dropPolicy
+	}
+}